@@ -8,14 +8,19 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"strconv"
+	"strings"
+	"sync"
 	"syscall"
 	"time"
 
+	"reviewer-service/internal/domain/entity"
 	"reviewer-service/internal/domain/service"
 	httphandler "reviewer-service/internal/http"
 	"reviewer-service/internal/http/handler"
 	"reviewer-service/internal/repository/postgres"
 	"reviewer-service/internal/usecase"
+	"reviewer-service/internal/worker"
 
 	_ "github.com/lib/pq"
 	"github.com/rs/zerolog"
@@ -34,6 +39,29 @@ func main() {
 	dbUser := getEnv("DB_USER", "postgres")
 	dbPass := getEnv("DB_PASS", "postgres")
 	port := getEnv("PORT", "8080")
+	defaultStrategy := getEnv("REVIEW_STRATEGY", service.StrategyLeastLoaded)
+	teamStrategies := getEnv("REVIEW_TEAM_STRATEGIES", "")
+	tieBreakSeed, err := strconv.ParseUint(getEnv("REVIEW_TIEBREAK_SEED", "0"), 10, 64)
+	if err != nil {
+		log.Fatal().Err(err).Msg("invalid REVIEW_TIEBREAK_SEED")
+	}
+	sizeBuckets, err := entity.ParseSizeBuckets(getEnv("REVIEW_SIZE_BUCKETS", ""))
+	if err != nil {
+		log.Fatal().Err(err).Msg("invalid REVIEW_SIZE_BUCKETS")
+	}
+	adminToken := getEnv("ADMIN_TOKEN", "")
+	absenceInterval, err := time.ParseDuration(getEnv("ABSENCE_CHECK_INTERVAL", "1m"))
+	if err != nil {
+		log.Fatal().Err(err).Msg("invalid ABSENCE_CHECK_INTERVAL")
+	}
+	escalationInterval, err := time.ParseDuration(getEnv("ESCALATION_CHECK_INTERVAL", "5m"))
+	if err != nil {
+		log.Fatal().Err(err).Msg("invalid ESCALATION_CHECK_INTERVAL")
+	}
+	topUpInterval, err := time.ParseDuration(getEnv("TOPUP_CHECK_INTERVAL", "1m"))
+	if err != nil {
+		log.Fatal().Err(err).Msg("invalid TOPUP_CHECK_INTERVAL")
+	}
 
 	// Connect to DB
 	dsn := fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=%s sslmode=disable",
@@ -65,17 +93,23 @@ func main() {
 
 	// Initialize layers
 	txManager := postgres.NewTxManager(db)
+	txManager.SetSizeBuckets(sizeBuckets)
 	selector := service.NewReviewerSelector()
+	if err := configureStrategies(selector, defaultStrategy, teamStrategies); err != nil {
+		log.Fatal().Err(err).Msg("invalid selection strategy config")
+	}
+	selector.SetSeed(tieBreakSeed)
 
-	teamUC := usecase.NewTeamUseCase(txManager)
-	userUC := usecase.NewUserUseCase(txManager)
+	teamUC := usecase.NewTeamUseCase(txManager, selector)
+	userUC := usecase.NewUserUseCase(txManager, selector)
 	prUC := usecase.NewPullRequestUseCase(txManager, selector)
+	prUC.SetNotifier(logNotifier{})
 
 	teamHandler := handler.NewTeamHandler(teamUC)
 	userHandler := handler.NewUserHandler(userUC)
 	prHandler := handler.NewPullRequestHandler(prUC)
 
-	router := httphandler.NewRouter(teamHandler, userHandler, prHandler)
+	router := httphandler.NewRouter(teamHandler, userHandler, prHandler, adminToken)
 
 	// HTTP Server
 	srv := &http.Server{
@@ -86,6 +120,24 @@ func main() {
 		IdleTimeout:  60 * time.Second,
 	}
 
+	// Background jobs
+	jobsCtx, stopJobs := context.WithCancel(context.Background())
+	defer stopJobs()
+
+	// Wait for jobs on shutdown so the DB is not closed mid-transaction
+	var jobs sync.WaitGroup
+	runJob := func(name string, interval time.Duration, job worker.Job) {
+		jobs.Add(1)
+		go func() {
+			defer jobs.Done()
+			worker.Run(jobsCtx, name, interval, job)
+		}()
+	}
+
+	runJob("absences", absenceInterval, userUC.ApplyAbsences)
+	runJob("escalations", escalationInterval, prUC.ApplyEscalations)
+	runJob("topups", topUpInterval, prUC.TopUpReviewers)
+
 	// Start server
 	go func() {
 		log.Info().Str("port", port).Msg("starting http server")
@@ -100,6 +152,7 @@ func main() {
 	<-quit
 
 	log.Info().Msg("shutting down server...")
+	stopJobs()
 
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
@@ -107,10 +160,51 @@ func main() {
 	if err := srv.Shutdown(ctx); err != nil {
 		log.Fatal().Err(err).Msg("server forced to shutdown")
 	}
+	jobs.Wait()
 
 	log.Info().Msg("server exited")
 }
 
+// configureStrategies применяет стратегию по умолчанию и
+// переопределения по командам в формате "team:strategy,team:strategy"
+func configureStrategies(selector *service.ReviewerSelector, defaultStrategy, teamStrategies string) error {
+	if err := selector.SetDefaultStrategy(defaultStrategy); err != nil {
+		return err
+	}
+
+	for _, pair := range strings.Split(teamStrategies, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+
+		team, strategy, ok := strings.Cut(pair, ":")
+		if !ok {
+			return fmt.Errorf("invalid team strategy %q, expected team:strategy", pair)
+		}
+
+		if err := selector.SetTeamStrategy(strings.TrimSpace(team), strings.TrimSpace(strategy)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// logNotifier пишет уведомления об эскалациях в лог
+type logNotifier struct{}
+
+func (logNotifier) Notify(ctx context.Context, e *entity.ReviewEscalation) error {
+	log.Warn().
+		Str("pull_request_id", e.PullRequestID).
+		Str("reviewer_id", e.UserID).
+		Str("action", string(e.Action)).
+		Int("step", e.Step).
+		Str("recipient_id", e.RecipientID).
+		Msg("review SLA breached")
+	return nil
+}
+
 func getEnv(key, defaultValue string) string {
 	if value := os.Getenv(key); value != "" {
 		return value
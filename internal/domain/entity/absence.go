@@ -0,0 +1,34 @@
+package entity
+
+import "time"
+
+// AbsenceStatus - состояние периода отсутствия
+type AbsenceStatus string
+
+const (
+	AbsenceScheduled AbsenceStatus = "SCHEDULED" // ещё не начался
+	AbsenceActive    AbsenceStatus = "ACTIVE"    // пользователь отсутствует
+	AbsenceFinished  AbsenceStatus = "FINISHED"  // пользователь вернулся
+)
+
+// Absence - запланированное отсутствие (отпуск, больничный, командировка)
+type Absence struct {
+	ID          int64         `json:"absence_id"`
+	UserID      string        `json:"user_id"`
+	StartsAt    time.Time     `json:"starts_at"`
+	EndsAt      time.Time     `json:"ends_at"`
+	Reason      string        `json:"reason"`
+	Status      AbsenceStatus `json:"status"`
+	Deactivated bool          `json:"-"` // is_active выключил планировщик
+	CreatedAt   time.Time     `json:"created_at"`
+}
+
+// Overlaps проверяет, пересекаются ли периоды
+func (a *Absence) Overlaps(other *Absence) bool {
+	return a.StartsAt.Before(other.EndsAt) && other.StartsAt.Before(a.EndsAt)
+}
+
+// IsPending - период ещё не завершён
+func (a *Absence) IsPending() bool {
+	return a.Status == AbsenceScheduled || a.Status == AbsenceActive
+}
@@ -0,0 +1,32 @@
+package entity
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAbsence_Overlaps(t *testing.T) {
+	base := time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC)
+	day := 24 * time.Hour
+
+	vacation := &Absence{StartsAt: base, EndsAt: base.Add(14 * day)}
+
+	tests := []struct {
+		name     string
+		other    *Absence
+		expected bool
+	}{
+		{"Inside", &Absence{StartsAt: base.Add(day), EndsAt: base.Add(2 * day)}, true},
+		{"Overlaps end", &Absence{StartsAt: base.Add(13 * day), EndsAt: base.Add(20 * day)}, true},
+		{"Adjacent", &Absence{StartsAt: base.Add(14 * day), EndsAt: base.Add(15 * day)}, false},
+		{"Before", &Absence{StartsAt: base.Add(-3 * day), EndsAt: base.Add(-day)}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := vacation.Overlaps(tt.other); result != tt.expected {
+				t.Errorf("Overlaps() = %v, want %v", result, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,30 @@
+package entity
+
+import "time"
+
+// OwnerType - тип владельца пути
+type OwnerType string
+
+const (
+	OwnerUser OwnerType = "USER"
+	OwnerTeam OwnerType = "TEAM"
+)
+
+// CodeOwner - владелец пути из CODEOWNERS: пользователь или команда целиком
+type CodeOwner struct {
+	Type OwnerType `json:"type"`
+	Name string    `json:"name"`
+}
+
+// CodeOwnersRule - строка CODEOWNERS: шаблон пути и его владельцы
+type CodeOwnersRule struct {
+	Pattern string      `json:"pattern"`
+	Owners  []CodeOwner `json:"owners"`
+}
+
+// CodeOwnersFile - загруженный командой файл владения
+type CodeOwnersFile struct {
+	TeamName  string    `json:"team_name"`
+	Content   string    `json:"content"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
@@ -0,0 +1,21 @@
+package entity
+
+// ReviewerSwap - ревьювер OldUserID заменён на NewUserID
+type ReviewerSwap struct {
+	OldUserID string `json:"old_user_id"`
+	NewUserID string `json:"new_user_id"`
+}
+
+// PRReassignment - что стало с ревьюверами PR после выключения пользователей
+type PRReassignment struct {
+	PullRequestID string         `json:"pull_request_id"`
+	Reassigned    []ReviewerSwap `json:"reassigned"`
+	NotReassigned []string       `json:"not_reassigned"` // замены не нашлось, ревьювер остался на PR
+	Blocking      []string       `json:"blocking"`       // запросил изменения и остался на PR, чтобы не снять блокировку
+}
+
+// BulkDeactivation - результат массового выключения пользователей
+type BulkDeactivation struct {
+	Deactivated  []string          `json:"deactivated"`
+	PullRequests []*PRReassignment `json:"pull_requests"`
+}
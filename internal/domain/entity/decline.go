@@ -0,0 +1,33 @@
+package entity
+
+import "time"
+
+// DeclineReason - почему ревьювер отказался от ревью
+type DeclineReason string
+
+const (
+	DeclineNoContext   DeclineReason = "NO_CONTEXT"    // не знает эту часть кода
+	DeclineConflict    DeclineReason = "CONFLICT"      // конфликт интересов
+	DeclineOverloaded  DeclineReason = "OVERLOADED"    // нет времени
+	DeclineOutOfOffice DeclineReason = "OUT_OF_OFFICE" // отсутствует
+)
+
+// Valid проверяет, что причина отказа известна
+func (r DeclineReason) Valid() bool {
+	switch r {
+	case DeclineNoContext, DeclineConflict, DeclineOverloaded, DeclineOutOfOffice:
+		return true
+	}
+	return false
+}
+
+// ReviewDecline - отказ ревьювера от ревью PR
+type ReviewDecline struct {
+	ID            int64         `json:"decline_id"`
+	PullRequestID string        `json:"pull_request_id"`
+	UserID        string        `json:"user_id"`
+	Reason        DeclineReason `json:"reason"`
+	Comment       string        `json:"comment,omitempty"`
+	ReplacedBy    string        `json:"replaced_by,omitempty"` // пусто, если замены не нашлось
+	CreatedAt     time.Time     `json:"created_at"`
+}
@@ -0,0 +1,24 @@
+package entity
+
+import "testing"
+
+func TestDeclineReason_Valid(t *testing.T) {
+	tests := []struct {
+		reason DeclineReason
+		want   bool
+	}{
+		{DeclineNoContext, true},
+		{DeclineConflict, true},
+		{DeclineOverloaded, true},
+		{DeclineOutOfOffice, true},
+		{"", false},
+		{"no_context", false},
+		{"BUSY", false},
+	}
+
+	for _, tt := range tests {
+		if got := tt.reason.Valid(); got != tt.want {
+			t.Errorf("DeclineReason(%q).Valid() = %v, want %v", tt.reason, got, tt.want)
+		}
+	}
+}
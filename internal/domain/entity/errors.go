@@ -11,4 +11,6 @@ var (
 	ErrPRMerged     = errors.New("cannot modify merged pull request")
 	ErrNotAssigned  = errors.New("user is not assigned as reviewer")
 	ErrNoCandidate  = errors.New("no suitable reviewer candidate found")
+
+	ErrInvalidTransition = errors.New("invalid pull request status transition")
 )
@@ -0,0 +1,47 @@
+package entity
+
+import "time"
+
+// EscalationAction - шаг эскалации просроченного ревью
+type EscalationAction string
+
+const (
+	EscalationNotify    EscalationAction = "NOTIFY"     // напомнить ревьюверу
+	EscalationReassign  EscalationAction = "REASSIGN"   // передать ревью другому
+	EscalationAlertLead EscalationAction = "ALERT_LEAD" // сообщить лиду команды
+)
+
+// DefaultEscalationLadder - шаги эскалации, если политика их не задаёт
+func DefaultEscalationLadder() []EscalationAction {
+	return []EscalationAction{EscalationNotify, EscalationReassign, EscalationAlertLead}
+}
+
+// Valid проверяет, что шаг эскалации известен
+func (a EscalationAction) Valid() bool {
+	switch a {
+	case EscalationNotify, EscalationReassign, EscalationAlertLead:
+		return true
+	}
+	return false
+}
+
+// ReviewAssignment - назначение ревьювера на открытый PR
+type ReviewAssignment struct {
+	PullRequestID  string
+	UserID         string
+	TeamName       string    // команда, которая ревьюит PR; её политика задаёт SLA
+	SLAStart       time.Time // назначение плюс время, пока PR был закрыт
+	EscalationStep int       // сколько шагов эскалации уже сделано
+}
+
+// ReviewEscalation - выполненный шаг эскалации
+type ReviewEscalation struct {
+	ID            int64            `json:"escalation_id"`
+	PullRequestID string           `json:"pull_request_id"`
+	UserID        string           `json:"user_id"` // ревьювер, нарушивший SLA
+	Action        EscalationAction `json:"action"`
+	Step          int              `json:"step"`                   // номер шага, с 1
+	RecipientID   string           `json:"recipient_id,omitempty"` // кому ушло уведомление
+	ReplacedBy    string           `json:"replaced_by,omitempty"`  // для REASSIGN: кто получил ревью
+	CreatedAt     time.Time        `json:"created_at"`
+}
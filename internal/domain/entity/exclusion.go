@@ -0,0 +1,44 @@
+package entity
+
+import "time"
+
+// ReviewExclusion - пара, в которой ReviewerID не должен ревьюить PR AuthorID
+// (конфликт интересов, руководитель и подчинённый). BothWays запрещает и обратное.
+type ReviewExclusion struct {
+	ID         int64      `json:"exclusion_id"`
+	AuthorID   string     `json:"author_id"`
+	ReviewerID string     `json:"reviewer_id"`
+	BothWays   bool       `json:"both_ways"`
+	Reason     string     `json:"reason"`
+	ExpiresAt  *time.Time `json:"expires_at,omitempty"` // nil = бессрочно
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+// IsActive проверяет, что правило ещё действует
+func (e *ReviewExclusion) IsActive(now time.Time) bool {
+	return e.ExpiresAt == nil || e.ExpiresAt.After(now)
+}
+
+// Blocks проверяет, запрещает ли правило reviewerID ревьюить PR authorID
+func (e *ReviewExclusion) Blocks(authorID, reviewerID string, now time.Time) bool {
+	if !e.IsActive(now) {
+		return false
+	}
+	if e.AuthorID == authorID && e.ReviewerID == reviewerID {
+		return true
+	}
+	return e.BothWays && e.AuthorID == reviewerID && e.ReviewerID == authorID
+}
+
+// BlockedReviewers возвращает пользователей, которым правила запрещают ревьюить PR автора
+func BlockedReviewers(exclusions []*ReviewExclusion, authorID string, now time.Time) map[string]bool {
+	blocked := make(map[string]bool)
+	for _, e := range exclusions {
+		for _, id := range []string{e.ReviewerID, e.AuthorID} {
+			if e.Blocks(authorID, id, now) {
+				blocked[id] = true
+			}
+		}
+	}
+	return blocked
+}
@@ -0,0 +1,50 @@
+package entity
+
+import (
+	"testing"
+	"time"
+)
+
+func TestReviewExclusion_Blocks(t *testing.T) {
+	now := time.Date(2024, 7, 1, 12, 0, 0, 0, time.UTC)
+	past := now.Add(-time.Hour)
+	future := now.Add(time.Hour)
+
+	tests := []struct {
+		name     string
+		rule     ReviewExclusion
+		author   string
+		reviewer string
+		want     bool
+	}{
+		{"One-way forward", ReviewExclusion{AuthorID: "a", ReviewerID: "b"}, "a", "b", true},
+		{"One-way reverse allowed", ReviewExclusion{AuthorID: "a", ReviewerID: "b"}, "b", "a", false},
+		{"Both ways reverse", ReviewExclusion{AuthorID: "a", ReviewerID: "b", BothWays: true}, "b", "a", true},
+		{"Other pair", ReviewExclusion{AuthorID: "a", ReviewerID: "b", BothWays: true}, "a", "c", false},
+		{"Not expired", ReviewExclusion{AuthorID: "a", ReviewerID: "b", ExpiresAt: &future}, "a", "b", true},
+		{"Expired", ReviewExclusion{AuthorID: "a", ReviewerID: "b", ExpiresAt: &past}, "a", "b", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.rule.Blocks(tt.author, tt.reviewer, now); got != tt.want {
+				t.Errorf("Blocks() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBlockedReviewers(t *testing.T) {
+	now := time.Date(2024, 7, 1, 12, 0, 0, 0, time.UTC)
+
+	rules := []*ReviewExclusion{
+		{AuthorID: "a", ReviewerID: "b"},
+		{AuthorID: "c", ReviewerID: "a", BothWays: true},
+		{AuthorID: "d", ReviewerID: "a"},
+	}
+
+	blocked := BlockedReviewers(rules, "a", now)
+	if len(blocked) != 2 || !blocked["b"] || !blocked["c"] {
+		t.Errorf("BlockedReviewers() = %v, want [b c]", blocked)
+	}
+}
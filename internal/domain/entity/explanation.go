@@ -0,0 +1,65 @@
+package entity
+
+// Причины, по которым кандидат не может быть ревьювером
+const (
+	ExcludedInactive   = "inactive"       // выключен или в отпуске
+	ExcludedAuthor     = "author"         // автор PR
+	ExcludedAssigned   = "assigned"       // уже назначен или исключён явно
+	ExcludedAtCapacity = "at_capacity"    // исчерпан лимит открытых ревью
+	ExcludedOverloaded = "overloaded"     // исчерпан лимит нагрузки с весами по размеру PR
+	ExcludedOffHours   = "off_hours"      // вне рабочих часов дольше порога политики
+	ExcludedNotOwner   = "not_owner"      // режим REQUIRE, а кандидат не владелец путей
+	ExcludedByRule     = "exclusion_rule" // правило исключения пары с автором
+)
+
+// Причины, по которым пробный подбор никого не выбрал
+const (
+	UnselectedAtCapacity       = "at_capacity"       // все кандидаты упёрлись в лимиты
+	UnselectedOwnerUnavailable = "owner_unavailable" // режим REQUIRE, а владельцев путей назначить нельзя
+	UnselectedExcludedByRule   = "exclusion_rule"    // всех кандидатов отсеяли правила исключения пар
+	UnselectedNoCandidate      = "no_candidate"      // подходящих кандидатов нет
+)
+
+// Правила политики, сработавшие для кандидата
+const (
+	RuleCodeOwner     = "codeowner"           // владелец затронутых путей
+	RuleLabelPrefix   = "label:"              // закрывает метку PR
+	RuleWorkingHours  = "working_hours"       // на работе или скоро будет
+	RuleFallbackTeam  = "fallback_team"       // из резервной команды
+	RulePersonalLimit = "personal_review_cap" // действует личный лимит или неполная ставка
+	RuleSenior        = "senior"              // закрывает требование старшего ревьювера
+	RuleLearner       = "learning_reviewer"   // может быть учебным ревьювером
+	RuleAntiAffinity  = "anti_affinity"       // опущен: недавно часто ревьюил автора
+)
+
+// CandidateExplanation - почему кандидат выбран, пропущен или исключён
+type CandidateExplanation struct {
+	UserID              string   `json:"user_id"`
+	TeamName            string   `json:"team_name"`
+	Rank                int      `json:"rank,omitempty"` // место среди допущенных, 1 = лучший
+	Selected            bool     `json:"selected"`
+	OpenReviews         int      `json:"open_reviews"`
+	ReviewLimit         int      `json:"review_limit"`  // 0 = без ограничения
+	WeightedLoad        int      `json:"weighted_load"` // открытые ревью с весами по размеру PR
+	OffHoursWaitSeconds int64    `json:"off_hours_wait_seconds"`
+	RecentPairReviews   int      `json:"recent_pair_reviews"` // ревью PR автора за окно anti-affinity
+	TieBreak            string   `json:"tie_break"`           // ключ разрешения равенства, меньше = выше
+	Excluded            string   `json:"excluded,omitempty"`
+	Rules               []string `json:"rules"`
+}
+
+// SelectionExplanation - результат пробного подбора ревьюверов
+type SelectionExplanation struct {
+	PullRequestID     string                  `json:"pull_request_id"`
+	TeamName          string                  `json:"team_name"`
+	AuthorID          string                  `json:"author_id"`
+	Labels            []string                `json:"labels"`
+	ChangedFiles      []string                `json:"changed_files"`
+	Strategy          string                  `json:"strategy"`
+	Policy            *TeamPolicy             `json:"policy"`
+	Selected          []string                `json:"selected"`
+	Mandatory         []string                `json:"mandatory"`            // обязательные ревьюверы сверх выбора
+	AwaitingReviewers bool                    `json:"awaiting_reviewers"`   // все упёрлись в лимиты
+	Unselected        string                  `json:"unselected,omitempty"` // почему никого не выбрали (Unselected*)
+	Candidates        []*CandidateExplanation `json:"candidates"`
+}
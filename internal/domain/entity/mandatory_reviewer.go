@@ -0,0 +1,28 @@
+package entity
+
+import "time"
+
+// MandatoryReviewer - пользователь, которого команда добавляет на свои PR
+// сверх сбалансированного выбора (техлид, релиз-менеджер)
+type MandatoryReviewer struct {
+	TeamName  string    `json:"team_name"`
+	UserID    string    `json:"user_id"`
+	Labels    []string  `json:"labels"` // пусто = на каждый PR команды
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// AppliesTo проверяет, нужен ли ревьювер на PR с такими метками
+func (m *MandatoryReviewer) AppliesTo(labels []string) bool {
+	if len(m.Labels) == 0 {
+		return true
+	}
+
+	for _, want := range m.Labels {
+		for _, label := range labels {
+			if label == want {
+				return true
+			}
+		}
+	}
+	return false
+}
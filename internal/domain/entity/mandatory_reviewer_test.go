@@ -0,0 +1,25 @@
+package entity
+
+import "testing"
+
+func TestMandatoryReviewer_AppliesTo(t *testing.T) {
+	tests := []struct {
+		name   string
+		rule   MandatoryReviewer
+		labels []string
+		want   bool
+	}{
+		{"No labels - every PR", MandatoryReviewer{}, nil, true},
+		{"Matching label", MandatoryReviewer{Labels: []string{"release"}}, []string{"bugfix", "release"}, true},
+		{"No matching label", MandatoryReviewer{Labels: []string{"release"}}, []string{"bugfix"}, false},
+		{"PR without labels", MandatoryReviewer{Labels: []string{"release"}}, nil, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.rule.AppliesTo(tt.labels); got != tt.want {
+				t.Errorf("AppliesTo() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
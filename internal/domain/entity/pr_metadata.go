@@ -0,0 +1,76 @@
+package entity
+
+import (
+	"fmt"
+	"net/url"
+)
+
+// PRMetadata - описание PR во внешней системе, чтобы ревьюверу не искать его отдельно
+type PRMetadata struct {
+	Description  string `json:"description,omitempty"`
+	URL          string `json:"url,omitempty"`
+	Repository   string `json:"repository,omitempty"`
+	Number       int    `json:"number,omitempty"`
+	SourceBranch string `json:"source_branch,omitempty"`
+	TargetBranch string `json:"target_branch,omitempty"`
+}
+
+// Validate проверяет, что ссылка на PR - абсолютный http(s) URL,
+// а номер PR задан только вместе с репозиторием
+func (m PRMetadata) Validate() error {
+	if m.Number < 0 {
+		return fmt.Errorf("number must be positive, got %d", m.Number)
+	}
+	if m.Number > 0 && m.Repository == "" {
+		return fmt.Errorf("number requires repository")
+	}
+
+	if m.URL == "" {
+		return nil
+	}
+
+	u, err := url.Parse(m.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("url must be an absolute http(s) URL, got %q", m.URL)
+	}
+	return nil
+}
+
+// PRUpdate - изменение названия и метаданных PR; nil = поле не меняется
+type PRUpdate struct {
+	Name         *string
+	Description  *string
+	URL          *string
+	Repository   *string
+	SourceBranch *string
+	TargetBranch *string
+	Labels       *[]string
+}
+
+// Apply переносит заданные поля в PR
+func (u PRUpdate) Apply(pr *PullRequest) {
+	set := func(dst *string, src *string) {
+		if src != nil {
+			*dst = *src
+		}
+	}
+
+	set(&pr.Name, u.Name)
+	set(&pr.Description, u.Description)
+	set(&pr.URL, u.URL)
+	set(&pr.Repository, u.Repository)
+	set(&pr.SourceBranch, u.SourceBranch)
+	set(&pr.TargetBranch, u.TargetBranch)
+	if u.Labels != nil {
+		pr.Labels = NormalizeTags(*u.Labels)
+	}
+}
+
+// ReviewFilter отбирает PR в списке ревью; пустое поле не фильтрует
+type ReviewFilter struct {
+	Status       PRStatus
+	Repository   string
+	SourceBranch string
+	TargetBranch string
+	Label        string
+}
@@ -0,0 +1,49 @@
+package entity
+
+import "testing"
+
+func TestPRMetadata_Validate(t *testing.T) {
+	tests := []struct {
+		url     string
+		wantErr bool
+	}{
+		{"", false},
+		{"https://github.com/org/repo/pull/42", false},
+		{"http://git.local/pr/1", false},
+		{"github.com/org/repo/pull/42", true},
+		{"ftp://host/pr", true},
+		{"https://", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.url, func(t *testing.T) {
+			err := PRMetadata{URL: tt.url}.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestPRUpdate_Apply(t *testing.T) {
+	pr := &PullRequest{
+		Name:       "old",
+		PRMetadata: PRMetadata{Repository: "api", TargetBranch: "main"},
+		Labels:     []string{"backend"},
+	}
+
+	name := "new"
+	branch := "release"
+	labels := []string{"Security", "security", "db"}
+	PRUpdate{Name: &name, TargetBranch: &branch, Labels: &labels}.Apply(pr)
+
+	if pr.Name != "new" || pr.TargetBranch != "release" {
+		t.Errorf("Apply() name = %q, target = %q", pr.Name, pr.TargetBranch)
+	}
+	if pr.Repository != "api" {
+		t.Errorf("Apply() changed repository to %q", pr.Repository)
+	}
+	if len(pr.Labels) != 2 {
+		t.Errorf("Apply() labels = %v, want normalized pair", pr.Labels)
+	}
+}
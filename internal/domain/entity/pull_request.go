@@ -1,23 +1,79 @@
 package entity
 
-import "time"
+import (
+	"fmt"
+	"time"
+)
 
 type PRStatus string
 
 const (
-	StatusOpen   PRStatus = "OPEN"
-	StatusMerged PRStatus = "MERGED"
+	StatusOpen              PRStatus = "OPEN"
+	StatusMerged            PRStatus = "MERGED"
+	StatusAwaitingReviewers PRStatus = "AWAITING_REVIEWERS" // ждёт, пока у ревьюверов освободится место
+	StatusClosed            PRStatus = "CLOSED"             // закрыт без merge, можно открыть заново
+	StatusDraft             PRStatus = "DRAFT"              // черновик, ревьюверы не назначаются
+)
+
+// transitions - допустимые переходы между статусами PR
+var transitions = map[PRStatus][]PRStatus{
+	StatusOpen:              {StatusMerged, StatusClosed},
+	StatusAwaitingReviewers: {StatusOpen, StatusMerged, StatusClosed},
+	StatusClosed:            {StatusOpen, StatusAwaitingReviewers, StatusDraft},
+	StatusDraft:             {StatusOpen, StatusAwaitingReviewers, StatusClosed},
+}
+
+// Valid проверяет, что статус известен
+func (s PRStatus) Valid() bool {
+	switch s {
+	case StatusOpen, StatusMerged, StatusAwaitingReviewers, StatusClosed, StatusDraft:
+		return true
+	}
+	return false
+}
+
+// CanTransitionTo проверяет, можно ли перевести PR из статуса s в next
+func (s PRStatus) CanTransitionTo(next PRStatus) bool {
+	for _, allowed := range transitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
+
+// ReviewerSource - откуда взят ревьювер
+type ReviewerSource string
+
+const (
+	SourceTeam      ReviewerSource = "TEAM"      // из команды автора
+	SourceFallback  ReviewerSource = "FALLBACK"  // из резервной команды
+	SourceMandatory ReviewerSource = "MANDATORY" // обязательный ревьювер команды
 )
 
 type PullRequest struct {
-	ID                string
-	Name              string
-	AuthorID          string
-	Status            PRStatus
-	AssignedReviewers []string
-	CreatedAt         time.Time
-	MergedAt          *time.Time
-	Version           int
+	ID                 string     `json:"pull_request_id"`
+	Name               string     `json:"pull_request_name"`
+	AuthorID           string     `json:"author_id"`
+	Status             PRStatus   `json:"status"`
+	RequiredReviewers  int        `json:"required_reviewers"` // целевое число ревьюверов, 0 = не отслеживается
+	AssignedReviewers  []string   `json:"assigned_reviewers"`
+	FallbackReviewers  []string   `json:"fallback_reviewers,omitempty"`
+	MandatoryReviewers []string   `json:"mandatory_reviewers,omitempty"`
+	ChangedFiles       []string   `json:"changed_files,omitempty"`
+	Labels             []string   `json:"labels,omitempty"`
+	LinesAdded         int        `json:"lines_added"`
+	LinesRemoved       int        `json:"lines_removed"`
+	FilesChanged       int        `json:"files_changed"`
+	CreatedAt          time.Time  `json:"createdAt"`
+	MergedAt           *time.Time `json:"mergedAt"`
+	MergeOverride      bool       `json:"merge_override"` // смержен администратором в обход одобрений
+	ClosedAt           *time.Time `json:"closedAt"`
+	ReadyAt            *time.Time `json:"readyAt"`              // когда PR стал готов к ревью; nil = черновик
+	Unselected         string     `json:"unselected,omitempty"` // почему при назначении никого не выбрали (Unselected*); не хранится
+	Version            int        `json:"version"`
+
+	PRMetadata
 }
 
 func (pr *PullRequest) HasReviewer(userID string) bool {
@@ -29,6 +85,70 @@ func (pr *PullRequest) HasReviewer(userID string) bool {
 	return false
 }
 
+// IsMandatoryReviewer проверяет, назначен ли пользователь обязательным ревьювером
+func (pr *PullRequest) IsMandatoryReviewer(userID string) bool {
+	for _, id := range pr.MandatoryReviewers {
+		if id == userID {
+			return true
+		}
+	}
+	return false
+}
+
+// Shortfall возвращает, скольких ревьюверов не хватает до целевого числа
+func (pr *PullRequest) Shortfall() int {
+	if missing := pr.RequiredReviewers - len(pr.AssignedReviewers); missing > 0 {
+		return missing
+	}
+	return 0
+}
+
+// Size возвращает число изменённых строк
+func (pr *PullRequest) Size() int {
+	return pr.LinesAdded + pr.LinesRemoved
+}
+
+// TransitionTo переводит PR в статус next и проставляет время merge, закрытия
+// или готовности к ревью.
+// Недопустимый переход возвращает ErrInvalidTransition.
+func (pr *PullRequest) TransitionTo(next PRStatus, now time.Time) error {
+	if !pr.Status.CanTransitionTo(next) {
+		return fmt.Errorf("%w: %s -> %s", ErrInvalidTransition, pr.Status, next)
+	}
+
+	switch next {
+	case StatusMerged:
+		pr.MergedAt = &now
+	case StatusClosed:
+		pr.ClosedAt = &now
+	default:
+		pr.ClosedAt = nil
+		if next != StatusDraft && pr.ReadyAt == nil {
+			pr.ReadyAt = &now
+		}
+	}
+
+	pr.Status = next
+	return nil
+}
+
 func (pr *PullRequest) IsMerged() bool {
 	return pr.Status == StatusMerged
 }
+
+func (pr *PullRequest) IsDraft() bool {
+	return pr.Status == StatusDraft
+}
+
+func (pr *PullRequest) IsClosed() bool {
+	return pr.Status == StatusClosed
+}
+
+func (pr *PullRequest) IsAwaitingReviewers() bool {
+	return pr.Status == StatusAwaitingReviewers
+}
+
+// InReview - ревью ещё идёт: PR открыт или ждёт ревьюверов в очереди
+func (pr *PullRequest) InReview() bool {
+	return pr.Status == StatusOpen || pr.Status == StatusAwaitingReviewers
+}
@@ -1,7 +1,9 @@
 package entity
 
 import (
+	"errors"
 	"testing"
+	"time"
 )
 
 func TestPullRequest_HasReviewer(t *testing.T) {
@@ -49,3 +51,116 @@ func TestPullRequest_IsMerged(t *testing.T) {
 		})
 	}
 }
+
+func TestPullRequest_InReview(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   PRStatus
+		expected bool
+	}{
+		{"Open PR", StatusOpen, true},
+		{"Queued PR", StatusAwaitingReviewers, true},
+		{"Draft PR", StatusDraft, false},
+		{"Closed PR", StatusClosed, false},
+		{"Merged PR", StatusMerged, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pr := &PullRequest{Status: tt.status}
+			if got := pr.InReview(); got != tt.expected {
+				t.Errorf("InReview() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestPullRequest_Shortfall(t *testing.T) {
+	tests := []struct {
+		name     string
+		required int
+		assigned []string
+		expected int
+	}{
+		{"Fully staffed", 2, []string{"user1", "user2"}, 0},
+		{"One missing", 2, []string{"user1"}, 1},
+		{"None assigned", 2, nil, 2},
+		{"Above target", 1, []string{"user1", "user2"}, 0},
+		{"Not tracked", 0, nil, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pr := &PullRequest{RequiredReviewers: tt.required, AssignedReviewers: tt.assigned}
+			if got := pr.Shortfall(); got != tt.expected {
+				t.Errorf("Shortfall() = %d, want %d", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestPullRequest_TransitionTo(t *testing.T) {
+	now := time.Date(2024, 7, 3, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		from    PRStatus
+		to      PRStatus
+		wantErr bool
+	}{
+		{"Open to merged", StatusOpen, StatusMerged, false},
+		{"Open to closed", StatusOpen, StatusClosed, false},
+		{"Awaiting to open", StatusAwaitingReviewers, StatusOpen, false},
+		{"Awaiting to closed", StatusAwaitingReviewers, StatusClosed, false},
+		{"Closed to open", StatusClosed, StatusOpen, false},
+		{"Closed to awaiting", StatusClosed, StatusAwaitingReviewers, false},
+		{"Closed to draft", StatusClosed, StatusDraft, false},
+		{"Draft to open", StatusDraft, StatusOpen, false},
+		{"Draft to awaiting", StatusDraft, StatusAwaitingReviewers, false},
+		{"Draft to closed", StatusDraft, StatusClosed, false},
+		{"Draft to merged", StatusDraft, StatusMerged, true},
+		{"Open to draft", StatusOpen, StatusDraft, true},
+		{"Closed to merged", StatusClosed, StatusMerged, true},
+		{"Merged to open", StatusMerged, StatusOpen, true},
+		{"Merged to closed", StatusMerged, StatusClosed, true},
+		{"Open to open", StatusOpen, StatusOpen, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pr := &PullRequest{Status: tt.from}
+			err := pr.TransitionTo(tt.to, now)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("TransitionTo() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				if !errors.Is(err, ErrInvalidTransition) {
+					t.Errorf("TransitionTo() error = %v, want ErrInvalidTransition", err)
+				}
+				if pr.Status != tt.from {
+					t.Errorf("TransitionTo() changed status on error to %v", pr.Status)
+				}
+				return
+			}
+			if pr.Status != tt.to {
+				t.Errorf("TransitionTo() status = %v, want %v", pr.Status, tt.to)
+			}
+		})
+	}
+
+	pr := &PullRequest{Status: StatusOpen}
+	_ = pr.TransitionTo(StatusClosed, now)
+	if pr.ClosedAt == nil || !pr.ClosedAt.Equal(now) {
+		t.Errorf("TransitionTo(CLOSED) closedAt = %v, want %v", pr.ClosedAt, now)
+	}
+	_ = pr.TransitionTo(StatusOpen, now)
+	if pr.ClosedAt != nil {
+		t.Error("TransitionTo(OPEN) should clear closedAt")
+	}
+
+	draft := &PullRequest{Status: StatusDraft}
+	_ = draft.TransitionTo(StatusOpen, now)
+	if draft.ReadyAt == nil || !draft.ReadyAt.Equal(now) {
+		t.Errorf("TransitionTo(OPEN) from draft readyAt = %v, want %v", draft.ReadyAt, now)
+	}
+}
@@ -0,0 +1,23 @@
+package entity
+
+import "time"
+
+// QueueEntry - PR в очереди ожидания ревьюверов
+type QueueEntry struct {
+	PullRequestID        string    `json:"pull_request_id"`
+	TeamName             string    `json:"team_name"`
+	Position             int       `json:"position"` // 1 = следующий на назначение
+	WaitingSince         time.Time `json:"waiting_since"`
+	EstimatedWaitSeconds *int64    `json:"estimated_wait_seconds"` // nil = оценить нельзя
+}
+
+// EstimateWait оценивает ожидание по темпу мёржей команды за окно:
+// каждый мёрж освобождает место у ревьювера. Без мёржей оценки нет.
+func EstimateWait(position, merged int, window time.Duration) *time.Duration {
+	if merged <= 0 || position <= 0 {
+		return nil
+	}
+
+	wait := window * time.Duration(position) / time.Duration(merged)
+	return &wait
+}
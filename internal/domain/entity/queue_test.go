@@ -0,0 +1,28 @@
+package entity
+
+import (
+	"testing"
+	"time"
+)
+
+func TestEstimateWait(t *testing.T) {
+	window := 7 * 24 * time.Hour
+
+	t.Run("No merges", func(t *testing.T) {
+		if wait := EstimateWait(1, 0, window); wait != nil {
+			t.Errorf("EstimateWait() = %v, want nil", *wait)
+		}
+	})
+
+	t.Run("Proportional to position", func(t *testing.T) {
+		wait := EstimateWait(2, 14, window)
+		if wait == nil {
+			t.Fatal("EstimateWait() = nil, want estimate")
+		}
+
+		// 14 мёржей за неделю - по одному в 12 часов
+		if *wait != 24*time.Hour {
+			t.Errorf("EstimateWait() = %v, want %v", *wait, 24*time.Hour)
+		}
+	})
+}
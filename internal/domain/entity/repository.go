@@ -0,0 +1,60 @@
+package entity
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Repository - репозиторий кода и команды, которые ревьюят его PR.
+// Первая команда основная, остальные подключаются как резервные.
+type Repository struct {
+	Name        string    `json:"repository"`
+	OwningTeams []string  `json:"owning_teams"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// PrimaryTeam возвращает основную команду-владельца
+func (r *Repository) PrimaryTeam() string {
+	if len(r.OwningTeams) == 0 {
+		return ""
+	}
+	return r.OwningTeams[0]
+}
+
+// RoutePolicy возвращает копию политики основной команды, в которой
+// остальные команды-владельцы идут первыми среди резервных
+func (r *Repository) RoutePolicy(policy *TeamPolicy) *TeamPolicy {
+	routed := *policy
+
+	fallback := make([]string, 0, len(r.OwningTeams)+len(policy.FallbackTeams))
+	for _, team := range slices.Concat(r.OwningTeams[1:], policy.FallbackTeams) {
+		if team != r.PrimaryTeam() && !slices.Contains(fallback, team) {
+			fallback = append(fallback, team)
+		}
+	}
+	routed.FallbackTeams = fallback
+
+	return &routed
+}
+
+// PRKey - идентификатор PR с номером number в репозитории repository
+func PRKey(repository string, number int) string {
+	return fmt.Sprintf("%s#%d", repository, number)
+}
+
+// ParsePRKey разбирает идентификатор PR вида repository#number
+func ParsePRKey(id string) (string, int, bool) {
+	i := strings.LastIndex(id, "#")
+	if i <= 0 {
+		return "", 0, false
+	}
+
+	number, err := strconv.Atoi(id[i+1:])
+	if err != nil || number <= 0 || strconv.Itoa(number) != id[i+1:] {
+		return "", 0, false
+	}
+	return id[:i], number, true
+}
@@ -0,0 +1,58 @@
+package entity
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestRepository_RoutePolicy(t *testing.T) {
+	repo := &Repository{Name: "monorepo", OwningTeams: []string{"platform", "payments", "search"}}
+	policy := &TeamPolicy{TeamName: "platform", ReviewerCount: 3, FallbackTeams: []string{"search", "sre"}}
+
+	routed := repo.RoutePolicy(policy)
+
+	if repo.PrimaryTeam() != "platform" {
+		t.Errorf("PrimaryTeam() = %q, want platform", repo.PrimaryTeam())
+	}
+	if want := []string{"payments", "search", "sre"}; !slices.Equal(routed.FallbackTeams, want) {
+		t.Errorf("RoutePolicy() fallback = %v, want %v", routed.FallbackTeams, want)
+	}
+	if routed.ReviewerCount != 3 {
+		t.Errorf("RoutePolicy() reviewer count = %d, want 3", routed.ReviewerCount)
+	}
+	if len(policy.FallbackTeams) != 2 {
+		t.Errorf("RoutePolicy() modified original policy: %v", policy.FallbackTeams)
+	}
+}
+
+func TestPRKey(t *testing.T) {
+	if key := PRKey("billing-api", 42); key != "billing-api#42" {
+		t.Errorf("PRKey() = %q, want billing-api#42", key)
+	}
+}
+
+func TestParsePRKey(t *testing.T) {
+	tests := []struct {
+		id         string
+		wantRepo   string
+		wantNumber int
+		wantOK     bool
+	}{
+		{"billing-api#42", "billing-api", 42, true},
+		{"org/repo#issue#3", "org/repo#issue", 3, true},
+		{"pr-1001", "", 0, false},
+		{"#42", "", 0, false},
+		{"api#0", "", 0, false},
+		{"api#07", "", 0, false},
+		{"api#-1", "", 0, false},
+		{"api#", "", 0, false},
+	}
+
+	for _, tt := range tests {
+		repo, number, ok := ParsePRKey(tt.id)
+		if repo != tt.wantRepo || number != tt.wantNumber || ok != tt.wantOK {
+			t.Errorf("ParsePRKey(%q) = (%q, %d, %v), want (%q, %d, %v)",
+				tt.id, repo, number, ok, tt.wantRepo, tt.wantNumber, tt.wantOK)
+		}
+	}
+}
@@ -0,0 +1,189 @@
+package entity
+
+import (
+	"fmt"
+	"time"
+	_ "time/tzdata" // часовые пояса не зависят от образа контейнера
+)
+
+// DefaultTimezone - часовой пояс пользователей без настройки
+const DefaultTimezone = "UTC"
+
+// WorkingHours - рабочий интервал в один из дней недели
+// в часовом поясе пользователя
+type WorkingHours struct {
+	Weekday time.Weekday `json:"weekday"` // 0 = воскресенье
+	Start   string       `json:"start"`   // "09:00"
+	End     string       `json:"end"`     // "18:00", позже Start
+}
+
+// Availability - когда пользователь будет в рабочих часах
+type Availability struct {
+	UserID         string    `json:"user_id"`
+	Timezone       string    `json:"timezone"`
+	At             time.Time `json:"at"`
+	InWorkingHours bool      `json:"in_working_hours"`
+	AvailableAt    time.Time `json:"available_at"`
+	WaitSeconds    int64     `json:"wait_seconds"`
+}
+
+// ParseClock разбирает время суток "HH:MM" в минуты от полуночи
+func ParseClock(s string) (int, error) {
+	t, err := time.Parse("15:04", s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid time %q, expected HH:MM", s)
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+// ValidateSchedule проверяет часовой пояс и рабочие интервалы.
+// Интервал через полночь задаётся двумя интервалами в соседние дни.
+func ValidateSchedule(timezone string, hours []WorkingHours) error {
+	if _, err := time.LoadLocation(timezone); err != nil {
+		return fmt.Errorf("unknown timezone %q", timezone)
+	}
+
+	for _, h := range hours {
+		if h.Weekday < time.Sunday || h.Weekday > time.Saturday {
+			return fmt.Errorf("invalid weekday %d", h.Weekday)
+		}
+
+		start, err := ParseClock(h.Start)
+		if err != nil {
+			return err
+		}
+		end, err := ParseClock(h.End)
+		if err != nil {
+			return err
+		}
+		if end <= start {
+			return fmt.Errorf("working hours %s-%s must end after they start", h.Start, h.End)
+		}
+	}
+
+	return nil
+}
+
+// OffHoursWait возвращает, через сколько пользователь окажется в рабочих часах.
+// 0 - сейчас рабочее время или расписание не задано.
+func (u *User) OffHoursWait(now time.Time) time.Duration {
+	if len(u.WorkingHours) == 0 {
+		return 0
+	}
+
+	local := now.In(u.location())
+	wait := time.Duration(-1)
+
+	// Неделя вперёд плюс текущий день покрывает любое расписание
+	for offset := 0; offset <= 7; offset++ {
+		day := time.Date(local.Year(), local.Month(), local.Day()+offset, 0, 0, 0, 0, local.Location())
+
+		for _, h := range u.WorkingHours {
+			if h.Weekday != day.Weekday() {
+				continue
+			}
+
+			startMin, err := ParseClock(h.Start)
+			if err != nil {
+				continue
+			}
+			endMin, err := ParseClock(h.End)
+			if err != nil {
+				continue
+			}
+
+			start := time.Date(day.Year(), day.Month(), day.Day(), startMin/60, startMin%60, 0, 0, day.Location())
+			end := time.Date(day.Year(), day.Month(), day.Day(), endMin/60, endMin%60, 0, 0, day.Location())
+
+			if !local.Before(start) && local.Before(end) {
+				return 0
+			}
+			if start.After(local) && (wait < 0 || start.Sub(local) < wait) {
+				wait = start.Sub(local)
+			}
+		}
+
+		if wait >= 0 {
+			return wait
+		}
+	}
+
+	return wait
+}
+
+// WorkingTimeBetween возвращает, сколько рабочего времени пользователя прошло
+// между from и to. Без расписания считается всё время.
+func (u *User) WorkingTimeBetween(from, to time.Time) time.Duration {
+	if !to.After(from) {
+		return 0
+	}
+	if len(u.WorkingHours) == 0 {
+		return to.Sub(from)
+	}
+
+	loc := u.location()
+	localFrom := from.In(loc)
+	localTo := to.In(loc)
+
+	var total time.Duration
+	day := time.Date(localFrom.Year(), localFrom.Month(), localFrom.Day(), 0, 0, 0, 0, loc)
+	for !day.After(localTo) {
+		for _, h := range u.WorkingHours {
+			if h.Weekday != day.Weekday() {
+				continue
+			}
+
+			startMin, err := ParseClock(h.Start)
+			if err != nil {
+				continue
+			}
+			endMin, err := ParseClock(h.End)
+			if err != nil {
+				continue
+			}
+
+			start := time.Date(day.Year(), day.Month(), day.Day(), startMin/60, startMin%60, 0, 0, loc)
+			end := time.Date(day.Year(), day.Month(), day.Day(), endMin/60, endMin%60, 0, 0, loc)
+
+			if start.Before(localFrom) {
+				start = localFrom
+			}
+			if end.After(localTo) {
+				end = localTo
+			}
+			if end.After(start) {
+				total += end.Sub(start)
+			}
+		}
+
+		day = day.AddDate(0, 0, 1)
+	}
+
+	return total
+}
+
+// AvailabilityAt описывает доступность пользователя в момент now
+func (u *User) AvailabilityAt(now time.Time) *Availability {
+	wait := u.OffHoursWait(now)
+
+	return &Availability{
+		UserID:         u.UserID,
+		Timezone:       u.location().String(),
+		At:             now,
+		InWorkingHours: wait == 0,
+		AvailableAt:    now.Add(wait),
+		WaitSeconds:    int64(wait.Seconds()),
+	}
+}
+
+func (u *User) location() *time.Location {
+	if u.Timezone == "" {
+		return time.UTC
+	}
+
+	loc, err := time.LoadLocation(u.Timezone)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
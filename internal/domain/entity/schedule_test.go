@@ -0,0 +1,114 @@
+package entity
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseClock(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected int
+		wantErr  bool
+	}{
+		{"09:00", 540, false},
+		{"18:30", 1110, false},
+		{"24:00", 0, true},
+		{"9am", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			result, err := ParseClock(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseClock() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if result != tt.expected {
+				t.Errorf("ParseClock() = %v, want %v", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestValidateSchedule(t *testing.T) {
+	if err := ValidateSchedule("Europe/Moscow", []WorkingHours{{Weekday: time.Monday, Start: "09:00", End: "18:00"}}); err != nil {
+		t.Errorf("ValidateSchedule() error = %v", err)
+	}
+	if err := ValidateSchedule("Mars/Olympus", nil); err == nil {
+		t.Error("ValidateSchedule() should reject unknown timezone")
+	}
+	if err := ValidateSchedule("UTC", []WorkingHours{{Weekday: time.Monday, Start: "18:00", End: "09:00"}}); err == nil {
+		t.Error("ValidateSchedule() should reject interval ending before start")
+	}
+}
+
+func TestUser_OffHoursWait(t *testing.T) {
+	// Москва: UTC+3, пн-пт 09:00-18:00
+	user := &User{Timezone: "Europe/Moscow"}
+	for d := time.Monday; d <= time.Friday; d++ {
+		user.WorkingHours = append(user.WorkingHours, WorkingHours{Weekday: d, Start: "09:00", End: "18:00"})
+	}
+
+	tests := []struct {
+		name     string
+		now      time.Time
+		expected time.Duration
+	}{
+		// Среда 2024-07-03
+		{"Inside hours", time.Date(2024, 7, 3, 10, 0, 0, 0, time.UTC), 0},
+		{"Before start", time.Date(2024, 7, 3, 4, 0, 0, 0, time.UTC), 2 * time.Hour},
+		{"After end", time.Date(2024, 7, 3, 16, 0, 0, 0, time.UTC), 14 * time.Hour},
+		// Пятница вечер - до понедельника
+		{"Weekend", time.Date(2024, 7, 5, 16, 0, 0, 0, time.UTC), 62 * time.Hour},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := user.OffHoursWait(tt.now); result != tt.expected {
+				t.Errorf("OffHoursWait() = %v, want %v", result, tt.expected)
+			}
+		})
+	}
+
+	t.Run("No schedule", func(t *testing.T) {
+		if result := (&User{}).OffHoursWait(time.Now()); result != 0 {
+			t.Errorf("OffHoursWait() = %v, want 0", result)
+		}
+	})
+}
+
+func TestUser_WorkingTimeBetween(t *testing.T) {
+	// Москва: UTC+3, пн-пт 09:00-18:00
+	user := &User{Timezone: "Europe/Moscow"}
+	for d := time.Monday; d <= time.Friday; d++ {
+		user.WorkingHours = append(user.WorkingHours, WorkingHours{Weekday: d, Start: "09:00", End: "18:00"})
+	}
+
+	tests := []struct {
+		name     string
+		from     time.Time
+		to       time.Time
+		expected time.Duration
+	}{
+		// Среда 2024-07-03
+		{"Inside one day", time.Date(2024, 7, 3, 7, 0, 0, 0, time.UTC), time.Date(2024, 7, 3, 10, 0, 0, 0, time.UTC), 3 * time.Hour},
+		{"Overnight", time.Date(2024, 7, 3, 14, 0, 0, 0, time.UTC), time.Date(2024, 7, 4, 7, 0, 0, 0, time.UTC), 2 * time.Hour},
+		// Пятница вечер - понедельник утро
+		{"Over weekend", time.Date(2024, 7, 5, 14, 0, 0, 0, time.UTC), time.Date(2024, 7, 8, 7, 0, 0, 0, time.UTC), 2 * time.Hour},
+		{"Reversed", time.Date(2024, 7, 3, 10, 0, 0, 0, time.UTC), time.Date(2024, 7, 3, 7, 0, 0, 0, time.UTC), 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := user.WorkingTimeBetween(tt.from, tt.to); result != tt.expected {
+				t.Errorf("WorkingTimeBetween() = %v, want %v", result, tt.expected)
+			}
+		})
+	}
+
+	unscheduled := &User{}
+	from := time.Date(2024, 7, 6, 0, 0, 0, 0, time.UTC)
+	if result := unscheduled.WorkingTimeBetween(from, from.Add(5*time.Hour)); result != 5*time.Hour {
+		t.Errorf("WorkingTimeBetween() without schedule = %v, want 5h", result)
+	}
+}
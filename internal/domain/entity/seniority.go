@@ -0,0 +1,32 @@
+package entity
+
+// Seniority - уровень ревьювера
+type Seniority string
+
+const (
+	SeniorityJunior Seniority = "JUNIOR"
+	SeniorityMiddle Seniority = "MIDDLE"
+	SenioritySenior Seniority = "SENIOR"
+)
+
+// DefaultSeniority - уровень пользователя, для которого он не задан
+const DefaultSeniority = SeniorityMiddle
+
+// Valid проверяет, что уровень известен
+func (s Seniority) Valid() bool {
+	switch s {
+	case SeniorityJunior, SeniorityMiddle, SenioritySenior:
+		return true
+	}
+	return false
+}
+
+// IsSenior проверяет, может ли пользователь быть старшим ревьювером
+func (u *User) IsSenior() bool {
+	return u.Seniority == SenioritySenior
+}
+
+// IsJunior проверяет, может ли пользователь быть учебным ревьювером
+func (u *User) IsJunior() bool {
+	return u.Seniority == SeniorityJunior
+}
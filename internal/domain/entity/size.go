@@ -0,0 +1,107 @@
+package entity
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// SizeBucket - вес ревью для PR размером до MaxLines изменённых строк
+// и до MaxFiles изменённых файлов (0 = число файлов не ограничено)
+type SizeBucket struct {
+	MaxLines int
+	MaxFiles int
+	Weight   int
+}
+
+// SizeBuckets - веса ревью по размеру PR. Bounded упорядочены по MaxLines,
+// PR попадает в первую корзину, в которую укладывается и по строкам, и по файлам.
+// PR крупнее всех корзин весят Overflow. Пустые корзины = вес 1 у любого PR.
+type SizeBuckets struct {
+	Bounded  []SizeBucket
+	Overflow int
+}
+
+// DefaultSizeBuckets - каждое открытое ревью весит 1, как при подсчёте штук
+func DefaultSizeBuckets() SizeBuckets {
+	return SizeBuckets{Overflow: 1}
+}
+
+// Weight возвращает вес ревью PR с lines изменёнными строками в files файлах
+func (b SizeBuckets) Weight(lines, files int) int {
+	for _, bucket := range b.Bounded {
+		if lines <= bucket.MaxLines && (bucket.MaxFiles == 0 || files <= bucket.MaxFiles) {
+			return bucket.Weight
+		}
+	}
+	return b.Overflow
+}
+
+// ParseSizeBuckets разбирает корзины вида "50/5:1,200/20:2,1000:3,*:5",
+// где граница - max_lines или max_lines/max_files.
+// "*" задаёт вес PR крупнее всех границ (по умолчанию - вес последней корзины).
+// Пустая строка - корзины по умолчанию.
+func ParseSizeBuckets(s string) (SizeBuckets, error) {
+	if strings.TrimSpace(s) == "" {
+		return DefaultSizeBuckets(), nil
+	}
+
+	var buckets SizeBuckets
+	seen := make(map[SizeBucket]bool)
+
+	for _, part := range strings.Split(s, ",") {
+		bound, weightStr, ok := strings.Cut(strings.TrimSpace(part), ":")
+		if !ok {
+			return SizeBuckets{}, fmt.Errorf("invalid size bucket %q, expected max_lines[/max_files]:weight", part)
+		}
+
+		weight, err := strconv.Atoi(strings.TrimSpace(weightStr))
+		if err != nil || weight <= 0 {
+			return SizeBuckets{}, fmt.Errorf("invalid weight in size bucket %q", part)
+		}
+
+		bound = strings.TrimSpace(bound)
+		if bound == "*" {
+			buckets.Overflow = weight
+			continue
+		}
+
+		linesStr, filesStr, hasFiles := strings.Cut(bound, "/")
+		maxLines, err := strconv.Atoi(strings.TrimSpace(linesStr))
+		if err != nil || maxLines < 0 {
+			return SizeBuckets{}, fmt.Errorf("invalid max_lines in size bucket %q", part)
+		}
+
+		maxFiles := 0
+		if hasFiles {
+			maxFiles, err = strconv.Atoi(strings.TrimSpace(filesStr))
+			if err != nil || maxFiles <= 0 {
+				return SizeBuckets{}, fmt.Errorf("invalid max_files in size bucket %q", part)
+			}
+		}
+
+		key := SizeBucket{MaxLines: maxLines, MaxFiles: maxFiles}
+		if seen[key] {
+			return SizeBuckets{}, fmt.Errorf("duplicate size bucket %q", bound)
+		}
+		seen[key] = true
+
+		buckets.Bounded = append(buckets.Bounded, SizeBucket{MaxLines: maxLines, MaxFiles: maxFiles, Weight: weight})
+	}
+
+	// При равных строках корзина с ограничением по файлам проверяется первой
+	sort.SliceStable(buckets.Bounded, func(i, j int) bool {
+		a, b := buckets.Bounded[i], buckets.Bounded[j]
+		if a.MaxLines != b.MaxLines {
+			return a.MaxLines < b.MaxLines
+		}
+		return a.MaxFiles != 0 && (b.MaxFiles == 0 || a.MaxFiles < b.MaxFiles)
+	})
+
+	if buckets.Overflow == 0 {
+		buckets.Overflow = buckets.Bounded[len(buckets.Bounded)-1].Weight
+	}
+
+	return buckets, nil
+}
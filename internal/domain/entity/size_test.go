@@ -0,0 +1,78 @@
+package entity
+
+import "testing"
+
+func TestParseSizeBuckets(t *testing.T) {
+	buckets, err := ParseSizeBuckets("200:2, 50:1, 1000:3, *:5")
+	if err != nil {
+		t.Fatalf("ParseSizeBuckets() error = %v", err)
+	}
+
+	tests := []struct {
+		lines int
+		want  int
+	}{
+		{0, 1},
+		{50, 1},
+		{51, 2},
+		{1000, 3},
+		{3000, 5},
+	}
+
+	for _, tt := range tests {
+		if got := buckets.Weight(tt.lines, 1); got != tt.want {
+			t.Errorf("Weight(%d, 1) = %d, want %d", tt.lines, got, tt.want)
+		}
+	}
+}
+
+func TestParseSizeBuckets_Files(t *testing.T) {
+	buckets, err := ParseSizeBuckets("50/5:1, 200/20:2, 200:3, *:5")
+	if err != nil {
+		t.Fatalf("ParseSizeBuckets() error = %v", err)
+	}
+
+	tests := []struct {
+		lines int
+		files int
+		want  int
+	}{
+		{10, 2, 1},
+		{10, 6, 2},   // мало строк, но много файлов
+		{100, 30, 3}, // файлов больше любой границы - только по строкам
+		{300, 1, 5},
+	}
+
+	for _, tt := range tests {
+		if got := buckets.Weight(tt.lines, tt.files); got != tt.want {
+			t.Errorf("Weight(%d, %d) = %d, want %d", tt.lines, tt.files, got, tt.want)
+		}
+	}
+}
+
+func TestParseSizeBuckets_Defaults(t *testing.T) {
+	buckets, err := ParseSizeBuckets("")
+	if err != nil {
+		t.Fatalf("ParseSizeBuckets() error = %v", err)
+	}
+	if got := buckets.Weight(5000, 1); got != 1 {
+		t.Errorf("Weight() = %d, want 1", got)
+	}
+
+	// Без "*" крупные PR весят как последняя корзина
+	buckets, err = ParseSizeBuckets("100:1,500:3")
+	if err != nil {
+		t.Fatalf("ParseSizeBuckets() error = %v", err)
+	}
+	if got := buckets.Weight(5000, 1); got != 3 {
+		t.Errorf("Weight() = %d, want 3", got)
+	}
+}
+
+func TestParseSizeBuckets_Invalid(t *testing.T) {
+	for _, s := range []string{"100", "100:0", "abc:1", "100:1,100:2", "*:x", "100/0:1", "100/x:1", "100/5:1,100/5:2"} {
+		if _, err := ParseSizeBuckets(s); err == nil {
+			t.Errorf("ParseSizeBuckets(%q) error = nil, want error", s)
+		}
+	}
+}
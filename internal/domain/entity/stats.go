@@ -17,7 +17,26 @@ type TeamStats struct {
 	TeamName      string `json:"team_name"`
 	OpenPRs       int    `json:"open_prs"`
 	OpenReviews   int    `json:"open_reviews"`
+	WeightedLoad  int    `json:"weighted_load"` // открытые ревью с весами по размеру PR
 	TotalMembers  int    `json:"total_members"`
 	ActiveMembers int    `json:"active_members"`
 	TotalPRs      int    `json:"total_prs"`
 }
+
+// DefaultPairingWindowDays - окно матрицы пар, если в политике оно не задано
+const DefaultPairingWindowDays = 30
+
+// PairStat - сколько раз ревьювер смотрел PR автора за окно
+type PairStat struct {
+	AuthorID   string `json:"author_id"`
+	ReviewerID string `json:"reviewer_id"`
+	Reviews    int    `json:"reviews"`
+}
+
+// PairingMatrix - пары автор-ревьювер команды за последние WindowDays дней
+type PairingMatrix struct {
+	TeamName   string      `json:"team_name"`
+	WindowDays int         `json:"window_days"`
+	Since      time.Time   `json:"since"`
+	Pairs      []*PairStat `json:"pairs"` // по убыванию числа ревью
+}
@@ -0,0 +1,110 @@
+package entity
+
+import "time"
+
+// DefaultReviewerCount - число ревьюверов для команд без политики
+const DefaultReviewerCount = 2
+
+// OwnershipMode - как учитывать владельцев путей из CODEOWNERS
+type OwnershipMode string
+
+const (
+	OwnershipOff     OwnershipMode = "OFF"     // не учитывать
+	OwnershipPrefer  OwnershipMode = "PREFER"  // сначала владельцы, остальные места - по команде
+	OwnershipRequire OwnershipMode = "REQUIRE" // только владельцы
+)
+
+// TeamPolicy - правила назначения ревьюверов для команды
+type TeamPolicy struct {
+	TeamName         string        `json:"team_name"`
+	ReviewerCount    int           `json:"reviewer_count"`
+	MinActiveMembers int           `json:"min_active_members"`
+	MaxOpenReviews   int           `json:"max_open_reviews"`  // 0 = без ограничения
+	MaxWeightedLoad  int           `json:"max_weighted_load"` // лимит суммы весов открытых ревью по размеру PR, 0 = без ограничения
+	Strategy         string        `json:"strategy"`          // пусто = стратегия по умолчанию
+	FallbackTeams    []string      `json:"fallback_teams"`    // в порядке приоритета
+	OwnershipMode    OwnershipMode `json:"ownership_mode"`
+
+	// Рабочие часы ревьюверов
+	PreferWorkingHours bool `json:"prefer_working_hours"` // сначала те, кто на работе
+	PreferWithinHours  int  `json:"prefer_within_hours"`  // ...или выйдет на работу в течение N часов
+	MaxOffHours        int  `json:"max_off_hours"`        // не назначать тех, кто вне работы дольше; 0 = не отсеивать
+
+	// Состав ревьюверов по уровням
+	RequireSenior    bool `json:"require_senior"`    // хотя бы один SENIOR на каждом PR
+	LearningReviewer bool `json:"learning_reviewer"` // сверх reviewer_count добавлять JUNIOR для обучения
+
+	// Разведение частых пар автор-ревьювер
+	AntiAffinityDays   int `json:"anti_affinity_days"`   // окно истории; 0 = не учитывать
+	AntiAffinityWeight int `json:"anti_affinity_weight"` // на сколько позиций опускает каждое общее ревью; 0 = 1
+
+	// SLA ревью и эскалация
+	ReviewSLAHours      int                `json:"review_sla_hours"`      // рабочих часов ревьювера на ответ; 0 = без SLA
+	EscalationStepHours int                `json:"escalation_step_hours"` // между шагами эскалации; 0 = review_sla_hours
+	EscalationLadder    []EscalationAction `json:"escalation_ladder"`     // пусто = NOTIFY, REASSIGN, ALERT_LEAD
+	TeamLeadID          string             `json:"team_lead_id"`          // получатель ALERT_LEAD
+
+	// Условия merge
+	RequiredApprovals int `json:"required_approvals"` // сколько APPROVED нужно; 0 = не требуются
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// DefaultTeamPolicy возвращает политику для команды без явной настройки
+func DefaultTeamPolicy(teamName string) *TeamPolicy {
+	return &TeamPolicy{
+		TeamName:      teamName,
+		ReviewerCount: DefaultReviewerCount,
+		FallbackTeams: []string{},
+		OwnershipMode: OwnershipPrefer,
+	}
+}
+
+// HasWeightedCapacity проверяет, можно ли назначить ещё одно ревью
+// при текущей нагрузке с весами по размеру PR
+func (p *TeamPolicy) HasWeightedCapacity(load int) bool {
+	return p.MaxWeightedLoad == 0 || load < p.MaxWeightedLoad
+}
+
+// Ladder возвращает шаги эскалации по порядку
+func (p *TeamPolicy) Ladder() []EscalationAction {
+	if len(p.EscalationLadder) == 0 {
+		return DefaultEscalationLadder()
+	}
+	return p.EscalationLadder
+}
+
+// DueEscalation возвращает следующий шаг эскалации назначения, по которому
+// сделано step шагов и прошло elapsed рабочего времени ревьювера.
+// Шаг i наступает через review_sla_hours + i * escalation_step_hours.
+func (p *TeamPolicy) DueEscalation(elapsed time.Duration, step int) (EscalationAction, bool) {
+	ladder := p.Ladder()
+	if p.ReviewSLAHours <= 0 || step < 0 || step >= len(ladder) {
+		return "", false
+	}
+
+	stepHours := p.EscalationStepHours
+	if stepHours == 0 {
+		stepHours = p.ReviewSLAHours
+	}
+
+	threshold := time.Duration(p.ReviewSLAHours+step*stepHours) * time.Hour
+	if elapsed < threshold {
+		return "", false
+	}
+	return ladder[step], true
+}
+
+// PairPenalty возвращает, на сколько позиций опускается кандидат
+// за reviews общих ревью с автором
+func (p *TeamPolicy) PairPenalty(reviews int) int {
+	if p.AntiAffinityDays <= 0 {
+		return 0
+	}
+	weight := p.AntiAffinityWeight
+	if weight == 0 {
+		weight = 1
+	}
+	return weight * reviews
+}
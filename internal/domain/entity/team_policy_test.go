@@ -0,0 +1,54 @@
+package entity
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDefaultTeamPolicy(t *testing.T) {
+	policy := DefaultTeamPolicy("backend")
+
+	if policy.TeamName != "backend" {
+		t.Errorf("Expected TeamName to be 'backend', got %s", policy.TeamName)
+	}
+
+	if policy.ReviewerCount != DefaultReviewerCount {
+		t.Errorf("Expected ReviewerCount to be %d, got %d", DefaultReviewerCount, policy.ReviewerCount)
+	}
+
+	if policy.MaxOpenReviews != 0 {
+		t.Errorf("Expected MaxOpenReviews to be unlimited, got %d", policy.MaxOpenReviews)
+	}
+}
+
+func TestTeamPolicy_DueEscalation(t *testing.T) {
+	policy := &TeamPolicy{ReviewSLAHours: 4, EscalationStepHours: 2}
+
+	tests := []struct {
+		name     string
+		elapsed  time.Duration
+		step     int
+		expected EscalationAction
+		due      bool
+	}{
+		{"Within SLA", 3 * time.Hour, 0, "", false},
+		{"SLA breached", 4 * time.Hour, 0, EscalationNotify, true},
+		{"Next step not yet", 5 * time.Hour, 1, "", false},
+		{"Second step", 6 * time.Hour, 1, EscalationReassign, true},
+		{"Third step", 8 * time.Hour, 2, EscalationAlertLead, true},
+		{"Ladder exhausted", 100 * time.Hour, 3, "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			action, due := policy.DueEscalation(tt.elapsed, tt.step)
+			if action != tt.expected || due != tt.due {
+				t.Errorf("DueEscalation() = (%v, %v), want (%v, %v)", action, due, tt.expected, tt.due)
+			}
+		})
+	}
+
+	if _, due := (&TeamPolicy{}).DueEscalation(100*time.Hour, 0); due {
+		t.Error("DueEscalation() should be disabled without SLA")
+	}
+}
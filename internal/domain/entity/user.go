@@ -1,12 +1,83 @@
 package entity
 
-import "time"
+import (
+	"sort"
+	"strings"
+	"time"
+)
+
+// FullCapacity - полная ставка ревьювера, в процентах
+const FullCapacity = 100
 
 type User struct {
-	UserID    string `json:"user_id"`
-	Username  string `json:"username"`
-	TeamName  string `json:"team_name"`
-	IsActive  bool   `json:"is_active"`
-	CreatedAt time.Time
-	UpdatedAt time.Time
+	UserID          string         `json:"user_id"`
+	Username        string         `json:"username"`
+	TeamName        string         `json:"team_name"`
+	IsActive        bool           `json:"is_active"`
+	Tags            []string       `json:"tags"`                       // области экспертизы: sql, frontend, security...
+	MaxOpenReviews  *int           `json:"max_open_reviews,omitempty"` // личный лимит, nil = лимит команды
+	CapacityPercent int            `json:"capacity_percent"`           // доля ставки: 50 = половина лимита
+	Timezone        string         `json:"timezone"`                   // IANA, например Europe/Moscow
+	WorkingHours    []WorkingHours `json:"working_hours"`              // пусто = доступен всегда
+	Seniority       Seniority      `json:"seniority"`
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+}
+
+// ReviewLimit возвращает лимит открытых ревью с учётом личного лимита
+// и доли ставки. 0 = без ограничения.
+func (u *User) ReviewLimit(teamMax int) int {
+	limit := teamMax
+	if u.MaxOpenReviews != nil {
+		limit = *u.MaxOpenReviews
+	}
+	if limit == 0 {
+		return 0
+	}
+
+	if u.CapacityPercent > 0 && u.CapacityPercent < FullCapacity {
+		limit = limit * u.CapacityPercent / FullCapacity
+		// Частичная ставка не должна полностью выключать ревьювера
+		if limit == 0 {
+			limit = 1
+		}
+	}
+
+	return limit
+}
+
+// HasCapacity проверяет, можно ли назначить ещё одно ревью при текущей нагрузке
+func (u *User) HasCapacity(teamMax, openReviews int) bool {
+	limit := u.ReviewLimit(teamMax)
+	return limit == 0 || openReviews < limit
+}
+
+// HasTag проверяет, есть ли у пользователя экспертиза (без учёта регистра)
+func (u *User) HasTag(tag string) bool {
+	tag = strings.ToLower(strings.TrimSpace(tag))
+	for _, t := range u.Tags {
+		if strings.ToLower(t) == tag {
+			return true
+		}
+	}
+	return false
+}
+
+// NormalizeTags приводит теги и метки к нижнему регистру,
+// убирает пустые и повторяющиеся значения
+func NormalizeTags(tags []string) []string {
+	seen := make(map[string]bool, len(tags))
+	result := make([]string, 0, len(tags))
+
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if tag == "" || seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		result = append(result, tag)
+	}
+
+	sort.Strings(result)
+	return result
 }
@@ -42,3 +42,81 @@ func TestUser(t *testing.T) {
 		t.Error("UpdatedAt not set correctly")
 	}
 }
+
+func TestUser_HasTag(t *testing.T) {
+	user := &User{Tags: []string{"sql", "security"}}
+
+	tests := []struct {
+		name     string
+		tag      string
+		expected bool
+	}{
+		{"Existing tag", "sql", true},
+		{"Case insensitive", "SQL", true},
+		{"Missing tag", "frontend", false},
+		{"Empty tag", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := user.HasTag(tt.tag); result != tt.expected {
+				t.Errorf("HasTag() = %v, want %v", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestNormalizeTags(t *testing.T) {
+	result := NormalizeTags([]string{" SQL", "frontend", "sql", "", "Security"})
+
+	expected := []string{"frontend", "security", "sql"}
+	if len(result) != len(expected) {
+		t.Fatalf("NormalizeTags() = %v, want %v", result, expected)
+	}
+
+	for i := range expected {
+		if result[i] != expected[i] {
+			t.Errorf("NormalizeTags()[%d] = %v, want %v", i, result[i], expected[i])
+		}
+	}
+}
+
+func TestUser_ReviewLimit(t *testing.T) {
+	personal := 4
+
+	tests := []struct {
+		name     string
+		user     *User
+		teamMax  int
+		expected int
+	}{
+		{"Team limit", &User{CapacityPercent: 100}, 6, 6},
+		{"Unlimited team", &User{CapacityPercent: 50}, 0, 0},
+		{"Personal limit overrides team", &User{MaxOpenReviews: &personal, CapacityPercent: 100}, 6, 4},
+		{"Half capacity", &User{CapacityPercent: 50}, 6, 3},
+		{"Half of personal limit", &User{MaxOpenReviews: &personal, CapacityPercent: 50}, 0, 2},
+		{"Small capacity keeps one slot", &User{CapacityPercent: 10}, 3, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := tt.user.ReviewLimit(tt.teamMax); result != tt.expected {
+				t.Errorf("ReviewLimit() = %v, want %v", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestUser_HasCapacity(t *testing.T) {
+	user := &User{CapacityPercent: 50}
+
+	if !user.HasCapacity(4, 1) {
+		t.Error("HasCapacity() = false, want true below limit")
+	}
+	if user.HasCapacity(4, 2) {
+		t.Error("HasCapacity() = true, want false at limit")
+	}
+	if !user.HasCapacity(0, 100) {
+		t.Error("HasCapacity() = false, want true without limit")
+	}
+}
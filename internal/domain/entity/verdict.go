@@ -0,0 +1,67 @@
+package entity
+
+import "time"
+
+// Verdict - итог ревью от назначенного ревьювера
+type Verdict string
+
+const (
+	VerdictApproved         Verdict = "APPROVED"
+	VerdictChangesRequested Verdict = "CHANGES_REQUESTED"
+	VerdictCommented        Verdict = "COMMENTED" // ответил, но без решения
+)
+
+// Valid проверяет, что вердикт известен
+func (v Verdict) Valid() bool {
+	switch v {
+	case VerdictApproved, VerdictChangesRequested, VerdictCommented:
+		return true
+	}
+	return false
+}
+
+// ReviewVerdict - последний вердикт ревьювера по PR
+type ReviewVerdict struct {
+	PullRequestID string    `json:"pull_request_id"`
+	UserID        string    `json:"user_id"`
+	Verdict       Verdict   `json:"verdict"`
+	SubmittedAt   time.Time `json:"submitted_at"`
+}
+
+// ApprovalStatus - готовность PR к merge по вердиктам ревьюверов
+type ApprovalStatus struct {
+	PullRequestID     string           `json:"pull_request_id"`
+	RequiredApprovals int              `json:"required_approvals"`
+	Approvals         int              `json:"approvals"`
+	ChangesRequested  []string         `json:"changes_requested"` // кто просит изменений
+	Verdicts          []*ReviewVerdict `json:"verdicts"`
+}
+
+// NewApprovalStatus подсчитывает одобрения и запросы изменений
+func NewApprovalStatus(prID string, verdicts []*ReviewVerdict, requiredApprovals int) *ApprovalStatus {
+	status := &ApprovalStatus{
+		PullRequestID:     prID,
+		RequiredApprovals: requiredApprovals,
+		ChangesRequested:  []string{},
+		Verdicts:          verdicts,
+	}
+	if status.Verdicts == nil {
+		status.Verdicts = []*ReviewVerdict{}
+	}
+
+	for _, v := range verdicts {
+		switch v.Verdict {
+		case VerdictApproved:
+			status.Approvals++
+		case VerdictChangesRequested:
+			status.ChangesRequested = append(status.ChangesRequested, v.UserID)
+		}
+	}
+
+	return status
+}
+
+// Mergeable - одобрений достаточно и никто не просит изменений
+func (s *ApprovalStatus) Mergeable() bool {
+	return s.Approvals >= s.RequiredApprovals && len(s.ChangesRequested) == 0
+}
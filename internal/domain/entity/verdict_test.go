@@ -0,0 +1,42 @@
+package entity
+
+import "testing"
+
+func TestVerdict_Valid(t *testing.T) {
+	for _, v := range []Verdict{VerdictApproved, VerdictChangesRequested, VerdictCommented} {
+		if !v.Valid() {
+			t.Errorf("Valid(%q) = false, want true", v)
+		}
+	}
+	if Verdict("LGTM").Valid() {
+		t.Error("Valid() should reject unknown verdict")
+	}
+}
+
+func TestApprovalStatus_Mergeable(t *testing.T) {
+	approved := &ReviewVerdict{UserID: "u1", Verdict: VerdictApproved}
+	commented := &ReviewVerdict{UserID: "u2", Verdict: VerdictCommented}
+	changes := &ReviewVerdict{UserID: "u3", Verdict: VerdictChangesRequested}
+
+	tests := []struct {
+		name     string
+		verdicts []*ReviewVerdict
+		required int
+		expected bool
+	}{
+		{"No gate", nil, 0, true},
+		{"Not enough approvals", []*ReviewVerdict{approved, commented}, 2, false},
+		{"Enough approvals", []*ReviewVerdict{approved, commented}, 1, true},
+		{"Changes requested", []*ReviewVerdict{approved, changes}, 1, false},
+		{"Changes requested without gate", []*ReviewVerdict{changes}, 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status := NewApprovalStatus("pr-1", tt.verdicts, tt.required)
+			if result := status.Mergeable(); result != tt.expected {
+				t.Errorf("Mergeable() = %v, want %v", result, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,76 @@
+package service
+
+import (
+	"context"
+	"sort"
+
+	"reviewer-service/internal/domain/entity"
+	"reviewer-service/internal/repository"
+)
+
+// rank упорядочивает кандидатов по стратегии команды, опускает тех,
+// кто недавно часто ревьюил автора, и поднимает тех, кто на работе
+func (s *ReviewerSelector) rank(
+	ctx context.Context,
+	tx repository.Tx,
+	req SelectionRequest,
+	teamName string,
+	policy *entity.TeamPolicy,
+	candidates []*entity.User,
+) ([]*entity.User, error) {
+	ranked, err := s.strategy(policy).Rank(ctx, tx, teamName, candidates, s.tieBreaker(req))
+	if err != nil {
+		return nil, err
+	}
+
+	ranked, err = s.avoidFrequentPairs(ctx, tx, req, ranked, policy)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.preferWorkingHours(ranked, policy), nil
+}
+
+// avoidFrequentPairs сдвигает каждого кандидата вниз на штраф за общие
+// с автором ревью в окне политики. При равном итоге сохраняется порядок стратегии.
+func (s *ReviewerSelector) avoidFrequentPairs(
+	ctx context.Context,
+	tx repository.Tx,
+	req SelectionRequest,
+	ranked []*entity.User,
+	policy *entity.TeamPolicy,
+) ([]*entity.User, error) {
+	if policy.AntiAffinityDays <= 0 || req.AuthorID == "" || len(ranked) < 2 {
+		return ranked, nil
+	}
+
+	pairs, err := s.recentPairs(ctx, tx, req.AuthorID, ranked, policy)
+	if err != nil {
+		return nil, err
+	}
+
+	score := make(map[string]int, len(ranked))
+	for i, u := range ranked {
+		score[u.UserID] = i + policy.PairPenalty(pairs[u.UserID])
+	}
+
+	result := make([]*entity.User, len(ranked))
+	copy(result, ranked)
+	sort.SliceStable(result, func(i, j int) bool {
+		return score[result[i].UserID] < score[result[j].UserID]
+	})
+
+	return result, nil
+}
+
+// recentPairs возвращает число ревью PR автора у каждого из users за окно политики
+func (s *ReviewerSelector) recentPairs(
+	ctx context.Context,
+	tx repository.Tx,
+	authorID string,
+	users []*entity.User,
+	policy *entity.TeamPolicy,
+) (map[string]int, error) {
+	since := s.Now().AddDate(0, 0, -policy.AntiAffinityDays)
+	return tx.Stats().GetPairCounts(ctx, authorID, userIDs(users), since)
+}
@@ -0,0 +1,78 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"reviewer-service/internal/domain/entity"
+)
+
+func TestReviewerSelector_SelectAntiAffinity(t *testing.T) {
+	selector := NewReviewerSelector()
+	ctx := context.Background()
+
+	tx := &mockTx{
+		usersRepo: &mockUsersRepo{
+			users: map[string]*entity.User{
+				"author": {UserID: "author", TeamName: "backend", IsActive: true},
+				"alice":  {UserID: "alice", TeamName: "backend", IsActive: true},
+				"bob":    {UserID: "bob", TeamName: "backend", IsActive: true},
+				"carol":  {UserID: "carol", TeamName: "backend", IsActive: true},
+			},
+		},
+		statsRepo: &mockStatsRepo{
+			workload: map[string]int{"alice": 0, "bob": 1, "carol": 2},
+			pairs:    map[string]int{"alice": 2, "bob": 0, "carol": 0},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		policy   *entity.TeamPolicy
+		expected []string
+	}{
+		{
+			name:     "Disabled - plain balancing",
+			policy:   &entity.TeamPolicy{TeamName: "backend", ReviewerCount: 2},
+			expected: []string{"alice", "bob"},
+		},
+		{
+			name:     "Frequent pair moves down",
+			policy:   &entity.TeamPolicy{TeamName: "backend", ReviewerCount: 2, AntiAffinityDays: 14},
+			expected: []string{"bob", "alice"},
+		},
+		{
+			name: "Heavier weight pushes pair to the end",
+			policy: &entity.TeamPolicy{
+				TeamName:           "backend",
+				ReviewerCount:      2,
+				AntiAffinityDays:   14,
+				AntiAffinityWeight: 2,
+			},
+			expected: []string{"bob", "carol"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			selected, err := selector.Select(ctx, tx, SelectionRequest{
+				TeamName: "backend",
+				AuthorID: "author",
+				Policy:   tt.policy,
+			})
+			if err != nil {
+				t.Fatalf("Select() error = %v", err)
+			}
+
+			if len(selected) != len(tt.expected) {
+				t.Fatalf("Select() = %v, want %v", userIDs(selected), tt.expected)
+			}
+
+			for i, id := range tt.expected {
+				if selected[i].UserID != id {
+					t.Errorf("Select()[%d] = %v, want %v", i, selected[i].UserID, id)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,142 @@
+package service
+
+import (
+	"bufio"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"reviewer-service/internal/domain/entity"
+)
+
+// CodeOwners - разобранный файл CODEOWNERS
+type CodeOwners struct {
+	Rules    []entity.CodeOwnersRule
+	patterns []*regexp.Regexp
+}
+
+// ParseCodeOwners разбирает файл в формате CODEOWNERS.
+//
+// Каждая строка - шаблон пути и список владельцев через пробел.
+// "@user_id" - пользователь, "@org/team_name" - команда целиком.
+// Пустые строки и строки, начинающиеся с "#", пропускаются.
+func ParseCodeOwners(content string) (*CodeOwners, error) {
+	co := &CodeOwners{}
+
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	lineNo := 0
+	for scanner.Scan() {
+		lineNo++
+
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		// Комментарий в конце строки
+		if idx := strings.Index(line, " #"); idx >= 0 {
+			line = strings.TrimSpace(line[:idx])
+		}
+
+		fields := strings.Fields(line)
+		pattern := fields[0]
+
+		re, err := compilePattern(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid pattern %q: %w", lineNo, pattern, err)
+		}
+
+		owners := make([]entity.CodeOwner, 0, len(fields)-1)
+		for _, field := range fields[1:] {
+			owner, err := parseOwner(field)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", lineNo, err)
+			}
+			owners = append(owners, owner)
+		}
+
+		co.Rules = append(co.Rules, entity.CodeOwnersRule{Pattern: pattern, Owners: owners})
+		co.patterns = append(co.patterns, re)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return co, nil
+}
+
+// OwnersOf возвращает владельцев пути. Как и в CODEOWNERS,
+// побеждает последнее подходящее правило.
+func (co *CodeOwners) OwnersOf(path string) []entity.CodeOwner {
+	path = strings.TrimPrefix(path, "/")
+
+	for i := len(co.patterns) - 1; i >= 0; i-- {
+		if co.patterns[i].MatchString(path) {
+			return co.Rules[i].Owners
+		}
+	}
+
+	return nil
+}
+
+func parseOwner(field string) (entity.CodeOwner, error) {
+	if !strings.HasPrefix(field, "@") || len(field) == 1 {
+		return entity.CodeOwner{}, fmt.Errorf("invalid owner %q, expected @user or @org/team", field)
+	}
+
+	name := field[1:]
+	if _, team, ok := strings.Cut(name, "/"); ok {
+		if team == "" {
+			return entity.CodeOwner{}, fmt.Errorf("invalid team owner %q", field)
+		}
+		return entity.CodeOwner{Type: entity.OwnerTeam, Name: team}, nil
+	}
+
+	return entity.CodeOwner{Type: entity.OwnerUser, Name: name}, nil
+}
+
+// compilePattern переводит шаблон CODEOWNERS в регулярное выражение.
+//
+//   - "/" в начале или в середине привязывает шаблон к корню репозитория,
+//     иначе он совпадает на любой глубине;
+//   - "/" в конце совпадает только с содержимым каталога;
+//   - "*" - любые символы внутри сегмента, "**" - любое число сегментов.
+func compilePattern(pattern string) (*regexp.Regexp, error) {
+	dirOnly := strings.HasSuffix(pattern, "/")
+	p := strings.TrimSuffix(pattern, "/")
+
+	anchored := strings.HasPrefix(p, "/") || strings.Contains(p, "/")
+	p = strings.TrimPrefix(p, "/")
+
+	var b strings.Builder
+	b.WriteString("^")
+	if !anchored {
+		b.WriteString("(.*/)?")
+	}
+
+	for i := 0; i < len(p); i++ {
+		switch {
+		case strings.HasPrefix(p[i:], "**/"):
+			b.WriteString("(.*/)?")
+			i += 2
+		case strings.HasPrefix(p[i:], "**"):
+			b.WriteString(".*")
+			i++
+		case p[i] == '*':
+			b.WriteString("[^/]*")
+		case p[i] == '?':
+			b.WriteString("[^/]")
+		default:
+			b.WriteString(regexp.QuoteMeta(string(p[i])))
+		}
+	}
+
+	if dirOnly {
+		b.WriteString("/.*$")
+	} else {
+		b.WriteString("(/.*)?$")
+	}
+
+	return regexp.Compile(b.String())
+}
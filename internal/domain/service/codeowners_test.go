@@ -0,0 +1,67 @@
+package service
+
+import (
+	"testing"
+
+	"reviewer-service/internal/domain/entity"
+)
+
+func TestParseCodeOwners(t *testing.T) {
+	content := `
+# Владельцы по умолчанию
+*                 @lead
+/internal/http/   @org/frontend
+*.sql             @dba @org/backend  # миграции
+docs/**/*.md      @writer
+/cmd/api/main.go
+`
+
+	co, err := ParseCodeOwners(content)
+	if err != nil {
+		t.Fatalf("ParseCodeOwners() error = %v", err)
+	}
+
+	if len(co.Rules) != 5 {
+		t.Fatalf("ParseCodeOwners() = %v rules, want 5", len(co.Rules))
+	}
+
+	tests := []struct {
+		path     string
+		expected []entity.CodeOwner
+	}{
+		{"README.md", []entity.CodeOwner{{Type: entity.OwnerUser, Name: "lead"}}},
+		{"internal/http/router.go", []entity.CodeOwner{{Type: entity.OwnerTeam, Name: "frontend"}}},
+		{"/internal/http/handler/team.go", []entity.CodeOwner{{Type: entity.OwnerTeam, Name: "frontend"}}},
+		{"migrations/001_init.up.sql", []entity.CodeOwner{
+			{Type: entity.OwnerUser, Name: "dba"},
+			{Type: entity.OwnerTeam, Name: "backend"},
+		}},
+		{"docs/api/v1/intro.md", []entity.CodeOwner{{Type: entity.OwnerUser, Name: "writer"}}},
+		{"docs/intro.md", []entity.CodeOwner{{Type: entity.OwnerUser, Name: "writer"}}},
+		{"cmd/api/main.go", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			owners := co.OwnersOf(tt.path)
+			if len(owners) != len(tt.expected) {
+				t.Fatalf("OwnersOf(%q) = %v, want %v", tt.path, owners, tt.expected)
+			}
+			for i := range owners {
+				if owners[i] != tt.expected[i] {
+					t.Errorf("OwnersOf(%q)[%d] = %v, want %v", tt.path, i, owners[i], tt.expected[i])
+				}
+			}
+		})
+	}
+}
+
+func TestParseCodeOwners_InvalidOwner(t *testing.T) {
+	if _, err := ParseCodeOwners("*.go alice"); err == nil {
+		t.Error("ParseCodeOwners() expected error for owner without @, got nil")
+	}
+
+	if _, err := ParseCodeOwners("*.go @org/"); err == nil {
+		t.Error("ParseCodeOwners() expected error for empty team, got nil")
+	}
+}
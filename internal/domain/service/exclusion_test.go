@@ -0,0 +1,89 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"reviewer-service/internal/domain/entity"
+	"reviewer-service/internal/repository"
+)
+
+func TestReviewerSelector_Exclusions(t *testing.T) {
+	selector := NewReviewerSelector()
+	ctx := context.Background()
+
+	tx := &mockTx{
+		usersRepo: &mockUsersRepo{
+			users: map[string]*entity.User{
+				"author":  {UserID: "author", TeamName: "backend", IsActive: true},
+				"manager": {UserID: "manager", TeamName: "backend", IsActive: true},
+				"alice":   {UserID: "alice", TeamName: "backend", IsActive: true},
+				"bob":     {UserID: "bob", TeamName: "backend", IsActive: true},
+			},
+		},
+		statsRepo: &mockStatsRepo{
+			workload: map[string]int{"manager": 0, "alice": 1, "bob": 2},
+		},
+		exclusionRepo: &mockExclusionRepo{
+			rules: []*entity.ReviewExclusion{
+				{AuthorID: "author", ReviewerID: "manager"},
+				{AuthorID: "bob", ReviewerID: "author", BothWays: true},
+			},
+		},
+	}
+
+	policy := &entity.TeamPolicy{TeamName: "backend", ReviewerCount: 2}
+
+	t.Run("Select skips excluded pairs", func(t *testing.T) {
+		selected, err := selector.Select(ctx, tx, SelectionRequest{
+			TeamName: "backend",
+			AuthorID: "author",
+			Policy:   policy,
+		})
+		if err != nil {
+			t.Fatalf("Select() error = %v", err)
+		}
+
+		// manager исключён напрямую, bob - обратным правилом
+		if len(selected) != 1 || selected[0].UserID != "alice" {
+			t.Errorf("Select() = %v, want [alice]", userIDs(selected))
+		}
+	})
+
+	t.Run("Replacement reports exclusion", func(t *testing.T) {
+		_, err := selector.SelectReplacement(ctx, tx, SelectionRequest{
+			TeamName:       "backend",
+			AuthorID:       "author",
+			ExcludeUserIDs: []string{"alice"},
+			Policy:         policy,
+		})
+		if !errors.Is(err, repository.ErrNoCandidate) || !errors.Is(err, repository.ErrExcludedByRule) {
+			t.Errorf("SelectReplacement() error = %v, want %v", err, repository.ErrExcludedByRule)
+		}
+	})
+
+	t.Run("Select reports exclusion", func(t *testing.T) {
+		_, err := selector.Select(ctx, tx, SelectionRequest{
+			TeamName:       "backend",
+			AuthorID:       "author",
+			ExcludeUserIDs: []string{"alice"},
+			Policy:         policy,
+		})
+		if !errors.Is(err, repository.ErrNoCandidate) || !errors.Is(err, repository.ErrExcludedByRule) {
+			t.Errorf("Select() error = %v, want %v", err, repository.ErrExcludedByRule)
+		}
+	})
+
+	t.Run("Empty pool without exclusions is not an error", func(t *testing.T) {
+		replacement, err := selector.SelectReplacement(ctx, tx, SelectionRequest{
+			TeamName:       "backend",
+			AuthorID:       "author",
+			ExcludeUserIDs: []string{"alice", "manager", "bob"},
+			Policy:         policy,
+		})
+		if err != nil || replacement != nil {
+			t.Errorf("SelectReplacement() = %v, %v, want nil, nil", replacement, err)
+		}
+	})
+}
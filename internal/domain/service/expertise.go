@@ -0,0 +1,100 @@
+package service
+
+import (
+	"context"
+
+	"reviewer-service/internal/domain/entity"
+	"reviewer-service/internal/repository"
+)
+
+// assignedUsers загружает уже назначенных ревьюверов, чтобы учесть их экспертизу и уровень
+func (s *ReviewerSelector) assignedUsers(
+	ctx context.Context,
+	tx repository.Tx,
+	req SelectionRequest,
+) ([]*entity.User, error) {
+	policy := req.policy()
+	if len(req.Labels) == 0 && !policy.RequireSenior && !policy.LearningReviewer {
+		return nil, nil
+	}
+
+	users := make([]*entity.User, 0, len(req.AssignedUserIDs))
+	for _, id := range req.AssignedUserIDs {
+		user, err := tx.Users().GetByID(ctx, id)
+		if err == repository.ErrNotFound {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		users = append(users, user)
+	}
+
+	return users, nil
+}
+
+// uncoveredLabels возвращает метки PR, которые не закрывает ни один
+// из уже назначенных или выбранных ревьюверов
+func uncoveredLabels(labels []string, groups ...[]*entity.User) []string {
+	var result []string
+	for _, label := range labels {
+		if !coversLabel(label, groups...) {
+			result = append(result, label)
+		}
+	}
+	return result
+}
+
+func coversLabel(label string, groups ...[]*entity.User) bool {
+	for _, users := range groups {
+		for _, u := range users {
+			if u.HasTag(label) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// prioritizeLabels поднимает в начало ranked кандидатов, закрывающих
+// непокрытые метки (не больше slots человек). Остальные кандидаты
+// сохраняют порядок стратегии, поэтому при отсутствии экспертов
+// работает обычная балансировка.
+func prioritizeLabels(ranked []*entity.User, labels []string, slots int) []*entity.User {
+	if len(labels) == 0 || slots <= 0 {
+		return ranked
+	}
+
+	var experts []*entity.User
+	picked := make(map[string]bool)
+
+	for _, label := range labels {
+		if len(experts) >= slots {
+			break
+		}
+		if coversLabel(label, experts) {
+			continue
+		}
+
+		for _, u := range ranked {
+			if !picked[u.UserID] && u.HasTag(label) {
+				experts = append(experts, u)
+				picked[u.UserID] = true
+				break
+			}
+		}
+	}
+
+	if len(experts) == 0 {
+		return ranked
+	}
+
+	result := make([]*entity.User, 0, len(ranked))
+	result = append(result, experts...)
+	for _, u := range ranked {
+		if !picked[u.UserID] {
+			result = append(result, u)
+		}
+	}
+	return result
+}
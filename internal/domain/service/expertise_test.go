@@ -0,0 +1,78 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"reviewer-service/internal/domain/entity"
+)
+
+func TestReviewerSelector_SelectWithLabels(t *testing.T) {
+	selector := NewReviewerSelector()
+	ctx := context.Background()
+
+	tx := &mockTx{
+		usersRepo: &mockUsersRepo{
+			users: map[string]*entity.User{
+				"author": {UserID: "author", TeamName: "backend", IsActive: true},
+				"alice":  {UserID: "alice", TeamName: "backend", IsActive: true},
+				"bob":    {UserID: "bob", TeamName: "backend", IsActive: true, Tags: []string{"database"}},
+				"carol":  {UserID: "carol", TeamName: "backend", IsActive: true, Tags: []string{"security"}},
+			},
+		},
+		statsRepo: &mockStatsRepo{
+			workload: map[string]int{"alice": 1, "bob": 5, "carol": 7},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		req      SelectionRequest
+		expected []string
+	}{
+		{
+			name:     "No labels - plain balancing",
+			req:      SelectionRequest{TeamName: "backend", AuthorID: "author"},
+			expected: []string{"alice", "bob"},
+		},
+		{
+			name:     "Expert covers label despite higher load",
+			req:      SelectionRequest{TeamName: "backend", AuthorID: "author", Labels: []string{"security"}},
+			expected: []string{"carol", "alice"},
+		},
+		{
+			name:     "Label without experts falls back to balancing",
+			req:      SelectionRequest{TeamName: "backend", AuthorID: "author", Labels: []string{"frontend"}},
+			expected: []string{"alice", "bob"},
+		},
+		{
+			name: "Label already covered by assigned reviewer",
+			req: SelectionRequest{
+				TeamName:        "backend",
+				AuthorID:        "author",
+				AssignedUserIDs: []string{"carol"},
+				Labels:          []string{"security"},
+			},
+			expected: []string{"alice", "bob"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			selected, err := selector.Select(ctx, tx, tt.req)
+			if err != nil {
+				t.Fatalf("Select() error = %v", err)
+			}
+
+			if len(selected) != len(tt.expected) {
+				t.Fatalf("Select() = %v reviewers, want %v", len(selected), len(tt.expected))
+			}
+
+			for i, id := range tt.expected {
+				if selected[i].UserID != id {
+					t.Errorf("Select()[%d] = %v, want %v", i, selected[i].UserID, id)
+				}
+			}
+		})
+	}
+}
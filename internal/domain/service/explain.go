@@ -0,0 +1,293 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"reviewer-service/internal/domain/entity"
+	"reviewer-service/internal/repository"
+)
+
+// Explain выполняет подбор ревьюверов без записи и объясняет решение
+// по каждому участнику команды и резервных команд. Выбранные идут первыми
+// в порядке выбора, затем допущенные в том порядке, в каком подбор
+// добирал бы их дальше, затем исключённые. Причины исключения дают
+// те же фильтры, что и подбор; если подбор никого не выбрал,
+// причина записывается в Unselected.
+func (s *ReviewerSelector) Explain(
+	ctx context.Context,
+	tx repository.Tx,
+	req SelectionRequest,
+) (*entity.SelectionExplanation, error) {
+	req, err := s.withExclusions(ctx, tx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	policy := req.policy()
+
+	exp := &entity.SelectionExplanation{
+		PullRequestID: req.PullRequestID,
+		TeamName:      req.TeamName,
+		AuthorID:      req.AuthorID,
+		Labels:        req.Labels,
+		ChangedFiles:  req.ChangedFiles,
+		Strategy:      s.strategy(policy).Name(),
+		Policy:        policy,
+		Selected:      []string{},
+		Candidates:    []*entity.CandidateExplanation{},
+	}
+
+	selected, err := s.Select(ctx, tx, req)
+	switch {
+	case errors.Is(err, repository.ErrNoCapacity):
+		exp.AwaitingReviewers = true
+		exp.Unselected = entity.UnselectedAtCapacity
+	case errors.Is(err, repository.ErrOwnerUnavailable):
+		exp.Unselected = entity.UnselectedOwnerUnavailable
+	case errors.Is(err, repository.ErrExcludedByRule):
+		exp.Unselected = entity.UnselectedExcludedByRule
+	case err != nil:
+		return nil, err
+	case len(selected) == 0 && policy.ReviewerCount > 0:
+		exp.Unselected = entity.UnselectedNoCandidate
+	}
+
+	owners, err := s.ownerIDs(ctx, tx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	byID := make(map[string]*entity.CandidateExplanation)
+	var all []*entity.CandidateExplanation
+
+	teams := append([]string{req.TeamName}, policy.FallbackTeams...)
+	for i, teamName := range teams {
+		teamPolicy := policy
+		if i > 0 {
+			if teamPolicy, err = LoadTeamPolicy(ctx, tx, teamName); err != nil {
+				return nil, err
+			}
+		}
+
+		members, err := tx.Users().GetByTeam(ctx, teamName)
+		if err != nil {
+			return nil, err
+		}
+
+		var fresh []*entity.User
+		for _, u := range members {
+			if byID[u.UserID] == nil {
+				fresh = append(fresh, u)
+			}
+		}
+
+		explained, err := s.explainUsers(ctx, tx, req, teamPolicy, owners, fresh, byID)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, explained...)
+	}
+
+	next, err := s.following(ctx, tx, req, selected, len(byID)+len(owners))
+	if err != nil {
+		return nil, err
+	}
+	ranked := slices.Concat(selected, next)
+
+	// Владельцы путей из других команд могли попасть в выбор
+	for _, u := range ranked {
+		if byID[u.UserID] != nil {
+			continue
+		}
+
+		ownerPolicy, err := LoadTeamPolicy(ctx, tx, u.TeamName)
+		if err != nil {
+			return nil, err
+		}
+		explained, err := s.explainUsers(ctx, tx, req, ownerPolicy, owners, []*entity.User{u}, byID)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, explained...)
+	}
+
+	for i, u := range ranked {
+		c := byID[u.UserID]
+		c.Rank = i + 1
+		c.Selected = i < len(selected)
+		if c.Selected {
+			exp.Selected = append(exp.Selected, u.UserID)
+		}
+		exp.Candidates = append(exp.Candidates, c)
+	}
+	// Допущенные, которых подбор не взял бы ни на какое место
+	for _, c := range all {
+		if c.Rank == 0 && c.Excluded == "" {
+			exp.Candidates = append(exp.Candidates, c)
+		}
+	}
+	for _, c := range all {
+		if c.Excluded != "" {
+			exp.Candidates = append(exp.Candidates, c)
+		}
+	}
+
+	return exp, nil
+}
+
+// following возвращает до count допущенных кандидатов в том порядке,
+// в каком подбор добирал бы их к уже выбранным
+func (s *ReviewerSelector) following(
+	ctx context.Context,
+	tx repository.Tx,
+	req SelectionRequest,
+	selected []*entity.User,
+	count int,
+) ([]*entity.User, error) {
+	rest := req
+	rest.AssignedUserIDs = slices.Concat(req.AssignedUserIDs, userIDs(selected))
+
+	next, err := s.fill(ctx, tx, rest, count)
+	if errors.Is(err, repository.ErrOwnerUnavailable) {
+		return nil, nil
+	}
+	return next, err
+}
+
+// explainUsers объясняет кандидатов с политикой их команды: причину
+// исключения даёт screen, а в режиме REQUIRE не владельцы путей исключаются
+func (s *ReviewerSelector) explainUsers(
+	ctx context.Context,
+	tx repository.Tx,
+	req SelectionRequest,
+	policy *entity.TeamPolicy,
+	owners map[string]bool,
+	users []*entity.User,
+	byID map[string]*entity.CandidateExplanation,
+) ([]*entity.CandidateExplanation, error) {
+	if len(users) == 0 {
+		return nil, nil
+	}
+
+	_, reasons, err := s.screen(ctx, tx, req, users, req.excluded(nil), policy)
+	if err != nil {
+		return nil, err
+	}
+
+	openReviews, err := tx.Stats().GetOpenReviews(ctx, userIDs(users))
+	if err != nil {
+		return nil, err
+	}
+
+	workload, err := tx.Stats().GetWorkload(ctx, userIDs(users))
+	if err != nil {
+		return nil, err
+	}
+
+	pairs := make(map[string]int)
+	if policy.AntiAffinityDays > 0 && req.AuthorID != "" {
+		if pairs, err = s.recentPairs(ctx, tx, req.AuthorID, users, policy); err != nil {
+			return nil, err
+		}
+	}
+
+	now := s.Now()
+	tie := s.tieBreaker(req)
+	requireOwner := req.policy().OwnershipMode == entity.OwnershipRequire && len(owners) > 0
+
+	explained := make([]*entity.CandidateExplanation, 0, len(users))
+	for _, u := range users {
+		wait := u.OffHoursWait(now)
+
+		excluded := reasons[u.UserID]
+		if excluded == "" && requireOwner && !owners[u.UserID] {
+			excluded = entity.ExcludedNotOwner
+		}
+
+		c := &entity.CandidateExplanation{
+			UserID:              u.UserID,
+			TeamName:            u.TeamName,
+			OpenReviews:         openReviews[u.UserID],
+			ReviewLimit:         u.ReviewLimit(policy.MaxOpenReviews),
+			WeightedLoad:        workload[u.UserID],
+			OffHoursWaitSeconds: int64(wait.Seconds()),
+			RecentPairReviews:   pairs[u.UserID],
+			TieBreak:            fmt.Sprintf("%016x", tie.Key(u.UserID)),
+			Excluded:            excluded,
+			Rules:               s.ruleHits(u, req, policy, owners, wait, pairs[u.UserID]),
+		}
+		byID[u.UserID] = c
+		explained = append(explained, c)
+	}
+
+	return explained, nil
+}
+
+// ownerIDs возвращает всех владельцев путей, затронутых PR
+func (s *ReviewerSelector) ownerIDs(
+	ctx context.Context,
+	tx repository.Tx,
+	req SelectionRequest,
+) (map[string]bool, error) {
+	owners := make(map[string]bool)
+	if req.policy().OwnershipMode == entity.OwnershipOff || len(req.ChangedFiles) == 0 {
+		return owners, nil
+	}
+
+	sets, err := s.ownerSets(ctx, tx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, set := range sets {
+		for id := range set.members {
+			owners[id] = true
+		}
+	}
+	return owners, nil
+}
+
+// ruleHits перечисляет правила политики, повлиявшие на позицию кандидата
+func (s *ReviewerSelector) ruleHits(
+	u *entity.User,
+	req SelectionRequest,
+	policy *entity.TeamPolicy,
+	owners map[string]bool,
+	offHoursWait time.Duration,
+	pairReviews int,
+) []string {
+	rules := []string{}
+
+	if owners[u.UserID] {
+		rules = append(rules, entity.RuleCodeOwner)
+	}
+	for _, label := range req.Labels {
+		if u.HasTag(label) {
+			rules = append(rules, entity.RuleLabelPrefix+label)
+		}
+	}
+	if policy.PreferWorkingHours && offHoursWait <= time.Duration(policy.PreferWithinHours)*time.Hour {
+		rules = append(rules, entity.RuleWorkingHours)
+	}
+	if policy.PairPenalty(pairReviews) > 0 {
+		rules = append(rules, entity.RuleAntiAffinity)
+	}
+	if u.TeamName != req.TeamName {
+		rules = append(rules, entity.RuleFallbackTeam)
+	}
+	if policy.RequireSenior && u.IsSenior() {
+		rules = append(rules, entity.RuleSenior)
+	}
+	if policy.LearningReviewer && u.IsJunior() {
+		rules = append(rules, entity.RuleLearner)
+	}
+	if u.MaxOpenReviews != nil || (u.CapacityPercent > 0 && u.CapacityPercent < entity.FullCapacity) {
+		rules = append(rules, entity.RulePersonalLimit)
+	}
+
+	return rules
+}
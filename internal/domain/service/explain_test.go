@@ -0,0 +1,248 @@
+package service
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"reviewer-service/internal/domain/entity"
+)
+
+func TestReviewerSelector_Explain(t *testing.T) {
+	selector := NewReviewerSelector()
+	ctx := context.Background()
+
+	tx := &mockTx{
+		usersRepo: &mockUsersRepo{
+			users: map[string]*entity.User{
+				"author": {UserID: "author", TeamName: "backend", IsActive: true},
+				"alice":  {UserID: "alice", TeamName: "backend", IsActive: true},
+				"bob":    {UserID: "bob", TeamName: "backend", IsActive: true, Tags: []string{"security"}},
+				"carol":  {UserID: "carol", TeamName: "backend", IsActive: false},
+				"dave":   {UserID: "dave", TeamName: "backend", IsActive: true},
+				"erin":   {UserID: "erin", TeamName: "backend", IsActive: true},
+			},
+		},
+		statsRepo: &mockStatsRepo{
+			workload: map[string]int{"alice": 1, "bob": 2, "dave": 4, "erin": 3},
+		},
+	}
+
+	exp, err := selector.Explain(ctx, tx, SelectionRequest{
+		PullRequestID: "pr-1",
+		TeamName:      "backend",
+		AuthorID:      "author",
+		Labels:        []string{"security"},
+		Policy:        &entity.TeamPolicy{TeamName: "backend", ReviewerCount: 2, MaxOpenReviews: 4},
+	})
+	if err != nil {
+		t.Fatalf("Explain() error = %v", err)
+	}
+
+	if len(exp.Selected) != 2 || exp.Selected[0] != "bob" || exp.Selected[1] != "alice" {
+		t.Errorf("Selected = %v, want [bob alice]", exp.Selected)
+	}
+
+	if len(exp.Candidates) != 6 {
+		t.Fatalf("Candidates = %d, want 6", len(exp.Candidates))
+	}
+
+	// Допущенные идут первыми и пронумерованы подряд
+	for i, want := range []string{"bob", "alice", "erin"} {
+		c := exp.Candidates[i]
+		if c.UserID != want || c.Rank != i+1 || c.Excluded != "" {
+			t.Errorf("Candidates[%d] = %s rank %d excluded %q, want %s rank %d",
+				i, c.UserID, c.Rank, c.Excluded, want, i+1)
+		}
+	}
+
+	byID := make(map[string]*entity.CandidateExplanation)
+	for _, c := range exp.Candidates {
+		byID[c.UserID] = c
+	}
+
+	excluded := map[string]string{
+		"author": entity.ExcludedAuthor,
+		"carol":  entity.ExcludedInactive,
+		"dave":   entity.ExcludedAtCapacity,
+	}
+	for id, reason := range excluded {
+		if c := byID[id]; c.Excluded != reason || c.Rank != 0 || c.Selected {
+			t.Errorf("%s: excluded = %q rank %d, want %q", id, c.Excluded, c.Rank, reason)
+		}
+	}
+
+	if !byID["bob"].Selected || byID["erin"].Selected {
+		t.Errorf("Selected flags: bob = %v, erin = %v", byID["bob"].Selected, byID["erin"].Selected)
+	}
+	if rules := byID["bob"].Rules; len(rules) != 1 || rules[0] != entity.RuleLabelPrefix+"security" {
+		t.Errorf("bob rules = %v, want [label:security]", rules)
+	}
+	if byID["dave"].OpenReviews != 4 || byID["dave"].ReviewLimit != 4 {
+		t.Errorf("dave open/limit = %d/%d, want 4/4", byID["dave"].OpenReviews, byID["dave"].ReviewLimit)
+	}
+	if byID["alice"].TieBreak == "" {
+		t.Error("alice tie_break is empty")
+	}
+}
+
+func TestReviewerSelector_ExplainMatchesSelect(t *testing.T) {
+	selector := NewReviewerSelector()
+	ctx := context.Background()
+
+	backend := func(users map[string]*entity.User, workload map[string]int) *mockTx {
+		return &mockTx{
+			usersRepo: &mockUsersRepo{users: users},
+			statsRepo: &mockStatsRepo{workload: workload},
+		}
+	}
+
+	tests := []struct {
+		name           string
+		tx             *mockTx
+		req            SelectionRequest
+		wantRanked     []string // nil = не проверять порядок
+		wantUnselected string
+	}{
+		{
+			name: "labels move experts ahead",
+			tx: backend(map[string]*entity.User{
+				"author": {UserID: "author", TeamName: "backend", IsActive: true},
+				"alice":  {UserID: "alice", TeamName: "backend", IsActive: true},
+				"bob":    {UserID: "bob", TeamName: "backend", IsActive: true, Tags: []string{"db"}},
+				"carol":  {UserID: "carol", TeamName: "backend", IsActive: true, Tags: []string{"security"}},
+				"dave":   {UserID: "dave", TeamName: "backend", IsActive: true},
+			}, map[string]int{"alice": 0, "bob": 3, "carol": 5, "dave": 1}),
+			req: SelectionRequest{
+				PullRequestID: "pr-1",
+				TeamName:      "backend",
+				AuthorID:      "author",
+				Labels:        []string{"security", "db"},
+				Policy:        &entity.TeamPolicy{TeamName: "backend", ReviewerCount: 1},
+			},
+			// После выбора carol первым добирался бы bob - он закрывает метку db
+			wantRanked: []string{"carol", "bob", "alice", "dave"},
+		},
+		{
+			name: "owners from other teams come first",
+			tx:   newOwnershipTx(),
+			req: SelectionRequest{
+				PullRequestID: "pr-2",
+				TeamName:      "backend",
+				AuthorID:      "author",
+				ChangedFiles:  []string{"web/app.ts", "README.md"},
+				Policy:        &entity.TeamPolicy{TeamName: "backend", ReviewerCount: 2, OwnershipMode: entity.OwnershipPrefer},
+			},
+			wantRanked: []string{"front2", "user1", "user2", "user3"},
+		},
+		{
+			name: "fallback team fills the gap",
+			tx: backend(map[string]*entity.User{
+				"author": {UserID: "author", TeamName: "backend", IsActive: true},
+				"alice":  {UserID: "alice", TeamName: "backend", IsActive: true},
+				"front1": {UserID: "front1", TeamName: "frontend", IsActive: true},
+				"front2": {UserID: "front2", TeamName: "frontend", IsActive: true},
+			}, map[string]int{"alice": 1, "front1": 4, "front2": 2}),
+			req: SelectionRequest{
+				PullRequestID: "pr-3",
+				TeamName:      "backend",
+				AuthorID:      "author",
+				Policy: &entity.TeamPolicy{
+					TeamName:      "backend",
+					ReviewerCount: 2,
+					FallbackTeams: []string{"frontend"},
+				},
+			},
+		},
+		{
+			name: "everyone at capacity",
+			tx: backend(map[string]*entity.User{
+				"author": {UserID: "author", TeamName: "backend", IsActive: true},
+				"alice":  {UserID: "alice", TeamName: "backend", IsActive: true},
+				"bob":    {UserID: "bob", TeamName: "backend", IsActive: true},
+			}, map[string]int{"alice": 2, "bob": 3}),
+			req: SelectionRequest{
+				PullRequestID: "pr-4",
+				TeamName:      "backend",
+				AuthorID:      "author",
+				Policy:        &entity.TeamPolicy{TeamName: "backend", ReviewerCount: 1, MaxOpenReviews: 2},
+			},
+			wantUnselected: entity.UnselectedAtCapacity,
+		},
+		{
+			name: "required owner unavailable",
+			tx:   newOwnershipTx(),
+			req: SelectionRequest{
+				PullRequestID: "pr-5",
+				TeamName:      "backend",
+				AuthorID:      "author",
+				ChangedFiles:  []string{"migrations/002.up.sql"},
+				Policy: &entity.TeamPolicy{
+					TeamName:       "backend",
+					ReviewerCount:  1,
+					MaxOpenReviews: 5,
+					OwnershipMode:  entity.OwnershipRequire,
+				},
+			},
+			wantUnselected: entity.UnselectedOwnerUnavailable,
+		},
+		{
+			name: "exclusion rules remove everyone",
+			tx: &mockTx{
+				usersRepo: &mockUsersRepo{users: map[string]*entity.User{
+					"author": {UserID: "author", TeamName: "backend", IsActive: true},
+					"alice":  {UserID: "alice", TeamName: "backend", IsActive: true},
+				}},
+				statsRepo: &mockStatsRepo{workload: map[string]int{}},
+				exclusionRepo: &mockExclusionRepo{rules: []*entity.ReviewExclusion{
+					{AuthorID: "author", ReviewerID: "alice"},
+				}},
+			},
+			req: SelectionRequest{
+				PullRequestID: "pr-6",
+				TeamName:      "backend",
+				AuthorID:      "author",
+				Policy:        &entity.TeamPolicy{TeamName: "backend", ReviewerCount: 1},
+			},
+			wantUnselected: entity.UnselectedExcludedByRule,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			selected, _ := selector.Select(ctx, tt.tx, tt.req)
+
+			exp, err := selector.Explain(ctx, tt.tx, tt.req)
+			if err != nil {
+				t.Fatalf("Explain() error = %v", err)
+			}
+
+			if !slices.Equal(exp.Selected, userIDs(selected)) {
+				t.Errorf("Explain() selected = %v, Select() = %v", exp.Selected, userIDs(selected))
+			}
+			if exp.Unselected != tt.wantUnselected {
+				t.Errorf("Unselected = %q, want %q", exp.Unselected, tt.wantUnselected)
+			}
+
+			// Места идут подряд с первого, выбранные - на первых местах,
+			// а у исключённых места нет
+			var ranked []string
+			rank := 0
+			for _, c := range exp.Candidates {
+				if c.Rank == 0 {
+					continue
+				}
+				ranked = append(ranked, c.UserID)
+				rank++
+				if c.Rank != rank || c.Excluded != "" || c.Selected != (rank <= len(selected)) {
+					t.Errorf("%s: rank %d excluded %q selected %v, want rank %d",
+						c.UserID, c.Rank, c.Excluded, c.Selected, rank)
+				}
+			}
+
+			if tt.wantRanked != nil && !slices.Equal(ranked, tt.wantRanked) {
+				t.Errorf("ranked = %v, want %v", ranked, tt.wantRanked)
+			}
+		})
+	}
+}
@@ -0,0 +1,272 @@
+package service
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"reviewer-service/internal/domain/entity"
+	"reviewer-service/internal/repository"
+)
+
+// ownerSet - владельцы одной группы затронутых путей
+type ownerSet struct {
+	members   map[string]bool // все владельцы, включая недоступных
+	available []*entity.User  // владельцы, которых можно назначить
+}
+
+// ownerSets разбивает изменённые файлы на группы с одинаковыми владельцами
+// по CODEOWNERS команды. Порядок групп совпадает с порядком файлов.
+func (s *ReviewerSelector) ownerSets(
+	ctx context.Context,
+	tx repository.Tx,
+	req SelectionRequest,
+) ([]*ownerSet, error) {
+	file, err := tx.CodeOwners().GetByTeam(ctx, req.TeamName)
+	if err == repository.ErrNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	codeOwners, err := ParseCodeOwners(file.Content)
+	if err != nil {
+		return nil, err
+	}
+
+	var sets []*ownerSet
+	seen := make(map[string]bool)
+
+	for _, path := range req.ChangedFiles {
+		owners := codeOwners.OwnersOf(path)
+		if len(owners) == 0 {
+			continue
+		}
+
+		key := ownersKey(owners)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		set, err := s.resolveOwners(ctx, tx, req, owners)
+		if err != nil {
+			return nil, err
+		}
+		sets = append(sets, set)
+	}
+
+	return sets, nil
+}
+
+// resolveOwners раскрывает владельцев-команды в участников
+// и отбирает тех, кого можно назначить
+func (s *ReviewerSelector) resolveOwners(
+	ctx context.Context,
+	tx repository.Tx,
+	req SelectionRequest,
+	owners []entity.CodeOwner,
+) (*ownerSet, error) {
+	set := &ownerSet{members: make(map[string]bool)}
+
+	var users []*entity.User
+	for _, owner := range owners {
+		switch owner.Type {
+		case entity.OwnerUser:
+			user, err := tx.Users().GetByID(ctx, owner.Name)
+			if err == repository.ErrNotFound {
+				continue
+			}
+			if err != nil {
+				return nil, err
+			}
+			users = append(users, user)
+		case entity.OwnerTeam:
+			members, err := tx.Users().GetByTeam(ctx, owner.Name)
+			if err != nil {
+				return nil, err
+			}
+			users = append(users, members...)
+		}
+	}
+
+	var unique []*entity.User
+	for _, user := range users {
+		if set.members[user.UserID] {
+			continue
+		}
+		set.members[user.UserID] = true
+		unique = append(unique, user)
+	}
+
+	available, err := s.availableByTeam(ctx, tx, req, unique)
+	if err != nil {
+		return nil, err
+	}
+	set.available = available
+
+	return set, nil
+}
+
+// availableByTeam применяет к пользователям лимиты политик их собственных команд
+func (s *ReviewerSelector) availableByTeam(
+	ctx context.Context,
+	tx repository.Tx,
+	req SelectionRequest,
+	users []*entity.User,
+) ([]*entity.User, error) {
+	byTeam := make(map[string][]*entity.User)
+	var teams []string
+	for _, user := range users {
+		if _, ok := byTeam[user.TeamName]; !ok {
+			teams = append(teams, user.TeamName)
+		}
+		byTeam[user.TeamName] = append(byTeam[user.TeamName], user)
+	}
+
+	excludeMap := req.excluded(nil)
+
+	var result []*entity.User
+	for _, teamName := range teams {
+		policy := req.policy()
+		if teamName != req.TeamName {
+			var err error
+			if policy, err = LoadTeamPolicy(ctx, tx, teamName); err != nil {
+				return nil, err
+			}
+		}
+
+		available, err := s.available(ctx, tx, req, byTeam[teamName], excludeMap, policy)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, available...)
+	}
+
+	return result, nil
+}
+
+// coverOwners добавляет по одному ревьюверу на каждую группу владельцев,
+// которую ещё не покрывают уже назначенные или выбранные ревьюверы
+func (s *ReviewerSelector) coverOwners(
+	ctx context.Context,
+	tx repository.Tx,
+	req SelectionRequest,
+	sets []*ownerSet,
+	assigned []*entity.User,
+	selected []*entity.User,
+	count int,
+) ([]*entity.User, error) {
+	policy := req.policy()
+
+	for _, set := range sets {
+		if len(selected) >= count {
+			break
+		}
+
+		if set.coveredBy(req.AssignedUserIDs, selected) {
+			continue
+		}
+
+		candidates := notSelected(set.available, selected)
+		if len(candidates) == 0 {
+			if policy.OwnershipMode == entity.OwnershipRequire {
+				return nil, repository.ErrOwnerUnavailable
+			}
+			continue
+		}
+
+		// Внутри группы владельцев работает обычная балансировка
+		ranked, err := s.rank(ctx, tx, req, req.TeamName, policy, candidates)
+		if err != nil {
+			return nil, err
+		}
+
+		// При равных условиях берём владельца, который закрывает метки PR
+		ranked = prioritizeLabels(ranked, uncoveredLabels(req.Labels, assigned, selected), 1)
+
+		selected = append(selected, ranked[0])
+	}
+
+	return selected, nil
+}
+
+// fillFromOwners добирает оставшиеся места из всех владельцев затронутых путей
+func (s *ReviewerSelector) fillFromOwners(
+	ctx context.Context,
+	tx repository.Tx,
+	req SelectionRequest,
+	sets []*ownerSet,
+	assigned []*entity.User,
+	selected []*entity.User,
+	count int,
+) ([]*entity.User, error) {
+	if len(selected) >= count {
+		return selected, nil
+	}
+
+	var pool []*entity.User
+	inPool := make(map[string]bool)
+	for _, set := range sets {
+		for _, user := range notSelected(set.available, selected) {
+			if !inPool[user.UserID] {
+				inPool[user.UserID] = true
+				pool = append(pool, user)
+			}
+		}
+	}
+
+	if len(pool) == 0 {
+		return selected, nil
+	}
+
+	ranked, err := s.rank(ctx, tx, req, req.TeamName, req.policy(), pool)
+	if err != nil {
+		return nil, err
+	}
+
+	slots := count - len(selected)
+	ranked = prioritizeLabels(ranked, uncoveredLabels(req.Labels, assigned, selected), slots)
+
+	take := min(slots, len(ranked))
+	return append(selected, ranked[:take]...), nil
+}
+
+func (set *ownerSet) coveredBy(assignedIDs []string, selected []*entity.User) bool {
+	for _, id := range assignedIDs {
+		if set.members[id] {
+			return true
+		}
+	}
+	for _, u := range selected {
+		if set.members[u.UserID] {
+			return true
+		}
+	}
+	return false
+}
+
+func notSelected(users, selected []*entity.User) []*entity.User {
+	taken := make(map[string]bool, len(selected))
+	for _, u := range selected {
+		taken[u.UserID] = true
+	}
+
+	var result []*entity.User
+	for _, u := range users {
+		if !taken[u.UserID] {
+			result = append(result, u)
+		}
+	}
+	return result
+}
+
+func ownersKey(owners []entity.CodeOwner) string {
+	keys := make([]string, len(owners))
+	for i, o := range owners {
+		keys[i] = string(o.Type) + ":" + o.Name
+	}
+	sort.Strings(keys)
+	return strings.Join(keys, ",")
+}
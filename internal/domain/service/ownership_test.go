@@ -0,0 +1,113 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"reviewer-service/internal/domain/entity"
+	"reviewer-service/internal/repository"
+)
+
+func newOwnershipTx() *mockTx {
+	return &mockTx{
+		usersRepo: &mockUsersRepo{
+			users: map[string]*entity.User{
+				"author": {UserID: "author", TeamName: "backend", IsActive: true},
+				"user1":  {UserID: "user1", TeamName: "backend", IsActive: true},
+				"user2":  {UserID: "user2", TeamName: "backend", IsActive: true},
+				"user3":  {UserID: "user3", TeamName: "backend", IsActive: true},
+				"dba":    {UserID: "dba", TeamName: "backend", IsActive: false},
+				"front1": {UserID: "front1", TeamName: "frontend", IsActive: true},
+				"front2": {UserID: "front2", TeamName: "frontend", IsActive: true},
+			},
+		},
+		statsRepo: &mockStatsRepo{
+			workload: map[string]int{"user1": 1, "user2": 2, "user3": 9, "front1": 5, "front2": 3},
+		},
+		ownersRepo: &mockCodeOwnersRepo{
+			files: map[string]string{
+				"backend": "*.sql @user3 @dba\n/web/ @org/frontend\n",
+			},
+		},
+	}
+}
+
+func TestReviewerSelector_SelectWithOwnership(t *testing.T) {
+	selector := NewReviewerSelector()
+	ctx := context.Background()
+
+	t.Run("Prefer owners, fill rest from team", func(t *testing.T) {
+		selected, err := selector.Select(ctx, newOwnershipTx(), SelectionRequest{
+			TeamName:     "backend",
+			AuthorID:     "author",
+			ChangedFiles: []string{"migrations/002.up.sql", "README.md"},
+			Policy:       &entity.TeamPolicy{TeamName: "backend", ReviewerCount: 2, OwnershipMode: entity.OwnershipPrefer},
+		})
+		if err != nil {
+			t.Fatalf("Select() error = %v", err)
+		}
+
+		// user3 перегружен, но он единственный доступный владелец *.sql
+		want := []string{"user3", "user1"}
+		if len(selected) != len(want) {
+			t.Fatalf("Select() = %v reviewers, want %v", len(selected), len(want))
+		}
+		for i, id := range want {
+			if selected[i].UserID != id {
+				t.Errorf("Select()[%d] = %v, want %v", i, selected[i].UserID, id)
+			}
+		}
+	})
+
+	t.Run("Team owner balanced within team", func(t *testing.T) {
+		selected, err := selector.Select(ctx, newOwnershipTx(), SelectionRequest{
+			TeamName:     "backend",
+			AuthorID:     "author",
+			ChangedFiles: []string{"web/app.js"},
+			Policy:       &entity.TeamPolicy{TeamName: "backend", ReviewerCount: 2, OwnershipMode: entity.OwnershipRequire},
+		})
+		if err != nil {
+			t.Fatalf("Select() error = %v", err)
+		}
+
+		// В режиме REQUIRE все места - из владельцев, по нагрузке
+		want := []string{"front2", "front1"}
+		if len(selected) != len(want) {
+			t.Fatalf("Select() = %v reviewers, want %v", len(selected), len(want))
+		}
+		for i, id := range want {
+			if selected[i].UserID != id {
+				t.Errorf("Select()[%d] = %v, want %v", i, selected[i].UserID, id)
+			}
+		}
+	})
+
+	t.Run("Require fails without available owner", func(t *testing.T) {
+		_, err := selector.Select(ctx, newOwnershipTx(), SelectionRequest{
+			TeamName:       "backend",
+			AuthorID:       "author",
+			ExcludeUserIDs: []string{"user3"},
+			ChangedFiles:   []string{"schema.sql"},
+			Policy:         &entity.TeamPolicy{TeamName: "backend", ReviewerCount: 2, OwnershipMode: entity.OwnershipRequire},
+		})
+		if err != repository.ErrOwnerUnavailable {
+			t.Errorf("Select() error = %v, want %v", err, repository.ErrOwnerUnavailable)
+		}
+	})
+
+	t.Run("Ownership off", func(t *testing.T) {
+		selected, err := selector.Select(ctx, newOwnershipTx(), SelectionRequest{
+			TeamName:     "backend",
+			AuthorID:     "author",
+			ChangedFiles: []string{"schema.sql"},
+			Policy:       &entity.TeamPolicy{TeamName: "backend", ReviewerCount: 2, OwnershipMode: entity.OwnershipOff},
+		})
+		if err != nil {
+			t.Fatalf("Select() error = %v", err)
+		}
+
+		if selected[0].UserID != "user1" || selected[1].UserID != "user2" {
+			t.Errorf("Select() = [%v %v], want [user1 user2]", selected[0].UserID, selected[1].UserID)
+		}
+	})
+}
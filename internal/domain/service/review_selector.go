@@ -2,118 +2,481 @@ package service
 
 import (
 	"context"
-	"math/rand"
-	"sort"
+	"errors"
+	"fmt"
+	"time"
 
 	"reviewer-service/internal/domain/entity"
 	"reviewer-service/internal/repository"
 )
 
 type ReviewerSelector struct {
-	// Без зависимостей - работаем через tx
+	// Без зависимостей от БД - работаем через tx
+	strategies      map[string]SelectionStrategy
+	defaultStrategy string
+	teamStrategies  map[string]string
+	clock           func() time.Time // nil = time.Now
+	seed            uint64           // seed детерминированного разрешения равенства
 }
 
+// NewReviewerSelector создаёт селектор со встроенными стратегиями.
+// По умолчанию используется least_loaded.
 func NewReviewerSelector() *ReviewerSelector {
-	return &ReviewerSelector{}
+	s := &ReviewerSelector{
+		strategies:      make(map[string]SelectionStrategy),
+		defaultStrategy: StrategyLeastLoaded,
+		teamStrategies:  make(map[string]string),
+	}
+
+	s.Register(LeastLoadedStrategy{})
+	s.Register(RoundRobinStrategy{})
+	s.Register(LeastRecentStrategy{})
+	s.Register(RandomStrategy{})
+
+	return s
 }
 
-// Select выбирает до 2 ревьюеров с учётом fair distribution
-func (s *ReviewerSelector) Select(
+// Register добавляет стратегию (или заменяет одноимённую)
+func (s *ReviewerSelector) Register(strategy SelectionStrategy) {
+	s.strategies[strategy.Name()] = strategy
+}
+
+// SetDefaultStrategy задаёт стратегию для команд без явной настройки
+func (s *ReviewerSelector) SetDefaultStrategy(name string) error {
+	if _, ok := s.strategies[name]; !ok {
+		return fmt.Errorf("unknown selection strategy %q", name)
+	}
+	s.defaultStrategy = name
+	return nil
+}
+
+// SetTeamStrategy закрепляет стратегию за командой
+func (s *ReviewerSelector) SetTeamStrategy(teamName, name string) error {
+	if _, ok := s.strategies[name]; !ok {
+		return fmt.Errorf("unknown selection strategy %q", name)
+	}
+	s.teamStrategies[teamName] = name
+	return nil
+}
+
+// SetSeed задаёт seed разрешения равенства. При одинаковом seed
+// и одинаковых данных выбор ревьюверов всегда воспроизводим.
+func (s *ReviewerSelector) SetSeed(seed uint64) {
+	s.seed = seed
+}
+
+// StrategyFor возвращает стратегию, действующую для команды
+func (s *ReviewerSelector) StrategyFor(teamName string) SelectionStrategy {
+	if name, ok := s.teamStrategies[teamName]; ok {
+		return s.strategies[name]
+	}
+	return s.strategies[s.defaultStrategy]
+}
+
+// HasStrategy проверяет, зарегистрирована ли стратегия
+func (s *ReviewerSelector) HasStrategy(name string) bool {
+	_, ok := s.strategies[name]
+	return ok
+}
+
+// SelectionRequest - параметры подбора ревьюверов
+type SelectionRequest struct {
+	PullRequestID   string // ключ детерминированного разрешения равенства
+	TeamName        string
+	AuthorID        string
+	ExcludeUserIDs  []string
+	AssignedUserIDs []string           // уже назначенные ревьюверы, которые остаются на PR
+	ChangedFiles    []string           // изменённые файлы для маршрутизации по CODEOWNERS
+	Labels          []string           // метки PR, которые должна покрыть экспертиза ревьюверов
+	Policy          *entity.TeamPolicy // nil = политика по умолчанию
+
+	ignoreCapacity bool            // подбор без учёта лимитов - чтобы понять, что мешают именно они
+	blocked        map[string]bool // кому правила исключения запрещают ревьюить автора; nil = не загружены
+}
+
+func (r SelectionRequest) policy() *entity.TeamPolicy {
+	if r.Policy == nil {
+		return entity.DefaultTeamPolicy(r.TeamName)
+	}
+	return r.Policy
+}
+
+// excluded возвращает пользователей, которых нельзя выбирать
+func (r SelectionRequest) excluded(selected []*entity.User) map[string]bool {
+	excludeMap := make(map[string]bool)
+	for _, id := range r.ExcludeUserIDs {
+		excludeMap[id] = true
+	}
+	for _, id := range r.AssignedUserIDs {
+		excludeMap[id] = true
+	}
+	for _, u := range selected {
+		excludeMap[u.UserID] = true
+	}
+	return excludeMap
+}
+
+// withExclusions загружает действующие правила исключения пар для автора запроса
+func (s *ReviewerSelector) withExclusions(
 	ctx context.Context,
 	tx repository.Tx,
-	teamName string,
-	authorID string,
-) ([]*entity.User, error) {
-	// 1. Получаем активных участников команды (кроме автора)
-	candidates, err := tx.Users().GetActiveByTeam(ctx, teamName, authorID)
+	req SelectionRequest,
+) (SelectionRequest, error) {
+	if req.blocked != nil {
+		return req, nil
+	}
+
+	now := s.Now()
+	exclusions, err := tx.Exclusions().GetActiveForAuthor(ctx, req.AuthorID, now)
 	if err != nil {
-		return nil, err
+		return req, err
 	}
 
-	if len(candidates) == 0 {
-		return []*entity.User{}, nil
+	req.blocked = entity.BlockedReviewers(exclusions, req.AuthorID, now)
+	return req, nil
+}
+
+// tieBreaker возвращает разрешитель равенства для PR из запроса
+func (s *ReviewerSelector) tieBreaker(req SelectionRequest) TieBreaker {
+	return NewTieBreaker(s.seed, req.PullRequestID)
+}
+
+// strategy возвращает стратегию из политики, а если она не задана - стратегию команды
+func (s *ReviewerSelector) strategy(policy *entity.TeamPolicy) SelectionStrategy {
+	if strategy, ok := s.strategies[policy.Strategy]; ok {
+		return strategy
 	}
+	return s.StrategyFor(policy.TeamName)
+}
 
-	// 2. Получаем текущую нагрузку каждого
-	candidateIDs := make([]string, len(candidates))
-	for i, c := range candidates {
-		candidateIDs[i] = c.UserID
+// Select выбирает ревьюверов согласно политике и стратегии команды.
+// Владельцы затронутых путей выбираются первыми, затем старший ревьювер,
+// если его требует политика; если в команде не хватает кандидатов,
+// недостающие места заполняются из резервных команд в порядке приоритета.
+// Учебный ревьювер добавляется сверх reviewer_count. Если кандидаты есть,
+// но у всех исчерпан лимит открытых ревью, возвращает ErrNoCapacity,
+// а если всех кандидатов отсеяли правила исключения пар - ErrNoCandidate
+// с причиной ErrExcludedByRule, как SelectReplacement.
+func (s *ReviewerSelector) Select(
+	ctx context.Context,
+	tx repository.Tx,
+	req SelectionRequest,
+) ([]*entity.User, error) {
+	req, err := s.withExclusions(ctx, tx, req)
+	if err != nil {
+		return nil, err
 	}
 
-	workload, err := tx.Stats().GetWorkload(ctx, candidateIDs)
+	selected, err := s.fill(ctx, tx, req, req.policy().ReviewerCount)
 	if err != nil {
 		return nil, err
 	}
 
-	// 3. Сортируем по нагрузке (меньше нагрузки = выше приоритет)
-	sort.Slice(candidates, func(i, j int) bool {
-		loadI := workload[candidates[i].UserID]
-		loadJ := workload[candidates[j].UserID]
+	// Никого не нашли - проверяем, не упёрлись ли все кандидаты в лимиты
+	if len(selected) == 0 && req.policy().ReviewerCount > 0 {
+		uncapped := req
+		uncapped.ignoreCapacity = true
 
-		if loadI == loadJ {
-			// При равной нагрузке - случайный выбор
-			return rand.Float32() > 0.5
+		blocked, err := s.fill(ctx, tx, uncapped, 1)
+		if err != nil {
+			return nil, err
 		}
-		return loadI < loadJ
-	})
+		if len(blocked) > 0 {
+			return nil, repository.ErrNoCapacity
+		}
+
+		if err := s.checkExcluded(ctx, tx, req); err != nil {
+			return nil, err
+		}
+	}
+
+	if req.policy().LearningReviewer && len(selected) > 0 {
+		return s.addLearner(ctx, tx, req, selected)
+	}
 
-	// 4. Берём топ-2
-	count := min(2, len(candidates))
-	return candidates[:count], nil
+	return selected, nil
 }
 
-// SelectReplacement выбирает одного ревьювера на замену
+// SelectReplacement выбирает одного ревьювера на замену. Если замены нет,
+// возвращает nil, а если кандидатов отсеяли правила исключения пар -
+// ErrNoCandidate с причиной ErrExcludedByRule.
 func (s *ReviewerSelector) SelectReplacement(
 	ctx context.Context,
 	tx repository.Tx,
-	teamName string,
-	excludeUserIDs []string,
+	req SelectionRequest,
 ) (*entity.User, error) {
-	// Получаем всех активных из команды
-	allUsers, err := tx.Users().GetByTeam(ctx, teamName)
+	req, err := s.withExclusions(ctx, tx, req)
 	if err != nil {
 		return nil, err
 	}
 
-	// Фильтруем: только активные + не в exclude списке
-	excludeMap := make(map[string]bool)
-	for _, id := range excludeUserIDs {
-		excludeMap[id] = true
+	selected, err := s.fill(ctx, tx, req, 1)
+	if err != nil {
+		return nil, err
 	}
 
-	var candidates []*entity.User
-	var candidateIDs []string
+	if len(selected) == 0 {
+		return nil, s.checkExcluded(ctx, tx, req)
+	}
 
-	for _, user := range allUsers {
-		if user.IsActive && !excludeMap[user.UserID] {
-			candidates = append(candidates, user)
-			candidateIDs = append(candidateIDs, user.UserID)
-		}
+	return selected[0], nil
+}
+
+// checkExcluded вызывается, когда подбор никого не нашёл. Если кто-то
+// нашёлся бы без правил исключения пар, возвращает ErrNoCandidate
+// с причиной ErrExcludedByRule.
+func (s *ReviewerSelector) checkExcluded(ctx context.Context, tx repository.Tx, req SelectionRequest) error {
+	if len(req.blocked) == 0 {
+		return nil
+	}
+
+	unblocked := req
+	unblocked.blocked = map[string]bool{}
+
+	found, err := s.fill(ctx, tx, unblocked, 1)
+	if err != nil {
+		return err
+	}
+	if len(found) > 0 {
+		return fmt.Errorf("%w: %w", repository.ErrNoCandidate, repository.ErrExcludedByRule)
+	}
+	return nil
+}
+
+// SelectAdditional добирает до count ревьюверов к уже назначенным
+// (req.AssignedUserIDs) тем же порядком, что и Select, но без учебного ревьювера.
+// Если подходящих кандидатов нет, возвращает пустой список.
+func (s *ReviewerSelector) SelectAdditional(
+	ctx context.Context,
+	tx repository.Tx,
+	req SelectionRequest,
+	count int,
+) ([]*entity.User, error) {
+	if count <= 0 {
+		return nil, nil
 	}
 
-	if len(candidates) == 0 {
+	req, err := s.withExclusions(ctx, tx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	selected, err := s.fill(ctx, tx, req, count)
+	if errors.Is(err, repository.ErrOwnerUnavailable) {
 		return nil, nil
 	}
+	return selected, err
+}
+
+// fill набирает count ревьюверов: сначала владельцев путей, затем из команды и резервных команд
+func (s *ReviewerSelector) fill(
+	ctx context.Context,
+	tx repository.Tx,
+	req SelectionRequest,
+	count int,
+) ([]*entity.User, error) {
+	selected := make([]*entity.User, 0, count)
+	policy := req.policy()
+
+	assigned, err := s.assignedUsers(ctx, tx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	needSenior := policy.RequireSenior && !hasSenior(assigned)
+
+	// 1. Владельцы затронутых путей
+	if policy.OwnershipMode != entity.OwnershipOff && len(req.ChangedFiles) > 0 {
+		sets, err := s.ownerSets(ctx, tx, req)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(sets) > 0 {
+			// Последнее место придерживаем для старшего ревьювера
+			ownerCount := count
+			if needSenior && count > 1 && policy.OwnershipMode != entity.OwnershipRequire {
+				ownerCount = count - 1
+			}
+
+			selected, err = s.coverOwners(ctx, tx, req, sets, assigned, selected, ownerCount)
+			if err != nil {
+				return nil, err
+			}
+
+			// В режиме REQUIRE ревьюверами могут быть только владельцы
+			if policy.OwnershipMode == entity.OwnershipRequire {
+				return s.fillFromOwners(ctx, tx, req, sets, assigned, selected, count)
+			}
+		}
+	}
+
+	teams := append([]string{req.TeamName}, policy.FallbackTeams...)
+
+	// 2. Старший ревьювер, если политика его требует
+	if needSenior && !hasSenior(selected) {
+		selected, err = s.coverSenior(ctx, tx, req, teams, assigned, selected, count)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// 3. Команда автора, затем резервные команды
+
+	for i, teamName := range teams {
+		if len(selected) >= count {
+			break
+		}
+
+		teamPolicy := policy
+		if i > 0 {
+			var err error
+			if teamPolicy, err = LoadTeamPolicy(ctx, tx, teamName); err != nil {
+				return nil, err
+			}
+		}
+
+		candidates, err := s.candidates(ctx, tx, teamName, req, req.excluded(selected), teamPolicy)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(candidates) == 0 {
+			continue
+		}
+
+		// Упорядочиваем по стратегии команды с учётом истории пар и рабочих часов
+		ranked, err := s.rank(ctx, tx, req, teamName, teamPolicy, candidates)
+		if err != nil {
+			return nil, err
+		}
+
+		// Эксперты по непокрытым меткам - вперёд
+		slots := count - len(selected)
+		ranked = prioritizeLabels(ranked, uncoveredLabels(req.Labels, assigned, selected), slots)
+
+		take := min(slots, len(ranked))
+		selected = append(selected, ranked[:take]...)
+	}
 
-	// Получаем нагрузку
-	workload, err := tx.Stats().GetWorkload(ctx, candidateIDs)
+	return selected, nil
+}
+
+// candidates возвращает активных участников команды, доступных для назначения
+func (s *ReviewerSelector) candidates(
+	ctx context.Context,
+	tx repository.Tx,
+	teamName string,
+	req SelectionRequest,
+	excludeMap map[string]bool,
+	policy *entity.TeamPolicy,
+) ([]*entity.User, error) {
+	active, err := tx.Users().GetActiveByTeam(ctx, teamName, req.AuthorID)
 	if err != nil {
 		return nil, err
 	}
 
-	// Сортируем по нагрузке
-	sort.Slice(candidates, func(i, j int) bool {
-		loadI := workload[candidates[i].UserID]
-		loadJ := workload[candidates[j].UserID]
+	return s.available(ctx, tx, req, active, excludeMap, policy)
+}
+
+// available отбрасывает тех, кого screen не допускает к ревью
+func (s *ReviewerSelector) available(
+	ctx context.Context,
+	tx repository.Tx,
+	req SelectionRequest,
+	users []*entity.User,
+	excludeMap map[string]bool,
+	policy *entity.TeamPolicy,
+) ([]*entity.User, error) {
+	candidates, _, err := s.screen(ctx, tx, req, users, excludeMap, policy)
+	return candidates, err
+}
+
+// screen делит пользователей на допущенных к ревью и исключённых с причиной
+// (entity.Excluded*): неактивных, автора, исключённых явно или правилами
+// исключения пар, тех, кто слишком долго вне рабочих часов, и тех, кто
+// упёрся в лимит открытых ревью (личный или командный) или в лимит
+// нагрузки с весами по размеру PR. Те же причины показывает Explain.
+func (s *ReviewerSelector) screen(
+	ctx context.Context,
+	tx repository.Tx,
+	req SelectionRequest,
+	users []*entity.User,
+	excludeMap map[string]bool,
+	policy *entity.TeamPolicy,
+) ([]*entity.User, map[string]string, error) {
+	reasons := make(map[string]string)
 
-		if loadI == loadJ {
-			return rand.Float32() > 0.5
+	var candidates []*entity.User
+	for _, user := range users {
+		switch {
+		case user.UserID == req.AuthorID:
+			reasons[user.UserID] = entity.ExcludedAuthor
+		case !user.IsActive:
+			reasons[user.UserID] = entity.ExcludedInactive
+		case excludeMap[user.UserID]:
+			reasons[user.UserID] = entity.ExcludedAssigned
+		case req.blocked[user.UserID]:
+			reasons[user.UserID] = entity.ExcludedByRule
+		default:
+			candidates = append(candidates, user)
 		}
-		return loadI < loadJ
-	})
+	}
 
-	// Возвращаем первого (с минимальной нагрузкой)
-	return candidates[0], nil
+	candidates = s.withinOffHours(candidates, policy, reasons)
+
+	limited := false
+	for _, user := range candidates {
+		limited = limited || user.ReviewLimit(policy.MaxOpenReviews) > 0
+	}
+
+	weighted := policy.MaxWeightedLoad > 0
+
+	if (!limited && !weighted) || req.ignoreCapacity {
+		return candidates, reasons, nil
+	}
+
+	openReviews := make(map[string]int)
+	if limited {
+		var err error
+		if openReviews, err = tx.Stats().GetOpenReviews(ctx, userIDs(candidates)); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	workload := make(map[string]int)
+	if weighted {
+		var err error
+		if workload, err = tx.Stats().GetWorkload(ctx, userIDs(candidates)); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	available := candidates[:0]
+	for _, user := range candidates {
+		switch {
+		case !user.HasCapacity(policy.MaxOpenReviews, openReviews[user.UserID]):
+			reasons[user.UserID] = entity.ExcludedAtCapacity
+		case !policy.HasWeightedCapacity(workload[user.UserID]):
+			reasons[user.UserID] = entity.ExcludedOverloaded
+		default:
+			available = append(available, user)
+		}
+	}
+
+	return available, reasons, nil
+}
+
+// LoadTeamPolicy возвращает политику команды, а если она не настроена - политику по умолчанию
+func LoadTeamPolicy(ctx context.Context, tx repository.Tx, teamName string) (*entity.TeamPolicy, error) {
+	policy, err := tx.Policies().GetByTeam(ctx, teamName)
+	if err == repository.ErrNotFound {
+		return entity.DefaultTeamPolicy(teamName), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return policy, nil
 }
 
 func min(a, b int) int {
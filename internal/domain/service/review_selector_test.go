@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"slices"
 	"testing"
 	"time"
 
@@ -11,8 +12,10 @@ import (
 
 // Mock implementation of Tx interface for testing
 type mockTx struct {
-	usersRepo repository.UserRepository
-	statsRepo repository.StatsRepository
+	usersRepo     repository.UserRepository
+	statsRepo     repository.StatsRepository
+	ownersRepo    repository.CodeOwnersRepository
+	exclusionRepo repository.ExclusionRepository
 }
 
 func (m *mockTx) Teams() repository.TeamRepository {
@@ -31,6 +34,44 @@ func (m *mockTx) PullRequests() repository.PullRequestRepository {
 	return &mockPRRepo{}
 }
 
+func (m *mockTx) Policies() repository.TeamPolicyRepository {
+	return &mockPolicyRepo{}
+}
+
+func (m *mockTx) CodeOwners() repository.CodeOwnersRepository {
+	if m.ownersRepo == nil {
+		return &mockCodeOwnersRepo{}
+	}
+	return m.ownersRepo
+}
+
+func (m *mockTx) Absences() repository.AbsenceRepository {
+	return nil
+}
+
+func (m *mockTx) MandatoryReviewers() repository.MandatoryReviewerRepository {
+	return nil
+}
+
+func (m *mockTx) Exclusions() repository.ExclusionRepository {
+	if m.exclusionRepo == nil {
+		return &mockExclusionRepo{}
+	}
+	return m.exclusionRepo
+}
+
+func (m *mockTx) Declines() repository.DeclineRepository {
+	return nil
+}
+
+func (m *mockTx) Escalations() repository.EscalationRepository {
+	return nil
+}
+
+func (m *mockTx) Repos() repository.ReposRepository {
+	return nil
+}
+
 func (m *mockTx) Commit() error {
 	return nil
 }
@@ -99,8 +140,38 @@ func (m *mockUsersRepo) BulkDeactivate(ctx context.Context, userIDs []string) er
 	return nil
 }
 
+func (m *mockUsersRepo) SetTags(ctx context.Context, userID string, tags []string) error {
+	return nil
+}
+
+func (m *mockUsersRepo) SetCapacity(ctx context.Context, userID string, maxOpenReviews *int, capacityPercent *int) error {
+	return nil
+}
+
+func (m *mockUsersRepo) SetSeniority(ctx context.Context, userID string, seniority entity.Seniority) error {
+	return nil
+}
+
+func (m *mockUsersRepo) SetSchedule(ctx context.Context, userID string, timezone string, hours []entity.WorkingHours) error {
+	return nil
+}
+
 type mockStatsRepo struct {
-	workload map[string]int
+	workload     map[string]int
+	openReviews  map[string]int // nil = совпадает с workload (все ревью весят 1)
+	lastAssigned map[string]time.Time
+	pairs        map[string]int // ревью PR автора по ревьюверам
+}
+
+func (m *mockStatsRepo) GetOpenReviews(ctx context.Context, userIDs []string) (map[string]int, error) {
+	if m.openReviews == nil {
+		return m.GetWorkload(ctx, userIDs)
+	}
+	result := make(map[string]int)
+	for _, id := range userIDs {
+		result[id] = m.openReviews[id]
+	}
+	return result, nil
 }
 
 func (m *mockStatsRepo) GetWorkload(ctx context.Context, userIDs []string) (map[string]int, error) {
@@ -117,6 +188,36 @@ func (m *mockStatsRepo) IncrementAssignment(ctx context.Context, userID string)
 	return nil
 }
 
+func (m *mockStatsRepo) DecrementAssignment(ctx context.Context, userID string) error {
+	return nil
+}
+
+func (m *mockStatsRepo) GetLastAssigned(ctx context.Context, userIDs []string) (map[string]time.Time, error) {
+	result := make(map[string]time.Time)
+	for _, id := range userIDs {
+		if at, exists := m.lastAssigned[id]; exists {
+			result[id] = at
+		}
+	}
+	return result, nil
+}
+
+func (m *mockStatsRepo) CountMergedSince(ctx context.Context, teamName string, since time.Time) (int, error) {
+	return 0, nil
+}
+
+func (m *mockStatsRepo) GetPairCounts(ctx context.Context, authorID string, reviewerIDs []string, since time.Time) (map[string]int, error) {
+	result := make(map[string]int)
+	for _, id := range reviewerIDs {
+		result[id] = m.pairs[id]
+	}
+	return result, nil
+}
+
+func (m *mockStatsRepo) GetPairingMatrix(ctx context.Context, teamName string, since time.Time) ([]*entity.PairStat, error) {
+	return nil, nil
+}
+
 func (m *mockStatsRepo) GetUserStats(ctx context.Context, userID string) (*entity.UserStats, error) {
 	return nil, nil
 }
@@ -143,7 +244,23 @@ func (m *mockPRRepo) GetByIDForUpdate(ctx context.Context, id string) (*entity.P
 	return nil, nil
 }
 
-func (m *mockPRRepo) GetByReviewer(ctx context.Context, userID string) ([]*entity.PullRequest, error) {
+func (m *mockPRRepo) GetByReviewer(ctx context.Context, userID string, filter entity.ReviewFilter) ([]*entity.PullRequest, error) {
+	return nil, nil
+}
+
+func (m *mockPRRepo) GetAwaiting(ctx context.Context) ([]*entity.PullRequest, error) {
+	return nil, nil
+}
+
+func (m *mockPRRepo) LockAwaiting(ctx context.Context) ([]*entity.PullRequest, error) {
+	return nil, nil
+}
+
+func (m *mockPRRepo) GetUnderstaffed(ctx context.Context) ([]*entity.PullRequest, error) {
+	return nil, nil
+}
+
+func (m *mockPRRepo) LockUnderstaffed(ctx context.Context) ([]*entity.PullRequest, error) {
 	return nil, nil
 }
 
@@ -151,18 +268,100 @@ func (m *mockPRRepo) GetOpenByReviewers(ctx context.Context, userIDs []string) (
 	return nil, nil
 }
 
-func (m *mockPRRepo) AssignReviewers(ctx context.Context, prID string, userIDs []string) error {
+func (m *mockPRRepo) AssignReviewers(ctx context.Context, prID string, userIDs []string, source entity.ReviewerSource) error {
+	return nil
+}
+
+func (m *mockPRRepo) ReplaceReviewer(ctx context.Context, prID, oldUserID, newUserID string, source entity.ReviewerSource) error {
+	return nil
+}
+
+func (m *mockPRRepo) RemoveReviewer(ctx context.Context, prID, userID string) error {
+	return nil
+}
+
+func (m *mockPRRepo) GetOpenAssignments(ctx context.Context) ([]*entity.ReviewAssignment, error) {
+	return nil, nil
+}
+
+func (m *mockPRRepo) GetEscalationStep(ctx context.Context, prID, userID string) (int, error) {
+	return 0, nil
+}
+
+func (m *mockPRRepo) SetEscalationStep(ctx context.Context, prID, userID string, step int) error {
+	return nil
+}
+
+func (m *mockPRRepo) SetVerdict(ctx context.Context, prID, userID string, verdict entity.Verdict) error {
+	return nil
+}
+
+func (m *mockPRRepo) SetLabels(ctx context.Context, prID string, labels []string) error {
 	return nil
 }
 
-func (m *mockPRRepo) ReplaceReviewer(ctx context.Context, prID, oldUserID, newUserID string) error {
+func (m *mockPRRepo) ResumeReviews(ctx context.Context, prID string, closedAt time.Time) error {
 	return nil
 }
 
+func (m *mockPRRepo) GetVerdicts(ctx context.Context, prID string) ([]*entity.ReviewVerdict, error) {
+	return nil, nil
+}
+
 func (m *mockPRRepo) IsReviewerAssigned(ctx context.Context, prID, userID string) (bool, error) {
 	return false, nil
 }
 
+type mockPolicyRepo struct{}
+
+func (m *mockPolicyRepo) GetByTeam(ctx context.Context, teamName string) (*entity.TeamPolicy, error) {
+	return nil, repository.ErrNotFound
+}
+
+func (m *mockPolicyRepo) Upsert(ctx context.Context, policy *entity.TeamPolicy) error {
+	return nil
+}
+
+func (m *mockPolicyRepo) Delete(ctx context.Context, teamName string) error {
+	return nil
+}
+
+type mockCodeOwnersRepo struct {
+	files map[string]string
+}
+
+func (m *mockCodeOwnersRepo) GetByTeam(ctx context.Context, teamName string) (*entity.CodeOwnersFile, error) {
+	content, exists := m.files[teamName]
+	if !exists {
+		return nil, repository.ErrNotFound
+	}
+	return &entity.CodeOwnersFile{TeamName: teamName, Content: content}, nil
+}
+
+func (m *mockCodeOwnersRepo) Upsert(ctx context.Context, file *entity.CodeOwnersFile) error {
+	return nil
+}
+
+type mockExclusionRepo struct {
+	rules []*entity.ReviewExclusion
+}
+
+func (m *mockExclusionRepo) Create(ctx context.Context, exclusion *entity.ReviewExclusion) error {
+	return nil
+}
+
+func (m *mockExclusionRepo) GetByUser(ctx context.Context, userID string) ([]*entity.ReviewExclusion, error) {
+	return m.rules, nil
+}
+
+func (m *mockExclusionRepo) GetActiveForAuthor(ctx context.Context, authorID string, now time.Time) ([]*entity.ReviewExclusion, error) {
+	return m.rules, nil
+}
+
+func (m *mockExclusionRepo) Delete(ctx context.Context, id int64) error {
+	return nil
+}
+
 type mockTeamRepo struct{}
 
 func (m *mockTeamRepo) Create(ctx context.Context, team *entity.Team) error {
@@ -218,7 +417,7 @@ func TestReviewerSelector_Select(t *testing.T) {
 		var selected []*entity.User
 		err := txManager.WithTx(ctx, func(tx repository.Tx) error {
 			var err error
-			selected, err = selector.Select(ctx, tx, "backend", "user5")
+			selected, err = selector.Select(ctx, tx, SelectionRequest{TeamName: "backend", AuthorID: "user5"})
 			return err
 		})
 
@@ -245,7 +444,7 @@ func TestReviewerSelector_Select(t *testing.T) {
 		err := txManager.WithTx(ctx, func(tx repository.Tx) error {
 			var err error
 			// Все пользователи в команде неактивны или это автор
-			selected, err = selector.Select(ctx, tx, "frontend", "user5")
+			selected, err = selector.Select(ctx, tx, SelectionRequest{TeamName: "frontend", AuthorID: "user5"})
 			return err
 		})
 
@@ -271,7 +470,10 @@ func TestReviewerSelector_SelectReplacement(t *testing.T) {
 		var selected *entity.User
 		err := txManager.WithTx(ctx, func(tx repository.Tx) error {
 			var err error
-			selected, err = selector.SelectReplacement(ctx, tx, "backend", []string{"user1"})
+			selected, err = selector.SelectReplacement(ctx, tx, SelectionRequest{
+				TeamName:       "backend",
+				ExcludeUserIDs: []string{"user1"},
+			})
 			return err
 		})
 
@@ -301,7 +503,10 @@ func TestReviewerSelector_SelectReplacement(t *testing.T) {
 		err := txManager.WithTx(ctx, func(tx repository.Tx) error {
 			var err error
 			// Исключаем всех активных пользователей
-			selected, err = selector.SelectReplacement(ctx, tx, "backend", []string{"user1", "user2", "user3"})
+			selected, err = selector.SelectReplacement(ctx, tx, SelectionRequest{
+				TeamName:       "backend",
+				ExcludeUserIDs: []string{"user1", "user2", "user3"},
+			})
 			return err
 		})
 
@@ -315,3 +520,269 @@ func TestReviewerSelector_SelectReplacement(t *testing.T) {
 		}
 	})
 }
+
+func TestReviewerSelector_SelectAdditional(t *testing.T) {
+	selector := NewReviewerSelector()
+	txManager := &mockTxManager{}
+
+	ctx := context.Background()
+
+	t.Run("Skips assigned reviewers", func(t *testing.T) {
+		var selected []*entity.User
+		err := txManager.WithTx(ctx, func(tx repository.Tx) error {
+			var err error
+			selected, err = selector.SelectAdditional(ctx, tx, SelectionRequest{
+				TeamName:        "backend",
+				AssignedUserIDs: []string{"user2"},
+			}, 1)
+			return err
+		})
+
+		if err != nil {
+			t.Fatalf("SelectAdditional() error = %v", err)
+		}
+
+		// user2 уже назначен, из оставшихся меньше всего нагрузки у user1
+		if len(selected) != 1 || selected[0].UserID != "user1" {
+			t.Errorf("SelectAdditional() = %v, want [user1]", userIDs(selected))
+		}
+	})
+
+	t.Run("Nothing to add", func(t *testing.T) {
+		var selected []*entity.User
+		err := txManager.WithTx(ctx, func(tx repository.Tx) error {
+			var err error
+			selected, err = selector.SelectAdditional(ctx, tx, SelectionRequest{
+				TeamName:        "backend",
+				AssignedUserIDs: []string{"user1", "user2", "user3"},
+			}, 1)
+			return err
+		})
+
+		if err != nil {
+			t.Fatalf("SelectAdditional() error = %v", err)
+		}
+
+		if len(selected) != 0 {
+			t.Errorf("SelectAdditional() = %v, want none", userIDs(selected))
+		}
+	})
+}
+
+func TestReviewerSelector_SelectWithPolicy(t *testing.T) {
+	selector := NewReviewerSelector()
+	txManager := &mockTxManager{}
+
+	ctx := context.Background()
+
+	tests := []struct {
+		name     string
+		policy   *entity.TeamPolicy
+		expected []string
+	}{
+		{
+			name:     "Single reviewer",
+			policy:   &entity.TeamPolicy{ReviewerCount: 1},
+			expected: []string{"user2"},
+		},
+		{
+			name:     "Three reviewers",
+			policy:   &entity.TeamPolicy{ReviewerCount: 3},
+			expected: []string{"user2", "user1", "user3"},
+		},
+		{
+			name:     "Max open reviews excludes overloaded",
+			policy:   &entity.TeamPolicy{ReviewerCount: 3, MaxOpenReviews: 6},
+			expected: []string{"user2", "user1"},
+		},
+		{
+			name:     "Strategy from policy",
+			policy:   &entity.TeamPolicy{ReviewerCount: 3, Strategy: StrategyRoundRobin},
+			expected: []string{"user1", "user2", "user3"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var selected []*entity.User
+			err := txManager.WithTx(ctx, func(tx repository.Tx) error {
+				var err error
+				selected, err = selector.Select(ctx, tx, SelectionRequest{
+					TeamName: "backend",
+					AuthorID: "user5",
+					Policy:   tt.policy,
+				})
+				return err
+			})
+
+			if err != nil {
+				t.Fatalf("Select() error = %v", err)
+			}
+
+			if len(selected) != len(tt.expected) {
+				t.Fatalf("Select() = %v reviewers, want %v", len(selected), len(tt.expected))
+			}
+
+			for i, id := range tt.expected {
+				if selected[i].UserID != id {
+					t.Errorf("Select()[%d] = %v, want %v", i, selected[i].UserID, id)
+				}
+			}
+		})
+	}
+}
+
+func TestReviewerSelector_SelectWithFallback(t *testing.T) {
+	selector := NewReviewerSelector()
+	ctx := context.Background()
+
+	tx := &mockTx{
+		usersRepo: &mockUsersRepo{
+			users: map[string]*entity.User{
+				"author": {UserID: "author", TeamName: "small", IsActive: true},
+				"peer":   {UserID: "peer", TeamName: "small", IsActive: true},
+				"user1":  {UserID: "user1", TeamName: "backend", IsActive: true},
+				"user2":  {UserID: "user2", TeamName: "backend", IsActive: true},
+			},
+		},
+		statsRepo: &mockStatsRepo{
+			workload: map[string]int{"peer": 4, "user1": 2, "user2": 1},
+		},
+	}
+
+	policy := &entity.TeamPolicy{
+		TeamName:      "small",
+		ReviewerCount: 2,
+		FallbackTeams: []string{"empty", "backend"},
+	}
+
+	t.Run("Fill missing slots from fallback team", func(t *testing.T) {
+		selected, err := selector.Select(ctx, tx, SelectionRequest{
+			TeamName: "small",
+			AuthorID: "author",
+			Policy:   policy,
+		})
+		if err != nil {
+			t.Fatalf("Select() error = %v", err)
+		}
+
+		if len(selected) != 2 {
+			t.Fatalf("Select() = %v reviewers, want 2", len(selected))
+		}
+
+		// Сначала своя команда, затем наименее загруженный из резервной
+		if selected[0].UserID != "peer" || selected[1].UserID != "user2" {
+			t.Errorf("Select() = [%v %v], want [peer user2]", selected[0].UserID, selected[1].UserID)
+		}
+	})
+
+	t.Run("Replacement from fallback team", func(t *testing.T) {
+		selected, err := selector.SelectReplacement(ctx, tx, SelectionRequest{
+			TeamName:       "small",
+			AuthorID:       "author",
+			ExcludeUserIDs: []string{"peer"},
+			Policy:         policy,
+		})
+		if err != nil {
+			t.Fatalf("SelectReplacement() error = %v", err)
+		}
+
+		if selected == nil || selected.UserID != "user2" {
+			t.Errorf("SelectReplacement() = %v, want user2", selected)
+		}
+	})
+}
+
+func TestReviewerSelector_SelectCapacity(t *testing.T) {
+	selector := NewReviewerSelector()
+	ctx := context.Background()
+
+	personal := 5
+	tx := &mockTx{
+		usersRepo: &mockUsersRepo{
+			users: map[string]*entity.User{
+				"author": {UserID: "author", TeamName: "backend", IsActive: true},
+				"full":   {UserID: "full", TeamName: "backend", IsActive: true, CapacityPercent: 100},
+				"half":   {UserID: "half", TeamName: "backend", IsActive: true, CapacityPercent: 50},
+				"senior": {UserID: "senior", TeamName: "backend", IsActive: true, MaxOpenReviews: &personal},
+			},
+		},
+		statsRepo: &mockStatsRepo{
+			workload: map[string]int{"full": 3, "half": 2, "senior": 4},
+		},
+	}
+
+	t.Run("Fractional and personal limits", func(t *testing.T) {
+		selected, err := selector.Select(ctx, tx, SelectionRequest{
+			TeamName: "backend",
+			AuthorID: "author",
+			Policy:   &entity.TeamPolicy{TeamName: "backend", ReviewerCount: 3, MaxOpenReviews: 4},
+		})
+		if err != nil {
+			t.Fatalf("Select() error = %v", err)
+		}
+
+		// half упирается в 2 из 4, senior имеет личный лимит 5
+		if len(selected) != 2 || selected[0].UserID != "full" || selected[1].UserID != "senior" {
+			t.Errorf("Select() = %v, want [full senior]", userIDs(selected))
+		}
+	})
+
+	t.Run("Everyone at capacity", func(t *testing.T) {
+		_, err := selector.Select(ctx, tx, SelectionRequest{
+			TeamName: "backend",
+			AuthorID: "author",
+			Policy:   &entity.TeamPolicy{TeamName: "backend", ReviewerCount: 2, MaxOpenReviews: 2},
+			// senior со своим лимитом 5 - исключаем, остальные заняты
+			ExcludeUserIDs: []string{"senior"},
+		})
+		if err != repository.ErrNoCapacity {
+			t.Errorf("Select() error = %v, want %v", err, repository.ErrNoCapacity)
+		}
+	})
+}
+
+func TestReviewerSelector_SelectWeightedLoad(t *testing.T) {
+	selector := NewReviewerSelector()
+	ctx := context.Background()
+
+	tx := &mockTx{
+		usersRepo: &mockUsersRepo{
+			users: map[string]*entity.User{
+				"author": {UserID: "author", TeamName: "backend", IsActive: true},
+				"big":    {UserID: "big", TeamName: "backend", IsActive: true},
+				"small":  {UserID: "small", TeamName: "backend", IsActive: true},
+			},
+		},
+		// big ревьюит один крупный PR, small - три мелких
+		statsRepo: &mockStatsRepo{
+			workload:    map[string]int{"big": 5, "small": 3},
+			openReviews: map[string]int{"big": 1, "small": 3},
+		},
+	}
+
+	tests := []struct {
+		name   string
+		policy *entity.TeamPolicy
+		want   []string
+	}{
+		{"open reviews count PRs", &entity.TeamPolicy{TeamName: "backend", ReviewerCount: 2, MaxOpenReviews: 3}, []string{"big"}},
+		{"weighted load counts size", &entity.TeamPolicy{TeamName: "backend", ReviewerCount: 2, MaxWeightedLoad: 4}, []string{"small"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			selected, err := selector.Select(ctx, tx, SelectionRequest{
+				TeamName: "backend",
+				AuthorID: "author",
+				Policy:   tt.policy,
+			})
+			if err != nil {
+				t.Fatalf("Select() error = %v", err)
+			}
+			if got := userIDs(selected); !slices.Equal(got, tt.want) {
+				t.Errorf("Select() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
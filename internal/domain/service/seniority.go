@@ -0,0 +1,114 @@
+package service
+
+import (
+	"context"
+
+	"reviewer-service/internal/domain/entity"
+	"reviewer-service/internal/repository"
+)
+
+// coverSenior добавляет одного старшего ревьювера: из команды автора,
+// а если там нет свободных - из резервных команд. Нагрузка балансируется
+// стратегией среди старших. Если старших нет вовсе, подбор продолжается
+// без него.
+func (s *ReviewerSelector) coverSenior(
+	ctx context.Context,
+	tx repository.Tx,
+	req SelectionRequest,
+	teams []string,
+	assigned []*entity.User,
+	selected []*entity.User,
+	count int,
+) ([]*entity.User, error) {
+	if len(selected) >= count {
+		return selected, nil
+	}
+
+	policy := req.policy()
+
+	for i, teamName := range teams {
+		teamPolicy := policy
+		if i > 0 {
+			var err error
+			if teamPolicy, err = LoadTeamPolicy(ctx, tx, teamName); err != nil {
+				return nil, err
+			}
+		}
+
+		candidates, err := s.candidates(ctx, tx, teamName, req, req.excluded(selected), teamPolicy)
+		if err != nil {
+			return nil, err
+		}
+
+		seniors := filterUsers(candidates, (*entity.User).IsSenior)
+		if len(seniors) == 0 {
+			continue
+		}
+
+		ranked, err := s.rank(ctx, tx, req, teamName, teamPolicy, seniors)
+		if err != nil {
+			return nil, err
+		}
+
+		ranked = prioritizeLabels(ranked, uncoveredLabels(req.Labels, assigned, selected), 1)
+
+		return append(selected, ranked[0]), nil
+	}
+
+	return selected, nil
+}
+
+// addLearner добавляет сверх выбранных одного младшего ревьювера из команды
+// автора, если среди назначенных и выбранных младших ещё нет
+func (s *ReviewerSelector) addLearner(
+	ctx context.Context,
+	tx repository.Tx,
+	req SelectionRequest,
+	selected []*entity.User,
+) ([]*entity.User, error) {
+	assigned, err := s.assignedUsers(ctx, tx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if hasJunior(assigned) || hasJunior(selected) {
+		return selected, nil
+	}
+
+	policy := req.policy()
+
+	candidates, err := s.candidates(ctx, tx, req.TeamName, req, req.excluded(selected), policy)
+	if err != nil {
+		return nil, err
+	}
+
+	juniors := filterUsers(candidates, (*entity.User).IsJunior)
+	if len(juniors) == 0 {
+		return selected, nil
+	}
+
+	ranked, err := s.rank(ctx, tx, req, req.TeamName, policy, juniors)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(selected, ranked[0]), nil
+}
+
+func hasSenior(users []*entity.User) bool {
+	return len(filterUsers(users, (*entity.User).IsSenior)) > 0
+}
+
+func hasJunior(users []*entity.User) bool {
+	return len(filterUsers(users, (*entity.User).IsJunior)) > 0
+}
+
+func filterUsers(users []*entity.User, keep func(*entity.User) bool) []*entity.User {
+	var result []*entity.User
+	for _, u := range users {
+		if keep(u) {
+			result = append(result, u)
+		}
+	}
+	return result
+}
@@ -0,0 +1,129 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"reviewer-service/internal/domain/entity"
+)
+
+func TestReviewerSelector_SelectSeniority(t *testing.T) {
+	selector := NewReviewerSelector()
+	ctx := context.Background()
+
+	tx := &mockTx{
+		usersRepo: &mockUsersRepo{
+			users: map[string]*entity.User{
+				"author":  {UserID: "author", TeamName: "backend", IsActive: true},
+				"junior1": {UserID: "junior1", TeamName: "backend", IsActive: true, Seniority: entity.SeniorityJunior},
+				"junior2": {UserID: "junior2", TeamName: "backend", IsActive: true, Seniority: entity.SeniorityJunior},
+				"senior1": {UserID: "senior1", TeamName: "backend", IsActive: true, Seniority: entity.SenioritySenior},
+				"senior2": {UserID: "senior2", TeamName: "backend", IsActive: true, Seniority: entity.SenioritySenior},
+			},
+		},
+		statsRepo: &mockStatsRepo{
+			workload: map[string]int{"junior1": 0, "junior2": 1, "senior1": 5, "senior2": 3},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		req      SelectionRequest
+		expected []string
+	}{
+		{
+			name: "Without rule two juniors win on load",
+			req: SelectionRequest{
+				TeamName: "backend",
+				AuthorID: "author",
+				Policy:   &entity.TeamPolicy{TeamName: "backend", ReviewerCount: 2},
+			},
+			expected: []string{"junior1", "junior2"},
+		},
+		{
+			name: "Least loaded senior goes first",
+			req: SelectionRequest{
+				TeamName: "backend",
+				AuthorID: "author",
+				Policy:   &entity.TeamPolicy{TeamName: "backend", ReviewerCount: 2, RequireSenior: true},
+			},
+			expected: []string{"senior2", "junior1"},
+		},
+		{
+			name: "Assigned senior satisfies the rule",
+			req: SelectionRequest{
+				TeamName:        "backend",
+				AuthorID:        "author",
+				AssignedUserIDs: []string{"senior1"},
+				Policy:          &entity.TeamPolicy{TeamName: "backend", ReviewerCount: 1, RequireSenior: true},
+			},
+			expected: []string{"junior1"},
+		},
+		{
+			name: "Junior in regular slots needs no extra learner",
+			req: SelectionRequest{
+				TeamName:       "backend",
+				AuthorID:       "author",
+				ExcludeUserIDs: []string{"junior1"},
+				Policy: &entity.TeamPolicy{
+					TeamName:         "backend",
+					ReviewerCount:    2,
+					RequireSenior:    true,
+					LearningReviewer: true,
+				},
+			},
+			expected: []string{"senior2", "junior2"},
+		},
+		{
+			name: "Learning reviewer added on top of reviewer count",
+			req: SelectionRequest{
+				TeamName:       "backend",
+				AuthorID:       "author",
+				ExcludeUserIDs: []string{"junior1"},
+				Policy: &entity.TeamPolicy{
+					TeamName:         "backend",
+					ReviewerCount:    1,
+					RequireSenior:    true,
+					LearningReviewer: true,
+				},
+			},
+			expected: []string{"senior2", "junior2"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			selected, err := selector.Select(ctx, tx, tt.req)
+			if err != nil {
+				t.Fatalf("Select() error = %v", err)
+			}
+
+			if len(selected) != len(tt.expected) {
+				t.Fatalf("Select() = %v, want %v", userIDs(selected), tt.expected)
+			}
+
+			for i, id := range tt.expected {
+				if selected[i].UserID != id {
+					t.Errorf("Select()[%d] = %v, want %v", i, selected[i].UserID, id)
+				}
+			}
+		})
+	}
+
+	t.Run("Senior replaced by senior", func(t *testing.T) {
+		replacement, err := selector.SelectReplacement(ctx, tx, SelectionRequest{
+			TeamName:        "backend",
+			AuthorID:        "author",
+			ExcludeUserIDs:  []string{"senior2"},
+			AssignedUserIDs: []string{"junior1"},
+			Policy:          &entity.TeamPolicy{TeamName: "backend", ReviewerCount: 2, RequireSenior: true},
+		})
+		if err != nil {
+			t.Fatalf("SelectReplacement() error = %v", err)
+		}
+
+		if replacement == nil || replacement.UserID != "senior1" {
+			t.Errorf("SelectReplacement() = %v, want senior1", replacement)
+		}
+	})
+}
@@ -0,0 +1,210 @@
+package service
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"reviewer-service/internal/domain/entity"
+	"reviewer-service/internal/repository"
+)
+
+// Имена встроенных стратегий выбора
+const (
+	StrategyLeastLoaded = "least_loaded"
+	StrategyRoundRobin  = "round_robin"
+	StrategyLeastRecent = "least_recent"
+	StrategyRandom      = "random"
+)
+
+// SelectionStrategy - политика упорядочивания кандидатов в ревьюверы.
+// Rank возвращает кандидатов в порядке приоритета: первый - самый предпочтительный.
+// Равных кандидатов стратегия упорядочивает через tie, чтобы результат
+// был воспроизводимым.
+type SelectionStrategy interface {
+	Name() string
+	Rank(
+		ctx context.Context,
+		tx repository.Tx,
+		teamName string,
+		candidates []*entity.User,
+		tie TieBreaker,
+	) ([]*entity.User, error)
+}
+
+// LeastLoadedStrategy - меньше открытых ревью = выше приоритет
+type LeastLoadedStrategy struct{}
+
+func (LeastLoadedStrategy) Name() string {
+	return StrategyLeastLoaded
+}
+
+func (LeastLoadedStrategy) Rank(
+	ctx context.Context,
+	tx repository.Tx,
+	teamName string,
+	candidates []*entity.User,
+	tie TieBreaker,
+) ([]*entity.User, error) {
+	workload, err := tx.Stats().GetWorkload(ctx, userIDs(candidates))
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(candidates, func(i, j int) bool {
+		loadI := workload[candidates[i].UserID]
+		loadJ := workload[candidates[j].UserID]
+
+		if loadI == loadJ {
+			return tie.Less(candidates[i], candidates[j])
+		}
+		return loadI < loadJ
+	})
+
+	return candidates, nil
+}
+
+// RoundRobinStrategy - строгая очередь по команде.
+// Участники упорядочены по user_id, очередь продолжается
+// со следующего после последнего назначенного участника команды.
+type RoundRobinStrategy struct{}
+
+func (RoundRobinStrategy) Name() string {
+	return StrategyRoundRobin
+}
+
+func (RoundRobinStrategy) Rank(
+	ctx context.Context,
+	tx repository.Tx,
+	teamName string,
+	candidates []*entity.User,
+	tie TieBreaker,
+) ([]*entity.User, error) {
+	members, err := tx.Users().GetByTeam(ctx, teamName)
+	if err != nil {
+		return nil, err
+	}
+
+	memberIDs := userIDs(members)
+	sort.Strings(memberIDs)
+
+	lastAssigned, err := tx.Stats().GetLastAssigned(ctx, memberIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	// Позиция каждого участника в кольце
+	position := make(map[string]int, len(memberIDs))
+	for i, id := range memberIDs {
+		position[id] = i
+	}
+
+	cursor := roundRobinCursor(memberIDs, lastAssigned)
+
+	// Расстояние по кольцу от курсора; кандидаты вне команды - в конец
+	distance := func(u *entity.User) int {
+		pos, ok := position[u.UserID]
+		if !ok {
+			return len(memberIDs) + 1
+		}
+		return (pos - cursor - 1 + len(memberIDs)) % len(memberIDs)
+	}
+
+	sort.Slice(candidates, func(i, j int) bool {
+		di, dj := distance(candidates[i]), distance(candidates[j])
+		if di == dj {
+			return tie.Less(candidates[i], candidates[j])
+		}
+		return di < dj
+	})
+
+	return candidates, nil
+}
+
+// roundRobinCursor возвращает позицию последнего назначенного участника.
+// Несколько ревьюверов одного PR получают одинаковое время назначения,
+// поэтому курсором считается последний из них по кольцу.
+func roundRobinCursor(memberIDs []string, lastAssigned map[string]time.Time) int {
+	var latest time.Time
+	for _, id := range memberIDs {
+		if t := lastAssigned[id]; t.After(latest) {
+			latest = t
+		}
+	}
+
+	if latest.IsZero() {
+		return -1
+	}
+
+	n := len(memberIDs)
+	for i, id := range memberIDs {
+		next := memberIDs[(i+1)%n]
+		if lastAssigned[id].Equal(latest) && !lastAssigned[next].Equal(latest) {
+			return i
+		}
+	}
+
+	// Все участники назначены одновременно - начинаем сначала
+	return n - 1
+}
+
+// LeastRecentStrategy - дольше всех без назначений = выше приоритет
+type LeastRecentStrategy struct{}
+
+func (LeastRecentStrategy) Name() string {
+	return StrategyLeastRecent
+}
+
+func (LeastRecentStrategy) Rank(
+	ctx context.Context,
+	tx repository.Tx,
+	teamName string,
+	candidates []*entity.User,
+	tie TieBreaker,
+) ([]*entity.User, error) {
+	lastAssigned, err := tx.Stats().GetLastAssigned(ctx, userIDs(candidates))
+	if err != nil {
+		return nil, err
+	}
+
+	// Никогда не назначенные имеют нулевое время и идут первыми
+	sort.Slice(candidates, func(i, j int) bool {
+		ti, tj := lastAssigned[candidates[i].UserID], lastAssigned[candidates[j].UserID]
+		if ti.Equal(tj) {
+			return tie.Less(candidates[i], candidates[j])
+		}
+		return ti.Before(tj)
+	})
+
+	return candidates, nil
+}
+
+// RandomStrategy - равновероятный выбор без учёта нагрузки.
+// Порядок псевдослучайный, но воспроизводимый: задаётся хешем TieBreaker.
+type RandomStrategy struct{}
+
+func (RandomStrategy) Name() string {
+	return StrategyRandom
+}
+
+func (RandomStrategy) Rank(
+	ctx context.Context,
+	tx repository.Tx,
+	teamName string,
+	candidates []*entity.User,
+	tie TieBreaker,
+) ([]*entity.User, error) {
+	sort.Slice(candidates, func(i, j int) bool {
+		return tie.Less(candidates[i], candidates[j])
+	})
+
+	return candidates, nil
+}
+
+func userIDs(users []*entity.User) []string {
+	ids := make([]string, len(users))
+	for i, u := range users {
+		ids[i] = u.UserID
+	}
+	return ids
+}
@@ -0,0 +1,164 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"reviewer-service/internal/domain/entity"
+)
+
+func newStrategyTx(lastAssigned map[string]time.Time) *mockTx {
+	return &mockTx{
+		usersRepo: &mockUsersRepo{
+			users: map[string]*entity.User{
+				"user1": {UserID: "user1", TeamName: "backend", IsActive: true},
+				"user2": {UserID: "user2", TeamName: "backend", IsActive: true},
+				"user3": {UserID: "user3", TeamName: "backend", IsActive: true},
+				"user4": {UserID: "user4", TeamName: "backend", IsActive: true},
+			},
+		},
+		statsRepo: &mockStatsRepo{
+			workload:     map[string]int{"user1": 5, "user2": 3, "user3": 7, "user4": 1},
+			lastAssigned: lastAssigned,
+		},
+	}
+}
+
+func candidatesOf(ids ...string) []*entity.User {
+	users := make([]*entity.User, len(ids))
+	for i, id := range ids {
+		users[i] = &entity.User{UserID: id, TeamName: "backend", IsActive: true}
+	}
+	return users
+}
+
+func TestLeastLoadedStrategy_Rank(t *testing.T) {
+	ctx := context.Background()
+	tx := newStrategyTx(nil)
+
+	ranked, err := LeastLoadedStrategy{}.Rank(ctx, tx, "backend", candidatesOf("user1", "user2", "user3", "user4"), NewTieBreaker(0, "pr-1"))
+	if err != nil {
+		t.Fatalf("Rank() error = %v", err)
+	}
+
+	want := []string{"user4", "user2", "user1", "user3"}
+	for i, id := range want {
+		if ranked[i].UserID != id {
+			t.Errorf("Rank()[%d] = %v, want %v", i, ranked[i].UserID, id)
+		}
+	}
+}
+
+func TestRoundRobinStrategy_Rank(t *testing.T) {
+	ctx := context.Background()
+	base := time.Now()
+
+	tests := []struct {
+		name         string
+		lastAssigned map[string]time.Time
+		want         []string
+	}{
+		{
+			name: "No assignments yet",
+			want: []string{"user1", "user2", "user3", "user4"},
+		},
+		{
+			name: "Continue after last assigned pair",
+			lastAssigned: map[string]time.Time{
+				"user1": base,
+				"user2": base,
+			},
+			want: []string{"user3", "user4", "user1", "user2"},
+		},
+		{
+			name: "Wrap around the ring",
+			lastAssigned: map[string]time.Time{
+				"user1": base.Add(time.Minute),
+				"user2": base,
+				"user3": base,
+				"user4": base.Add(time.Minute),
+			},
+			want: []string{"user2", "user3", "user4", "user1"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tx := newStrategyTx(tt.lastAssigned)
+
+			ranked, err := RoundRobinStrategy{}.Rank(ctx, tx, "backend", candidatesOf("user4", "user3", "user2", "user1"), NewTieBreaker(0, "pr-1"))
+			if err != nil {
+				t.Fatalf("Rank() error = %v", err)
+			}
+
+			for i, id := range tt.want {
+				if ranked[i].UserID != id {
+					t.Errorf("Rank()[%d] = %v, want %v", i, ranked[i].UserID, id)
+				}
+			}
+		})
+	}
+}
+
+func TestLeastRecentStrategy_Rank(t *testing.T) {
+	ctx := context.Background()
+	base := time.Now()
+
+	tx := newStrategyTx(map[string]time.Time{
+		"user1": base.Add(2 * time.Hour),
+		"user2": base,
+		"user3": base.Add(time.Hour),
+	})
+
+	ranked, err := LeastRecentStrategy{}.Rank(ctx, tx, "backend", candidatesOf("user1", "user2", "user3", "user4"), NewTieBreaker(0, "pr-1"))
+	if err != nil {
+		t.Fatalf("Rank() error = %v", err)
+	}
+
+	// user4 ни разу не назначался - идёт первым
+	want := []string{"user4", "user2", "user3", "user1"}
+	for i, id := range want {
+		if ranked[i].UserID != id {
+			t.Errorf("Rank()[%d] = %v, want %v", i, ranked[i].UserID, id)
+		}
+	}
+}
+
+func TestRandomStrategy_Rank(t *testing.T) {
+	ctx := context.Background()
+	tx := newStrategyTx(nil)
+
+	ranked, err := RandomStrategy{}.Rank(ctx, tx, "backend", candidatesOf("user1", "user2", "user3"), NewTieBreaker(0, "pr-1"))
+	if err != nil {
+		t.Fatalf("Rank() error = %v", err)
+	}
+
+	if len(ranked) != 3 {
+		t.Errorf("Rank() = %v candidates, want 3", len(ranked))
+	}
+}
+
+func TestReviewerSelector_StrategyFor(t *testing.T) {
+	selector := NewReviewerSelector()
+
+	if got := selector.StrategyFor("backend").Name(); got != StrategyLeastLoaded {
+		t.Errorf("StrategyFor() default = %v, want %v", got, StrategyLeastLoaded)
+	}
+
+	if err := selector.SetTeamStrategy("backend", StrategyRoundRobin); err != nil {
+		t.Fatalf("SetTeamStrategy() error = %v", err)
+	}
+
+	if got := selector.StrategyFor("backend").Name(); got != StrategyRoundRobin {
+		t.Errorf("StrategyFor(backend) = %v, want %v", got, StrategyRoundRobin)
+	}
+
+	if got := selector.StrategyFor("frontend").Name(); got != StrategyLeastLoaded {
+		t.Errorf("StrategyFor(frontend) = %v, want %v", got, StrategyLeastLoaded)
+	}
+
+	if err := selector.SetTeamStrategy("backend", "unknown"); err == nil {
+		t.Error("SetTeamStrategy() expected error for unknown strategy, got nil")
+	}
+}
@@ -0,0 +1,44 @@
+package service
+
+import (
+	"encoding/binary"
+	"hash/fnv"
+
+	"reviewer-service/internal/domain/entity"
+)
+
+// TieBreaker детерминированно упорядочивает равных кандидатов.
+// Ключ - хеш от seed, PR и пользователя: для одного PR порядок
+// всегда одинаковый, а между разными PR нагрузка распределяется равномерно.
+type TieBreaker struct {
+	seed          uint64
+	pullRequestID string
+}
+
+func NewTieBreaker(seed uint64, pullRequestID string) TieBreaker {
+	return TieBreaker{seed: seed, pullRequestID: pullRequestID}
+}
+
+// Key возвращает ключ пользователя: меньше ключ - выше приоритет при равенстве
+func (t TieBreaker) Key(userID string) uint64 {
+	h := fnv.New64a()
+
+	var seed [8]byte
+	binary.BigEndian.PutUint64(seed[:], t.seed)
+
+	h.Write(seed[:])
+	h.Write([]byte(t.pullRequestID))
+	h.Write([]byte{0}) // разделитель, чтобы "ab"+"c" != "a"+"bc"
+	h.Write([]byte(userID))
+
+	return h.Sum64()
+}
+
+// Less сравнивает кандидатов по ключу, при совпадении хешей - по user_id
+func (t TieBreaker) Less(a, b *entity.User) bool {
+	ka, kb := t.Key(a.UserID), t.Key(b.UserID)
+	if ka != kb {
+		return ka < kb
+	}
+	return a.UserID < b.UserID
+}
@@ -0,0 +1,55 @@
+package service
+
+import (
+	"context"
+	"testing"
+)
+
+func TestTieBreaker_Key(t *testing.T) {
+	tie := NewTieBreaker(42, "pr-1")
+
+	if tie.Key("user1") != NewTieBreaker(42, "pr-1").Key("user1") {
+		t.Error("Key() should be deterministic for the same seed, PR and user")
+	}
+	if tie.Key("user1") == NewTieBreaker(43, "pr-1").Key("user1") {
+		t.Error("Key() should depend on seed")
+	}
+	if tie.Key("user1") == NewTieBreaker(42, "pr-2").Key("user1") {
+		t.Error("Key() should depend on PR")
+	}
+	if NewTieBreaker(0, "ab").Key("c") == NewTieBreaker(0, "a").Key("bc") {
+		t.Error("Key() should separate PR and user")
+	}
+}
+
+func TestLeastLoadedStrategy_RankTieBreak(t *testing.T) {
+	ctx := context.Background()
+	tx := newStrategyTx(nil)
+	tx.statsRepo = &mockStatsRepo{workload: map[string]int{}}
+
+	rank := func(prID string, ids ...string) []string {
+		ranked, err := LeastLoadedStrategy{}.Rank(ctx, tx, "backend", candidatesOf(ids...), NewTieBreaker(7, prID))
+		if err != nil {
+			t.Fatalf("Rank() error = %v", err)
+		}
+		return userIDs(ranked)
+	}
+
+	// Порядок не зависит от порядка входа
+	first := rank("pr-1", "user1", "user2", "user3", "user4")
+	second := rank("pr-1", "user4", "user3", "user2", "user1")
+	for i := range first {
+		if first[i] != second[i] {
+			t.Fatalf("Rank() = %v and %v, want identical order", first, second)
+		}
+	}
+
+	// Разные PR получают разных первых ревьюверов
+	leaders := make(map[string]bool)
+	for _, prID := range []string{"pr-1", "pr-2", "pr-3", "pr-4", "pr-5", "pr-6", "pr-7", "pr-8"} {
+		leaders[rank(prID, "user1", "user2", "user3", "user4")[0]] = true
+	}
+	if len(leaders) < 2 {
+		t.Errorf("Rank() always puts %v first, want spread across PRs", leaders)
+	}
+}
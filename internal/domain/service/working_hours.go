@@ -0,0 +1,69 @@
+package service
+
+import (
+	"time"
+
+	"reviewer-service/internal/domain/entity"
+)
+
+// SetClock подменяет источник текущего времени (для тестов и предпросмотра)
+func (s *ReviewerSelector) SetClock(clock func() time.Time) {
+	s.clock = clock
+}
+
+// Now возвращает текущее время по часам селектора
+func (s *ReviewerSelector) Now() time.Time {
+	if s.clock == nil {
+		return time.Now()
+	}
+	return s.clock()
+}
+
+// withinOffHours отбрасывает тех, кто вне рабочих часов дольше порога политики,
+// и записывает им причину в reasons
+func (s *ReviewerSelector) withinOffHours(
+	users []*entity.User,
+	policy *entity.TeamPolicy,
+	reasons map[string]string,
+) []*entity.User {
+	if policy.MaxOffHours == 0 {
+		return users
+	}
+
+	now := s.Now()
+	limit := time.Duration(policy.MaxOffHours) * time.Hour
+
+	result := users[:0]
+	for _, u := range users {
+		if u.OffHoursWait(now) <= limit {
+			result = append(result, u)
+		} else {
+			reasons[u.UserID] = entity.ExcludedOffHours
+		}
+	}
+	return result
+}
+
+// preferWorkingHours поднимает вперёд тех, кто сейчас на работе
+// или выйдет на неё в течение PreferWithinHours. Внутри групп
+// сохраняется порядок стратегии.
+func (s *ReviewerSelector) preferWorkingHours(ranked []*entity.User, policy *entity.TeamPolicy) []*entity.User {
+	if !policy.PreferWorkingHours {
+		return ranked
+	}
+
+	now := s.Now()
+	window := time.Duration(policy.PreferWithinHours) * time.Hour
+
+	preferred := make([]*entity.User, 0, len(ranked))
+	var later []*entity.User
+	for _, u := range ranked {
+		if u.OffHoursWait(now) <= window {
+			preferred = append(preferred, u)
+		} else {
+			later = append(later, u)
+		}
+	}
+
+	return append(preferred, later...)
+}
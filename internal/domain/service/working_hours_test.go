@@ -0,0 +1,99 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"reviewer-service/internal/domain/entity"
+)
+
+func TestReviewerSelector_SelectWorkingHours(t *testing.T) {
+	selector := NewReviewerSelector()
+	ctx := context.Background()
+
+	// Среда 2024-07-03, 10:00 UTC
+	selector.SetClock(func() time.Time {
+		return time.Date(2024, 7, 3, 10, 0, 0, 0, time.UTC)
+	})
+
+	weekdays := func(start, end string) []entity.WorkingHours {
+		var hours []entity.WorkingHours
+		for d := time.Monday; d <= time.Friday; d++ {
+			hours = append(hours, entity.WorkingHours{Weekday: d, Start: start, End: end})
+		}
+		return hours
+	}
+
+	tx := &mockTx{
+		usersRepo: &mockUsersRepo{
+			users: map[string]*entity.User{
+				"author": {UserID: "author", TeamName: "backend", IsActive: true},
+				// Сан-Франциско: 03:00, на работе через 6 часов
+				"sf": {UserID: "sf", TeamName: "backend", IsActive: true,
+					Timezone: "America/Los_Angeles", WorkingHours: weekdays("09:00", "18:00")},
+				// Москва: 13:00, на работе
+				"msk": {UserID: "msk", TeamName: "backend", IsActive: true,
+					Timezone: "Europe/Moscow", WorkingHours: weekdays("09:00", "18:00")},
+				// Токио: 19:00, на работе через 14 часов
+				"tokyo": {UserID: "tokyo", TeamName: "backend", IsActive: true,
+					Timezone: "Asia/Tokyo", WorkingHours: weekdays("09:00", "18:00")},
+			},
+		},
+		statsRepo: &mockStatsRepo{
+			workload: map[string]int{"sf": 1, "tokyo": 2, "msk": 5},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		policy   *entity.TeamPolicy
+		expected []string
+	}{
+		{
+			name:     "Working hours ignored",
+			policy:   &entity.TeamPolicy{TeamName: "backend", ReviewerCount: 3},
+			expected: []string{"sf", "tokyo", "msk"},
+		},
+		{
+			name:     "Prefer currently working",
+			policy:   &entity.TeamPolicy{TeamName: "backend", ReviewerCount: 3, PreferWorkingHours: true},
+			expected: []string{"msk", "sf", "tokyo"},
+		},
+		{
+			name: "Prefer working within 8 hours",
+			policy: &entity.TeamPolicy{TeamName: "backend", ReviewerCount: 3,
+				PreferWorkingHours: true, PreferWithinHours: 8},
+			expected: []string{"sf", "msk", "tokyo"},
+		},
+		{
+			name:     "Skip off for more than 12 hours",
+			policy:   &entity.TeamPolicy{TeamName: "backend", ReviewerCount: 3, MaxOffHours: 12},
+			expected: []string{"sf", "msk"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			selected, err := selector.Select(ctx, tx, SelectionRequest{
+				TeamName: "backend",
+				AuthorID: "author",
+				Policy:   tt.policy,
+			})
+			if err != nil {
+				t.Fatalf("Select() error = %v", err)
+			}
+
+			if len(selected) != len(tt.expected) {
+				t.Fatalf("Select() = %v, want %v", userIDs(selected), tt.expected)
+			}
+
+			for i, id := range tt.expected {
+				if selected[i].UserID != id {
+					t.Errorf("Select() = %v, want %v", userIDs(selected), tt.expected)
+					break
+				}
+			}
+		})
+	}
+}
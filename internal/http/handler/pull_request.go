@@ -3,8 +3,11 @@ package handler
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
 
+	"reviewer-service/internal/domain/entity"
+	"reviewer-service/internal/http/middleware"
 	"reviewer-service/internal/http/response"
 	"reviewer-service/internal/repository"
 	"reviewer-service/internal/usecase"
@@ -19,9 +22,21 @@ func NewPullRequestHandler(prUC *usecase.PullRequestUseCase) *PullRequestHandler
 }
 
 type CreatePRRequest struct {
-	PullRequestID   string `json:"pull_request_id"`
-	PullRequestName string `json:"pull_request_name"`
-	AuthorID        string `json:"author_id"`
+	PullRequestID   string   `json:"pull_request_id"`
+	PullRequestName string   `json:"pull_request_name"`
+	AuthorID        string   `json:"author_id"`
+	ChangedFiles    []string `json:"changed_files"`
+	Labels          []string `json:"labels"`
+	LinesAdded      int      `json:"lines_added"`
+	LinesRemoved    int      `json:"lines_removed"`
+	FilesChanged    int      `json:"files_changed"` // 0 = по числу changed_files
+	Draft           bool     `json:"draft"`         // не назначать ревьюверов до /pullRequest/ready
+	Description     string   `json:"description"`
+	URL             string   `json:"url"`
+	Repository      string   `json:"repository"`
+	Number          int      `json:"number"` // номер PR в репозитории
+	SourceBranch    string   `json:"source_branch"`
+	TargetBranch    string   `json:"target_branch"`
 }
 
 func (h *PullRequestHandler) Create(w http.ResponseWriter, r *http.Request) {
@@ -31,33 +46,145 @@ func (h *PullRequestHandler) Create(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// PR репозитория можно создать по номеру: ID выводится из пары (repository, number)
+	if req.PullRequestID == "" && req.Repository != "" && req.Number > 0 {
+		req.PullRequestID = entity.PRKey(req.Repository, req.Number)
+	}
+
 	// Валидация
 	if req.PullRequestID == "" || req.PullRequestName == "" || req.AuthorID == "" {
 		response.Error(w, http.StatusBadRequest, "INVALID_REQUEST", "all fields are required")
 		return
 	}
 
-	pr, err := h.prUC.CreatePR(r.Context(), req.PullRequestID, req.PullRequestName, req.AuthorID)
+	if req.LinesAdded < 0 || req.LinesRemoved < 0 || req.FilesChanged < 0 {
+		response.Error(w, http.StatusBadRequest, "INVALID_PR_SIZE", "size metrics must be non-negative")
+		return
+	}
+
+	filesChanged := req.FilesChanged
+	if filesChanged == 0 {
+		filesChanged = len(req.ChangedFiles)
+	}
+
+	status := entity.StatusOpen
+	if req.Draft {
+		status = entity.StatusDraft
+	}
+
+	pr, err := h.prUC.CreatePR(r.Context(), &entity.PullRequest{
+		ID:           req.PullRequestID,
+		Name:         req.PullRequestName,
+		AuthorID:     req.AuthorID,
+		Status:       status,
+		ChangedFiles: req.ChangedFiles,
+		Labels:       req.Labels,
+		LinesAdded:   req.LinesAdded,
+		LinesRemoved: req.LinesRemoved,
+		FilesChanged: filesChanged,
+		PRMetadata: entity.PRMetadata{
+			Description:  req.Description,
+			URL:          req.URL,
+			Repository:   req.Repository,
+			Number:       req.Number,
+			SourceBranch: req.SourceBranch,
+			TargetBranch: req.TargetBranch,
+		},
+	})
 	if err != nil {
-		if err == repository.ErrPRExists {
-			response.Error(w, http.StatusConflict, "PR_EXISTS", "PR id already exists")
+		if errors.Is(err, repository.ErrInvalidPRMeta) {
+			response.Error(w, http.StatusBadRequest, "INVALID_PR_METADATA", err.Error())
+			return
+		}
+		if errors.Is(err, repository.ErrInvalidPRSize) {
+			response.Error(w, http.StatusBadRequest, "INVALID_PR_SIZE", "size metrics must be non-negative")
 			return
 		}
-		if err == repository.ErrNotFound {
+		if errors.Is(err, repository.ErrPRExists) {
+			response.Error(w, http.StatusConflict, "PR_EXISTS", "PR id or repository number already exists")
+			return
+		}
+		if errors.Is(err, repository.ErrNotFound) {
 			response.Error(w, http.StatusNotFound, "NOT_FOUND", "author not found")
 			return
 		}
+		if errors.Is(err, repository.ErrOwnerUnavailable) {
+			response.Error(w, http.StatusConflict, "OWNER_UNAVAILABLE", "no available owner for changed paths")
+			return
+		}
 		response.Error(w, http.StatusInternalServerError, "INTERNAL_ERROR", err.Error())
 		return
 	}
 
-	response.JSON(w, http.StatusCreated, map[string]interface{}{
+	resp := map[string]interface{}{
+		"pr": pr,
+	}
+
+	// PR ждёт освобождения ревьюверов - показываем место в очереди
+	if pr.IsAwaitingReviewers() {
+		entry, err := h.prUC.GetQueueEntry(r.Context(), pr.ID)
+		if err == nil {
+			resp["queue"] = entry
+		}
+	}
+
+	response.JSON(w, http.StatusCreated, resp)
+}
+
+type UpdatePRRequest struct {
+	PullRequestID   string    `json:"pull_request_id"`
+	PullRequestName *string   `json:"pull_request_name"` // отсутствующие поля не меняются
+	Description     *string   `json:"description"`
+	URL             *string   `json:"url"`
+	Repository      *string   `json:"repository"`
+	SourceBranch    *string   `json:"source_branch"`
+	TargetBranch    *string   `json:"target_branch"`
+	Labels          *[]string `json:"labels"`
+}
+
+// Update меняет название и метаданные PR
+func (h *PullRequestHandler) Update(w http.ResponseWriter, r *http.Request) {
+	var req UpdatePRRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, http.StatusBadRequest, "INVALID_REQUEST", "invalid request body")
+		return
+	}
+
+	if req.PullRequestID == "" {
+		response.Error(w, http.StatusBadRequest, "INVALID_REQUEST", "pull_request_id is required")
+		return
+	}
+
+	pr, err := h.prUC.UpdatePR(r.Context(), req.PullRequestID, entity.PRUpdate{
+		Name:         req.PullRequestName,
+		Description:  req.Description,
+		URL:          req.URL,
+		Repository:   req.Repository,
+		SourceBranch: req.SourceBranch,
+		TargetBranch: req.TargetBranch,
+		Labels:       req.Labels,
+	})
+	if err != nil {
+		if errors.Is(err, repository.ErrInvalidPRMeta) {
+			response.Error(w, http.StatusBadRequest, "INVALID_PR_METADATA", err.Error())
+			return
+		}
+		if errors.Is(err, repository.ErrNotFound) {
+			response.Error(w, http.StatusNotFound, "NOT_FOUND", "pull request not found")
+			return
+		}
+		response.Error(w, http.StatusInternalServerError, "INTERNAL_ERROR", err.Error())
+		return
+	}
+
+	response.JSON(w, http.StatusOK, map[string]interface{}{
 		"pr": pr,
 	})
 }
 
 type MergePRRequest struct {
 	PullRequestID string `json:"pull_request_id"`
+	Force         bool   `json:"force"` // смержить без одобрений, только для администратора
 }
 
 func (h *PullRequestHandler) Merge(w http.ResponseWriter, r *http.Request) {
@@ -72,12 +199,33 @@ func (h *PullRequestHandler) Merge(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	pr, err := h.prUC.Merge(r.Context(), req.PullRequestID)
+	if req.Force && !middleware.IsAdmin(r.Context()) {
+		response.Error(w, http.StatusForbidden, "FORBIDDEN", "force requires admin token")
+		return
+	}
+
+	pr, err := h.prUC.Merge(r.Context(), req.PullRequestID, req.Force)
 	if err != nil {
-		if err == repository.ErrNotFound {
+		if errors.Is(err, repository.ErrNotFound) {
 			response.Error(w, http.StatusNotFound, "NOT_FOUND", "pull request not found")
 			return
 		}
+		if errors.Is(err, repository.ErrPRClosed) {
+			response.Error(w, http.StatusConflict, "PR_CLOSED", "closed PR must be reopened before merge")
+			return
+		}
+		if errors.Is(err, repository.ErrPRDraft) {
+			response.Error(w, http.StatusConflict, "PR_DRAFT", "draft PR must be marked ready before merge")
+			return
+		}
+		if errors.Is(err, repository.ErrChangesRequested) {
+			response.Error(w, http.StatusConflict, "CHANGES_REQUESTED", "reviewers requested changes")
+			return
+		}
+		if errors.Is(err, repository.ErrNotApproved) {
+			response.Error(w, http.StatusConflict, "NOT_APPROVED", "pull request does not have enough approvals")
+			return
+		}
 		response.Error(w, http.StatusInternalServerError, "INTERNAL_ERROR", err.Error())
 		return
 	}
@@ -87,9 +235,115 @@ func (h *PullRequestHandler) Merge(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+// PRIDRequest - запрос, которому нужен только идентификатор PR
+type PRIDRequest struct {
+	PullRequestID string `json:"pull_request_id"`
+}
+
+// Close закрывает PR без merge
+func (h *PullRequestHandler) Close(w http.ResponseWriter, r *http.Request) {
+	var req PRIDRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, http.StatusBadRequest, "INVALID_REQUEST", "invalid request body")
+		return
+	}
+
+	if req.PullRequestID == "" {
+		response.Error(w, http.StatusBadRequest, "INVALID_REQUEST", "pull_request_id is required")
+		return
+	}
+
+	pr, err := h.prUC.Close(r.Context(), req.PullRequestID)
+	if err != nil {
+		lifecycleError(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, map[string]interface{}{
+		"pr": pr,
+	})
+}
+
+// Reopen открывает закрытый PR заново
+func (h *PullRequestHandler) Reopen(w http.ResponseWriter, r *http.Request) {
+	var req PRIDRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, http.StatusBadRequest, "INVALID_REQUEST", "invalid request body")
+		return
+	}
+
+	if req.PullRequestID == "" {
+		response.Error(w, http.StatusBadRequest, "INVALID_REQUEST", "pull_request_id is required")
+		return
+	}
+
+	pr, err := h.prUC.Reopen(r.Context(), req.PullRequestID)
+	if err != nil {
+		lifecycleError(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, map[string]interface{}{
+		"pr": pr,
+	})
+}
+
+// MarkReady переводит черновик в ревью и назначает ревьюверов
+func (h *PullRequestHandler) MarkReady(w http.ResponseWriter, r *http.Request) {
+	var req PRIDRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, http.StatusBadRequest, "INVALID_REQUEST", "invalid request body")
+		return
+	}
+
+	if req.PullRequestID == "" {
+		response.Error(w, http.StatusBadRequest, "INVALID_REQUEST", "pull_request_id is required")
+		return
+	}
+
+	pr, err := h.prUC.MarkReady(r.Context(), req.PullRequestID)
+	if err != nil {
+		lifecycleError(w, err)
+		return
+	}
+
+	resp := map[string]interface{}{
+		"pr": pr,
+	}
+
+	// PR ждёт освобождения ревьюверов - показываем место в очереди
+	if pr.IsAwaitingReviewers() {
+		entry, err := h.prUC.GetQueueEntry(r.Context(), pr.ID)
+		if err == nil {
+			resp["queue"] = entry
+		}
+	}
+
+	response.JSON(w, http.StatusOK, resp)
+}
+
+// lifecycleError отвечает на ошибки смены статуса PR
+func lifecycleError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, repository.ErrNotFound):
+		response.Error(w, http.StatusNotFound, "NOT_FOUND", "pull request not found")
+	case errors.Is(err, repository.ErrPRMerged):
+		response.Error(w, http.StatusConflict, "PR_MERGED", "PR is already merged")
+	case errors.Is(err, repository.ErrPRClosed):
+		response.Error(w, http.StatusConflict, "PR_CLOSED", "closed PR must be reopened first")
+	case errors.Is(err, repository.ErrOwnerUnavailable):
+		response.Error(w, http.StatusConflict, "OWNER_UNAVAILABLE", "no available owner for changed paths")
+	case errors.Is(err, entity.ErrInvalidTransition):
+		response.Error(w, http.StatusConflict, "INVALID_TRANSITION", err.Error())
+	default:
+		response.Error(w, http.StatusInternalServerError, "INTERNAL_ERROR", err.Error())
+	}
+}
+
 type ReassignRequest struct {
 	PullRequestID string `json:"pull_request_id"`
 	OldUserID     string `json:"old_user_id"`
+	Force         bool   `json:"force"` // заменить обязательного ревьювера, только для администратора
 }
 
 func (h *PullRequestHandler) Reassign(w http.ResponseWriter, r *http.Request) {
@@ -104,21 +358,50 @@ func (h *PullRequestHandler) Reassign(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	pr, newReviewerID, err := h.prUC.Reassign(r.Context(), req.PullRequestID, req.OldUserID)
+	if req.Force && !middleware.IsAdmin(r.Context()) {
+		response.Error(w, http.StatusForbidden, "FORBIDDEN", "force requires admin token")
+		return
+	}
+
+	pr, newReviewerID, err := h.prUC.Reassign(r.Context(), req.PullRequestID, req.OldUserID, req.Force)
 	if err != nil {
-		if err == repository.ErrPRMerged {
+		if errors.Is(err, repository.ErrPRMerged) {
 			response.Error(w, http.StatusConflict, "PR_MERGED", "cannot reassign on merged PR")
 			return
 		}
-		if err == repository.ErrNotAssigned {
+		if errors.Is(err, repository.ErrPRClosed) {
+			response.Error(w, http.StatusConflict, "PR_CLOSED", "cannot reassign on closed PR")
+			return
+		}
+		if errors.Is(err, repository.ErrPRDraft) {
+			response.Error(w, http.StatusConflict, "PR_DRAFT", "draft PR has no reviewers")
+			return
+		}
+		if errors.Is(err, repository.ErrNotAssigned) {
 			response.Error(w, http.StatusConflict, "NOT_ASSIGNED", "reviewer is not assigned to this PR")
 			return
 		}
-		if err == repository.ErrNoCandidate {
+		if errors.Is(err, repository.ErrMandatoryReviewer) {
+			response.Error(w, http.StatusConflict, "MANDATORY_REVIEWER", "mandatory reviewer can only be reassigned by admin with force")
+			return
+		}
+		if errors.Is(err, repository.ErrReviewerBlocking) {
+			response.Error(w, http.StatusConflict, "CHANGES_REQUESTED", "reviewer who requested changes can only be reassigned by admin with force")
+			return
+		}
+		if errors.Is(err, repository.ErrExcludedByRule) {
+			response.Error(w, http.StatusConflict, "NO_CANDIDATE", "no replacement candidate: exclusion rules removed all remaining candidates")
+			return
+		}
+		if errors.Is(err, repository.ErrNoCandidate) {
 			response.Error(w, http.StatusConflict, "NO_CANDIDATE", "no active replacement candidate in team")
 			return
 		}
-		if err == repository.ErrNotFound {
+		if errors.Is(err, repository.ErrOwnerUnavailable) {
+			response.Error(w, http.StatusConflict, "OWNER_UNAVAILABLE", "no available owner for changed paths")
+			return
+		}
+		if errors.Is(err, repository.ErrNotFound) {
 			response.Error(w, http.StatusNotFound, "NOT_FOUND", "PR or user not found")
 			return
 		}
@@ -131,3 +414,387 @@ func (h *PullRequestHandler) Reassign(w http.ResponseWriter, r *http.Request) {
 		"replaced_by": newReviewerID,
 	})
 }
+
+func (h *PullRequestHandler) GetQueue(w http.ResponseWriter, r *http.Request) {
+	prID := r.URL.Query().Get("pull_request_id")
+	if prID != "" {
+		entry, err := h.prUC.GetQueueEntry(r.Context(), prID)
+		if err != nil {
+			if err == repository.ErrNotFound {
+				response.Error(w, http.StatusNotFound, "NOT_FOUND", "pull request is not awaiting reviewers")
+				return
+			}
+			response.Error(w, http.StatusInternalServerError, "INTERNAL_ERROR", err.Error())
+			return
+		}
+
+		response.JSON(w, http.StatusOK, map[string]interface{}{
+			"queue": entry,
+		})
+		return
+	}
+
+	entries, err := h.prUC.GetQueue(r.Context(), r.URL.Query().Get("team_name"))
+	if err != nil {
+		response.Error(w, http.StatusInternalServerError, "INTERNAL_ERROR", err.Error())
+		return
+	}
+
+	response.JSON(w, http.StatusOK, map[string]interface{}{
+		"queue": entries,
+	})
+}
+
+type SuggestRequest struct {
+	PullRequestID string   `json:"pull_request_id"`
+	AuthorID      string   `json:"author_id"`
+	TeamName      string   `json:"team_name"`
+	Repository    string   `json:"repository"` // без team_name выбирает команду-владельца
+	ChangedFiles  []string `json:"changed_files"`
+	Labels        []string `json:"labels"`
+}
+
+// Suggest показывает, кого бы назначил сервис, ничего не записывая
+func (h *PullRequestHandler) Suggest(w http.ResponseWriter, r *http.Request) {
+	var req SuggestRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, http.StatusBadRequest, "INVALID_REQUEST", "invalid request body")
+		return
+	}
+
+	if req.AuthorID == "" {
+		response.Error(w, http.StatusBadRequest, "INVALID_REQUEST", "author_id is required")
+		return
+	}
+
+	suggestion, err := h.prUC.Suggest(r.Context(), &entity.PullRequest{
+		ID:           req.PullRequestID,
+		AuthorID:     req.AuthorID,
+		ChangedFiles: req.ChangedFiles,
+		Labels:       req.Labels,
+		PRMetadata:   entity.PRMetadata{Repository: req.Repository},
+	}, req.TeamName)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			response.Error(w, http.StatusNotFound, "NOT_FOUND", "author not found")
+			return
+		}
+		response.Error(w, http.StatusInternalServerError, "INTERNAL_ERROR", err.Error())
+		return
+	}
+
+	response.JSON(w, http.StatusOK, map[string]interface{}{
+		"suggestion": suggestion,
+	})
+}
+
+type ManualReviewerRequest struct {
+	PullRequestID string `json:"pull_request_id"`
+	UserID        string `json:"user_id"`
+	Force         bool   `json:"force"` // снять обязательного ревьювера, только для администратора
+}
+
+// AddReviewer назначает на PR выбранного вручную ревьювера
+func (h *PullRequestHandler) AddReviewer(w http.ResponseWriter, r *http.Request) {
+	var req ManualReviewerRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, http.StatusBadRequest, "INVALID_REQUEST", "invalid request body")
+		return
+	}
+
+	if req.PullRequestID == "" || req.UserID == "" {
+		response.Error(w, http.StatusBadRequest, "INVALID_REQUEST", "pull_request_id and user_id are required")
+		return
+	}
+
+	pr, err := h.prUC.AddReviewer(r.Context(), req.PullRequestID, req.UserID)
+	if err != nil {
+		manualReviewerError(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, map[string]interface{}{
+		"pr": pr,
+	})
+}
+
+// RemoveReviewer снимает ревьювера с PR без замены
+func (h *PullRequestHandler) RemoveReviewer(w http.ResponseWriter, r *http.Request) {
+	var req ManualReviewerRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, http.StatusBadRequest, "INVALID_REQUEST", "invalid request body")
+		return
+	}
+
+	if req.PullRequestID == "" || req.UserID == "" {
+		response.Error(w, http.StatusBadRequest, "INVALID_REQUEST", "pull_request_id and user_id are required")
+		return
+	}
+
+	if req.Force && !middleware.IsAdmin(r.Context()) {
+		response.Error(w, http.StatusForbidden, "FORBIDDEN", "force requires admin token")
+		return
+	}
+
+	pr, err := h.prUC.RemoveReviewer(r.Context(), req.PullRequestID, req.UserID, req.Force)
+	if err != nil {
+		manualReviewerError(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, map[string]interface{}{
+		"pr": pr,
+	})
+}
+
+type ReassignToRequest struct {
+	PullRequestID string `json:"pull_request_id"`
+	OldUserID     string `json:"old_user_id"`
+	NewUserID     string `json:"new_user_id"`
+	Force         bool   `json:"force"` // заменить обязательного ревьювера, только для администратора
+}
+
+// ReassignTo заменяет ревьювера на явно указанного пользователя
+func (h *PullRequestHandler) ReassignTo(w http.ResponseWriter, r *http.Request) {
+	var req ReassignToRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, http.StatusBadRequest, "INVALID_REQUEST", "invalid request body")
+		return
+	}
+
+	if req.PullRequestID == "" || req.OldUserID == "" || req.NewUserID == "" {
+		response.Error(w, http.StatusBadRequest, "INVALID_REQUEST", "all fields are required")
+		return
+	}
+
+	if req.Force && !middleware.IsAdmin(r.Context()) {
+		response.Error(w, http.StatusForbidden, "FORBIDDEN", "force requires admin token")
+		return
+	}
+
+	pr, err := h.prUC.ReassignTo(r.Context(), req.PullRequestID, req.OldUserID, req.NewUserID, req.Force)
+	if err != nil {
+		manualReviewerError(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, map[string]interface{}{
+		"pr":          pr,
+		"replaced_by": req.NewUserID,
+	})
+}
+
+// manualReviewerError отвечает на ошибки ручного изменения ревьюверов
+func manualReviewerError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, repository.ErrPRMerged):
+		response.Error(w, http.StatusConflict, "PR_MERGED", "cannot change reviewers on merged PR")
+	case errors.Is(err, repository.ErrPRClosed):
+		response.Error(w, http.StatusConflict, "PR_CLOSED", "cannot change reviewers on closed PR")
+	case errors.Is(err, repository.ErrPRDraft):
+		response.Error(w, http.StatusConflict, "PR_DRAFT", "draft PR must be marked ready before assigning reviewers")
+	case errors.Is(err, repository.ErrNotAssigned):
+		response.Error(w, http.StatusConflict, "NOT_ASSIGNED", "reviewer is not assigned to this PR")
+	case errors.Is(err, repository.ErrAlreadyAssigned):
+		response.Error(w, http.StatusConflict, "ALREADY_ASSIGNED", "reviewer is already assigned to this PR")
+	case errors.Is(err, repository.ErrMandatoryReviewer):
+		response.Error(w, http.StatusConflict, "MANDATORY_REVIEWER", "mandatory reviewer can only be changed by admin with force")
+	case errors.Is(err, repository.ErrReviewerBlocking):
+		response.Error(w, http.StatusConflict, "CHANGES_REQUESTED", "reviewer who requested changes can only be changed by admin with force")
+	case errors.Is(err, repository.ErrReviewerIsAuthor):
+		response.Error(w, http.StatusConflict, "REVIEWER_IS_AUTHOR", "author cannot review own PR")
+	case errors.Is(err, repository.ErrReviewerInactive):
+		response.Error(w, http.StatusConflict, "REVIEWER_INACTIVE", "reviewer is inactive")
+	case errors.Is(err, repository.ErrPairExcluded):
+		response.Error(w, http.StatusConflict, "PAIR_EXCLUDED", "exclusion rule forbids this reviewer for the author")
+	case errors.Is(err, repository.ErrNotTeamMember):
+		response.Error(w, http.StatusConflict, "NOT_TEAM_MEMBER", "reviewer is not a member of the reviewing team or its fallback teams")
+	case errors.Is(err, repository.ErrNotFound):
+		response.Error(w, http.StatusNotFound, "NOT_FOUND", "PR or user not found")
+	default:
+		response.Error(w, http.StatusInternalServerError, "INTERNAL_ERROR", err.Error())
+	}
+}
+
+type DeclineRequest struct {
+	PullRequestID string `json:"pull_request_id"`
+	UserID        string `json:"user_id"`
+	Reason        string `json:"reason"` // NO_CONTEXT, CONFLICT, OVERLOADED, OUT_OF_OFFICE
+	Comment       string `json:"comment"`
+}
+
+// Decline - отказ ревьювера от ревью с автоматической заменой
+func (h *PullRequestHandler) Decline(w http.ResponseWriter, r *http.Request) {
+	var req DeclineRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, http.StatusBadRequest, "INVALID_REQUEST", "invalid request body")
+		return
+	}
+
+	if req.PullRequestID == "" || req.UserID == "" || req.Reason == "" {
+		response.Error(w, http.StatusBadRequest, "INVALID_REQUEST", "pull_request_id, user_id and reason are required")
+		return
+	}
+
+	pr, decline, err := h.prUC.Decline(r.Context(), req.PullRequestID, req.UserID, entity.DeclineReason(req.Reason), req.Comment)
+	if err != nil {
+		if errors.Is(err, repository.ErrInvalidDeclineReason) {
+			response.Error(w, http.StatusBadRequest, "INVALID_REASON", "reason must be NO_CONTEXT, CONFLICT, OVERLOADED or OUT_OF_OFFICE")
+			return
+		}
+		if errors.Is(err, repository.ErrPRMerged) {
+			response.Error(w, http.StatusConflict, "PR_MERGED", "cannot decline review on merged PR")
+			return
+		}
+		if errors.Is(err, repository.ErrPRClosed) {
+			response.Error(w, http.StatusConflict, "PR_CLOSED", "cannot decline review on closed PR")
+			return
+		}
+		if errors.Is(err, repository.ErrPRDraft) {
+			response.Error(w, http.StatusConflict, "PR_DRAFT", "draft PR has no reviewers")
+			return
+		}
+		if errors.Is(err, repository.ErrNotAssigned) {
+			response.Error(w, http.StatusConflict, "NOT_ASSIGNED", "reviewer is not assigned to this PR")
+			return
+		}
+		if errors.Is(err, repository.ErrMandatoryReviewer) {
+			response.Error(w, http.StatusConflict, "MANDATORY_REVIEWER", "mandatory reviewer cannot decline, ask admin to reassign")
+			return
+		}
+		if errors.Is(err, repository.ErrReviewerBlocking) {
+			response.Error(w, http.StatusConflict, "CHANGES_REQUESTED", "reviewer who requested changes cannot decline, ask admin to reassign")
+			return
+		}
+		if errors.Is(err, repository.ErrNotFound) {
+			response.Error(w, http.StatusNotFound, "NOT_FOUND", "PR or user not found")
+			return
+		}
+		response.Error(w, http.StatusInternalServerError, "INTERNAL_ERROR", err.Error())
+		return
+	}
+
+	resp := map[string]interface{}{
+		"pr":      pr,
+		"decline": decline,
+	}
+	// Без замены место доберёт фоновая задача
+	if decline.ReplacedBy != "" {
+		resp["replaced_by"] = decline.ReplacedBy
+	}
+
+	response.JSON(w, http.StatusOK, resp)
+}
+
+func (h *PullRequestHandler) GetDeclines(w http.ResponseWriter, r *http.Request) {
+	prID := r.URL.Query().Get("pull_request_id")
+	if prID == "" {
+		response.Error(w, http.StatusBadRequest, "INVALID_REQUEST", "pull_request_id query parameter is required")
+		return
+	}
+
+	declines, err := h.prUC.GetDeclines(r.Context(), prID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			response.Error(w, http.StatusNotFound, "NOT_FOUND", "pull request not found")
+			return
+		}
+		response.Error(w, http.StatusInternalServerError, "INTERNAL_ERROR", err.Error())
+		return
+	}
+
+	response.JSON(w, http.StatusOK, map[string]interface{}{
+		"pull_request_id": prID,
+		"declines":        declines,
+	})
+}
+
+func (h *PullRequestHandler) GetEscalations(w http.ResponseWriter, r *http.Request) {
+	prID := r.URL.Query().Get("pull_request_id")
+	if prID == "" {
+		response.Error(w, http.StatusBadRequest, "INVALID_REQUEST", "pull_request_id query parameter is required")
+		return
+	}
+
+	escalations, err := h.prUC.GetEscalations(r.Context(), prID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			response.Error(w, http.StatusNotFound, "NOT_FOUND", "pull request not found")
+			return
+		}
+		response.Error(w, http.StatusInternalServerError, "INTERNAL_ERROR", err.Error())
+		return
+	}
+
+	response.JSON(w, http.StatusOK, map[string]interface{}{
+		"pull_request_id": prID,
+		"escalations":     escalations,
+	})
+}
+
+type VerdictRequest struct {
+	PullRequestID string `json:"pull_request_id"`
+	UserID        string `json:"user_id"`
+	Verdict       string `json:"verdict"` // APPROVED, CHANGES_REQUESTED, COMMENTED
+}
+
+// SubmitVerdict - вердикт ревьювера по PR
+func (h *PullRequestHandler) SubmitVerdict(w http.ResponseWriter, r *http.Request) {
+	var req VerdictRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, http.StatusBadRequest, "INVALID_REQUEST", "invalid request body")
+		return
+	}
+
+	if req.PullRequestID == "" || req.UserID == "" || req.Verdict == "" {
+		response.Error(w, http.StatusBadRequest, "INVALID_REQUEST", "all fields are required")
+		return
+	}
+
+	approval, err := h.prUC.SubmitVerdict(r.Context(), req.PullRequestID, req.UserID, entity.Verdict(req.Verdict))
+	if err != nil {
+		switch {
+		case errors.Is(err, repository.ErrInvalidVerdict):
+			response.Error(w, http.StatusBadRequest, "INVALID_VERDICT", "verdict must be one of APPROVED, CHANGES_REQUESTED, COMMENTED")
+		case errors.Is(err, repository.ErrPRMerged):
+			response.Error(w, http.StatusConflict, "PR_MERGED", "cannot review merged PR")
+		case errors.Is(err, repository.ErrPRClosed):
+			response.Error(w, http.StatusConflict, "PR_CLOSED", "cannot review closed PR")
+		case errors.Is(err, repository.ErrPRDraft):
+			response.Error(w, http.StatusConflict, "PR_DRAFT", "draft PR has no reviewers")
+		case errors.Is(err, repository.ErrNotAssigned):
+			response.Error(w, http.StatusConflict, "NOT_ASSIGNED", "reviewer is not assigned to this PR")
+		case errors.Is(err, repository.ErrNotFound):
+			response.Error(w, http.StatusNotFound, "NOT_FOUND", "pull request not found")
+		default:
+			response.Error(w, http.StatusInternalServerError, "INTERNAL_ERROR", err.Error())
+		}
+		return
+	}
+
+	response.JSON(w, http.StatusOK, map[string]interface{}{
+		"approval": approval,
+	})
+}
+
+func (h *PullRequestHandler) GetApproval(w http.ResponseWriter, r *http.Request) {
+	prID := r.URL.Query().Get("pull_request_id")
+	if prID == "" {
+		response.Error(w, http.StatusBadRequest, "INVALID_REQUEST", "pull_request_id query parameter is required")
+		return
+	}
+
+	approval, err := h.prUC.GetApproval(r.Context(), prID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			response.Error(w, http.StatusNotFound, "NOT_FOUND", "pull request not found")
+			return
+		}
+		response.Error(w, http.StatusInternalServerError, "INTERNAL_ERROR", err.Error())
+		return
+	}
+
+	response.JSON(w, http.StatusOK, map[string]interface{}{
+		"approval": approval,
+	})
+}
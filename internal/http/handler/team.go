@@ -2,7 +2,9 @@ package handler
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
+	"strconv"
 
 	"reviewer-service/internal/domain/entity"
 	"reviewer-service/internal/http/response"
@@ -92,3 +94,438 @@ func (h *TeamHandler) Get(w http.ResponseWriter, r *http.Request) {
 
 	response.JSON(w, http.StatusOK, team)
 }
+
+type TeamPolicyRequest struct {
+	TeamName         string   `json:"team_name"`
+	ReviewerCount    *int     `json:"reviewer_count"`
+	MinActiveMembers int      `json:"min_active_members"`
+	MaxOpenReviews   int      `json:"max_open_reviews"`
+	MaxWeightedLoad  int      `json:"max_weighted_load"`
+	Strategy         string   `json:"strategy"`
+	FallbackTeams    []string `json:"fallback_teams"`
+	OwnershipMode    string   `json:"ownership_mode"`
+
+	PreferWorkingHours bool `json:"prefer_working_hours"`
+	PreferWithinHours  int  `json:"prefer_within_hours"`
+	MaxOffHours        int  `json:"max_off_hours"`
+	RequireSenior      bool `json:"require_senior"`
+	LearningReviewer   bool `json:"learning_reviewer"`
+	AntiAffinityDays   int  `json:"anti_affinity_days"`
+	AntiAffinityWeight int  `json:"anti_affinity_weight"`
+
+	ReviewSLAHours      int      `json:"review_sla_hours"`
+	EscalationStepHours int      `json:"escalation_step_hours"`
+	EscalationLadder    []string `json:"escalation_ladder"`
+	TeamLeadID          string   `json:"team_lead_id"`
+
+	RequiredApprovals int `json:"required_approvals"`
+}
+
+func (h *TeamHandler) SetPolicy(w http.ResponseWriter, r *http.Request) {
+	var req TeamPolicyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, http.StatusBadRequest, "INVALID_REQUEST", "invalid request body")
+		return
+	}
+
+	if req.TeamName == "" {
+		response.Error(w, http.StatusBadRequest, "INVALID_REQUEST", "team_name is required")
+		return
+	}
+
+	policy := entity.DefaultTeamPolicy(req.TeamName)
+	if req.ReviewerCount != nil {
+		policy.ReviewerCount = *req.ReviewerCount
+	}
+	policy.MinActiveMembers = req.MinActiveMembers
+	policy.MaxOpenReviews = req.MaxOpenReviews
+	policy.MaxWeightedLoad = req.MaxWeightedLoad
+	policy.Strategy = req.Strategy
+	policy.FallbackTeams = req.FallbackTeams
+	policy.OwnershipMode = entity.OwnershipMode(req.OwnershipMode)
+	policy.PreferWorkingHours = req.PreferWorkingHours
+	policy.PreferWithinHours = req.PreferWithinHours
+	policy.MaxOffHours = req.MaxOffHours
+	policy.RequireSenior = req.RequireSenior
+	policy.LearningReviewer = req.LearningReviewer
+	policy.AntiAffinityDays = req.AntiAffinityDays
+	policy.AntiAffinityWeight = req.AntiAffinityWeight
+	policy.ReviewSLAHours = req.ReviewSLAHours
+	policy.EscalationStepHours = req.EscalationStepHours
+	policy.TeamLeadID = req.TeamLeadID
+	policy.RequiredApprovals = req.RequiredApprovals
+	for _, action := range req.EscalationLadder {
+		policy.EscalationLadder = append(policy.EscalationLadder, entity.EscalationAction(action))
+	}
+
+	result, err := h.teamUC.SetPolicy(r.Context(), policy)
+	if err != nil {
+		if errors.Is(err, repository.ErrInvalidPolicy) {
+			response.Error(w, http.StatusBadRequest, "INVALID_POLICY", err.Error())
+			return
+		}
+		if err == repository.ErrNotFound {
+			response.Error(w, http.StatusNotFound, "NOT_FOUND", "team not found")
+			return
+		}
+		response.Error(w, http.StatusInternalServerError, "INTERNAL_ERROR", err.Error())
+		return
+	}
+
+	response.JSON(w, http.StatusOK, map[string]interface{}{
+		"policy": result,
+	})
+}
+
+func (h *TeamHandler) GetPolicy(w http.ResponseWriter, r *http.Request) {
+	teamName := r.URL.Query().Get("team_name")
+	if teamName == "" {
+		response.Error(w, http.StatusBadRequest, "INVALID_REQUEST", "team_name query parameter is required")
+		return
+	}
+
+	policy, err := h.teamUC.GetPolicy(r.Context(), teamName)
+	if err != nil {
+		if err == repository.ErrNotFound {
+			response.Error(w, http.StatusNotFound, "NOT_FOUND", "team not found")
+			return
+		}
+		response.Error(w, http.StatusInternalServerError, "INTERNAL_ERROR", err.Error())
+		return
+	}
+
+	response.JSON(w, http.StatusOK, map[string]interface{}{
+		"policy": policy,
+	})
+}
+
+type DeletePolicyRequest struct {
+	TeamName string `json:"team_name"`
+}
+
+func (h *TeamHandler) DeletePolicy(w http.ResponseWriter, r *http.Request) {
+	var req DeletePolicyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, http.StatusBadRequest, "INVALID_REQUEST", "invalid request body")
+		return
+	}
+
+	if req.TeamName == "" {
+		response.Error(w, http.StatusBadRequest, "INVALID_REQUEST", "team_name is required")
+		return
+	}
+
+	if err := h.teamUC.DeletePolicy(r.Context(), req.TeamName); err != nil {
+		if err == repository.ErrNotFound {
+			response.Error(w, http.StatusNotFound, "NOT_FOUND", "team policy not found")
+			return
+		}
+		response.Error(w, http.StatusInternalServerError, "INTERNAL_ERROR", err.Error())
+		return
+	}
+
+	response.JSON(w, http.StatusOK, map[string]interface{}{
+		"team_name": req.TeamName,
+	})
+}
+
+type SetCodeOwnersRequest struct {
+	TeamName string `json:"team_name"`
+	Content  string `json:"content"`
+}
+
+func (h *TeamHandler) SetCodeOwners(w http.ResponseWriter, r *http.Request) {
+	var req SetCodeOwnersRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, http.StatusBadRequest, "INVALID_REQUEST", "invalid request body")
+		return
+	}
+
+	if req.TeamName == "" {
+		response.Error(w, http.StatusBadRequest, "INVALID_REQUEST", "team_name is required")
+		return
+	}
+
+	file := &entity.CodeOwnersFile{
+		TeamName: req.TeamName,
+		Content:  req.Content,
+	}
+
+	rules, err := h.teamUC.SetCodeOwners(r.Context(), file)
+	if err != nil {
+		if errors.Is(err, repository.ErrInvalidCodeOwners) {
+			response.Error(w, http.StatusBadRequest, "INVALID_CODEOWNERS", err.Error())
+			return
+		}
+		if err == repository.ErrNotFound {
+			response.Error(w, http.StatusNotFound, "NOT_FOUND", "team not found")
+			return
+		}
+		response.Error(w, http.StatusInternalServerError, "INTERNAL_ERROR", err.Error())
+		return
+	}
+
+	response.JSON(w, http.StatusOK, map[string]interface{}{
+		"team_name":  file.TeamName,
+		"updated_at": file.UpdatedAt,
+		"rules":      rules,
+	})
+}
+
+func (h *TeamHandler) GetCodeOwners(w http.ResponseWriter, r *http.Request) {
+	teamName := r.URL.Query().Get("team_name")
+	if teamName == "" {
+		response.Error(w, http.StatusBadRequest, "INVALID_REQUEST", "team_name query parameter is required")
+		return
+	}
+
+	file, rules, err := h.teamUC.GetCodeOwners(r.Context(), teamName)
+	if err != nil {
+		if err == repository.ErrNotFound {
+			response.Error(w, http.StatusNotFound, "NOT_FOUND", "codeowners not found")
+			return
+		}
+		response.Error(w, http.StatusInternalServerError, "INTERNAL_ERROR", err.Error())
+		return
+	}
+
+	response.JSON(w, http.StatusOK, map[string]interface{}{
+		"team_name":  file.TeamName,
+		"content":    file.Content,
+		"updated_at": file.UpdatedAt,
+		"rules":      rules,
+	})
+}
+
+func (h *TeamHandler) GetStats(w http.ResponseWriter, r *http.Request) {
+	stats, err := h.teamUC.GetStats(r.Context())
+	if err != nil {
+		response.Error(w, http.StatusInternalServerError, "INTERNAL_ERROR", err.Error())
+		return
+	}
+
+	if stats == nil {
+		stats = []*entity.TeamStats{}
+	}
+
+	response.JSON(w, http.StatusOK, map[string]interface{}{
+		"teams": stats,
+	})
+}
+
+func (h *TeamHandler) GetPairings(w http.ResponseWriter, r *http.Request) {
+	teamName := r.URL.Query().Get("team_name")
+	if teamName == "" {
+		response.Error(w, http.StatusBadRequest, "INVALID_REQUEST", "team_name query parameter is required")
+		return
+	}
+
+	days := 0
+	if raw := r.URL.Query().Get("days"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil || parsed <= 0 {
+			response.Error(w, http.StatusBadRequest, "INVALID_REQUEST", "days must be a positive integer")
+			return
+		}
+		days = parsed
+	}
+
+	matrix, err := h.teamUC.GetPairingMatrix(r.Context(), teamName, days)
+	if err != nil {
+		if err == repository.ErrNotFound {
+			response.Error(w, http.StatusNotFound, "NOT_FOUND", "team not found")
+			return
+		}
+		response.Error(w, http.StatusInternalServerError, "INTERNAL_ERROR", err.Error())
+		return
+	}
+
+	response.JSON(w, http.StatusOK, map[string]interface{}{
+		"pairings": matrix,
+	})
+}
+
+type MandatoryReviewerRequest struct {
+	TeamName string   `json:"team_name"`
+	UserID   string   `json:"user_id"`
+	Labels   []string `json:"labels"`
+}
+
+func (h *TeamHandler) SetMandatoryReviewer(w http.ResponseWriter, r *http.Request) {
+	var req MandatoryReviewerRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, http.StatusBadRequest, "INVALID_REQUEST", "invalid request body")
+		return
+	}
+
+	if req.TeamName == "" || req.UserID == "" {
+		response.Error(w, http.StatusBadRequest, "INVALID_REQUEST", "team_name and user_id are required")
+		return
+	}
+
+	reviewer, err := h.teamUC.SetMandatoryReviewer(r.Context(), &entity.MandatoryReviewer{
+		TeamName: req.TeamName,
+		UserID:   req.UserID,
+		Labels:   req.Labels,
+	})
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			response.Error(w, http.StatusNotFound, "NOT_FOUND", "team or user not found")
+			return
+		}
+		response.Error(w, http.StatusInternalServerError, "INTERNAL_ERROR", err.Error())
+		return
+	}
+
+	response.JSON(w, http.StatusOK, map[string]interface{}{
+		"mandatory_reviewer": reviewer,
+	})
+}
+
+func (h *TeamHandler) RemoveMandatoryReviewer(w http.ResponseWriter, r *http.Request) {
+	var req MandatoryReviewerRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, http.StatusBadRequest, "INVALID_REQUEST", "invalid request body")
+		return
+	}
+
+	if req.TeamName == "" || req.UserID == "" {
+		response.Error(w, http.StatusBadRequest, "INVALID_REQUEST", "team_name and user_id are required")
+		return
+	}
+
+	if err := h.teamUC.RemoveMandatoryReviewer(r.Context(), req.TeamName, req.UserID); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			response.Error(w, http.StatusNotFound, "NOT_FOUND", "mandatory reviewer not found")
+			return
+		}
+		response.Error(w, http.StatusInternalServerError, "INTERNAL_ERROR", err.Error())
+		return
+	}
+
+	response.JSON(w, http.StatusOK, map[string]interface{}{
+		"team_name": req.TeamName,
+		"user_id":   req.UserID,
+	})
+}
+
+func (h *TeamHandler) GetMandatoryReviewers(w http.ResponseWriter, r *http.Request) {
+	teamName := r.URL.Query().Get("team_name")
+	if teamName == "" {
+		response.Error(w, http.StatusBadRequest, "INVALID_REQUEST", "team_name query parameter is required")
+		return
+	}
+
+	reviewers, err := h.teamUC.GetMandatoryReviewers(r.Context(), teamName)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			response.Error(w, http.StatusNotFound, "NOT_FOUND", "team not found")
+			return
+		}
+		response.Error(w, http.StatusInternalServerError, "INTERNAL_ERROR", err.Error())
+		return
+	}
+
+	response.JSON(w, http.StatusOK, map[string]interface{}{
+		"mandatory_reviewers": reviewers,
+	})
+}
+
+type RepositoryRequest struct {
+	Repository  string   `json:"repository"`
+	OwningTeams []string `json:"owning_teams"`
+}
+
+func (h *TeamHandler) SetRepository(w http.ResponseWriter, r *http.Request) {
+	var req RepositoryRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, http.StatusBadRequest, "INVALID_REQUEST", "invalid request body")
+		return
+	}
+
+	if req.Repository == "" || len(req.OwningTeams) == 0 {
+		response.Error(w, http.StatusBadRequest, "INVALID_REQUEST", "repository and owning_teams are required")
+		return
+	}
+
+	repo, err := h.teamUC.SetRepository(r.Context(), &entity.Repository{
+		Name:        req.Repository,
+		OwningTeams: req.OwningTeams,
+	})
+	if err != nil {
+		if errors.Is(err, repository.ErrInvalidRepository) {
+			response.Error(w, http.StatusBadRequest, "INVALID_REPOSITORY", err.Error())
+			return
+		}
+		if errors.Is(err, repository.ErrNotFound) || errors.Is(err, repository.ErrTeamNotFound) {
+			response.Error(w, http.StatusNotFound, "NOT_FOUND", "team not found")
+			return
+		}
+		response.Error(w, http.StatusInternalServerError, "INTERNAL_ERROR", err.Error())
+		return
+	}
+
+	response.JSON(w, http.StatusOK, map[string]interface{}{
+		"repository": repo,
+	})
+}
+
+func (h *TeamHandler) GetRepository(w http.ResponseWriter, r *http.Request) {
+	name := r.URL.Query().Get("repository")
+	if name == "" {
+		response.Error(w, http.StatusBadRequest, "INVALID_REQUEST", "repository query parameter is required")
+		return
+	}
+
+	repo, err := h.teamUC.GetRepository(r.Context(), name)
+	if err != nil {
+		if errors.Is(err, repository.ErrRepositoryNotFound) {
+			response.Error(w, http.StatusNotFound, "REPOSITORY_NOT_FOUND", "repository not found")
+			return
+		}
+		response.Error(w, http.StatusInternalServerError, "INTERNAL_ERROR", err.Error())
+		return
+	}
+
+	response.JSON(w, http.StatusOK, map[string]interface{}{
+		"repository": repo,
+	})
+}
+
+func (h *TeamHandler) ListRepositories(w http.ResponseWriter, r *http.Request) {
+	repos, err := h.teamUC.ListRepositories(r.Context())
+	if err != nil {
+		response.Error(w, http.StatusInternalServerError, "INTERNAL_ERROR", err.Error())
+		return
+	}
+
+	response.JSON(w, http.StatusOK, map[string]interface{}{
+		"repositories": repos,
+	})
+}
+
+func (h *TeamHandler) DeleteRepository(w http.ResponseWriter, r *http.Request) {
+	var req RepositoryRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, http.StatusBadRequest, "INVALID_REQUEST", "invalid request body")
+		return
+	}
+
+	if req.Repository == "" {
+		response.Error(w, http.StatusBadRequest, "INVALID_REQUEST", "repository is required")
+		return
+	}
+
+	if err := h.teamUC.DeleteRepository(r.Context(), req.Repository); err != nil {
+		if errors.Is(err, repository.ErrRepositoryNotFound) {
+			response.Error(w, http.StatusNotFound, "REPOSITORY_NOT_FOUND", "repository not found")
+			return
+		}
+		response.Error(w, http.StatusInternalServerError, "INTERNAL_ERROR", err.Error())
+		return
+	}
+
+	response.JSON(w, http.StatusOK, map[string]interface{}{
+		"repository": req.Repository,
+	})
+}
@@ -3,8 +3,12 @@ package handler
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
+	"strings"
+	"time"
 
+	"reviewer-service/internal/domain/entity"
 	"reviewer-service/internal/http/response"
 	"reviewer-service/internal/repository"
 	"reviewer-service/internal/usecase"
@@ -41,6 +45,253 @@ func (h *UserHandler) SetIsActive(w http.ResponseWriter, r *http.Request) {
 			response.Error(w, http.StatusNotFound, "NOT_FOUND", "user not found")
 			return
 		}
+		if err == repository.ErrMinActiveMembers {
+			response.Error(w, http.StatusConflict, "MIN_ACTIVE_MEMBERS", "team would drop below minimum active members")
+			return
+		}
+		response.Error(w, http.StatusInternalServerError, "INTERNAL_ERROR", err.Error())
+		return
+	}
+
+	response.JSON(w, http.StatusOK, map[string]interface{}{
+		"user": user,
+	})
+}
+
+type SetTagsRequest struct {
+	UserID string   `json:"user_id"`
+	Tags   []string `json:"tags"`
+}
+
+func (h *UserHandler) SetTags(w http.ResponseWriter, r *http.Request) {
+	var req SetTagsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, http.StatusBadRequest, "INVALID_REQUEST", "invalid request body")
+		return
+	}
+
+	if req.UserID == "" {
+		response.Error(w, http.StatusBadRequest, "INVALID_REQUEST", "user_id is required")
+		return
+	}
+
+	user, err := h.userUC.SetTags(r.Context(), req.UserID, req.Tags)
+	if err != nil {
+		if err == repository.ErrNotFound {
+			response.Error(w, http.StatusNotFound, "NOT_FOUND", "user not found")
+			return
+		}
+		response.Error(w, http.StatusInternalServerError, "INTERNAL_ERROR", err.Error())
+		return
+	}
+
+	response.JSON(w, http.StatusOK, map[string]interface{}{
+		"user": user,
+	})
+}
+
+type SetSeniorityRequest struct {
+	UserID    string           `json:"user_id"`
+	Seniority entity.Seniority `json:"seniority"`
+}
+
+func (h *UserHandler) SetSeniority(w http.ResponseWriter, r *http.Request) {
+	var req SetSeniorityRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, http.StatusBadRequest, "INVALID_REQUEST", "invalid request body")
+		return
+	}
+
+	if req.UserID == "" || req.Seniority == "" {
+		response.Error(w, http.StatusBadRequest, "INVALID_REQUEST", "user_id and seniority are required")
+		return
+	}
+
+	user, err := h.userUC.SetSeniority(r.Context(), req.UserID, req.Seniority)
+	if err != nil {
+		if errors.Is(err, repository.ErrInvalidSeniority) {
+			response.Error(w, http.StatusBadRequest, "INVALID_SENIORITY", err.Error())
+			return
+		}
+		if errors.Is(err, repository.ErrNotFound) {
+			response.Error(w, http.StatusNotFound, "NOT_FOUND", "user not found")
+			return
+		}
+		response.Error(w, http.StatusInternalServerError, "INTERNAL_ERROR", err.Error())
+		return
+	}
+
+	response.JSON(w, http.StatusOK, map[string]interface{}{
+		"user": user,
+	})
+}
+
+type SetCapacityRequest struct {
+	UserID          string `json:"user_id"`
+	MaxOpenReviews  *int   `json:"max_open_reviews"`
+	CapacityPercent *int   `json:"capacity_percent"`
+}
+
+func (h *UserHandler) SetCapacity(w http.ResponseWriter, r *http.Request) {
+	var req SetCapacityRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, http.StatusBadRequest, "INVALID_REQUEST", "invalid request body")
+		return
+	}
+
+	if req.UserID == "" {
+		response.Error(w, http.StatusBadRequest, "INVALID_REQUEST", "user_id is required")
+		return
+	}
+
+	user, err := h.userUC.SetCapacity(r.Context(), req.UserID, req.MaxOpenReviews, req.CapacityPercent)
+	if err != nil {
+		if errors.Is(err, repository.ErrInvalidCapacity) {
+			response.Error(w, http.StatusBadRequest, "INVALID_CAPACITY", err.Error())
+			return
+		}
+		if errors.Is(err, repository.ErrNotFound) {
+			response.Error(w, http.StatusNotFound, "NOT_FOUND", "user not found")
+			return
+		}
+		response.Error(w, http.StatusInternalServerError, "INTERNAL_ERROR", err.Error())
+		return
+	}
+
+	response.JSON(w, http.StatusOK, map[string]interface{}{
+		"user": user,
+	})
+}
+
+type AddAbsenceRequest struct {
+	UserID   string    `json:"user_id"`
+	StartsAt time.Time `json:"starts_at"`
+	EndsAt   time.Time `json:"ends_at"`
+	Reason   string    `json:"reason"`
+}
+
+func (h *UserHandler) AddAbsence(w http.ResponseWriter, r *http.Request) {
+	var req AddAbsenceRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, http.StatusBadRequest, "INVALID_REQUEST", "invalid request body")
+		return
+	}
+
+	if req.UserID == "" || req.StartsAt.IsZero() || req.EndsAt.IsZero() {
+		response.Error(w, http.StatusBadRequest, "INVALID_REQUEST", "user_id, starts_at and ends_at are required")
+		return
+	}
+
+	absence, err := h.userUC.AddAbsence(r.Context(), &entity.Absence{
+		UserID:   req.UserID,
+		StartsAt: req.StartsAt,
+		EndsAt:   req.EndsAt,
+		Reason:   req.Reason,
+	})
+	if err != nil {
+		if errors.Is(err, repository.ErrInvalidAbsence) {
+			response.Error(w, http.StatusBadRequest, "INVALID_ABSENCE", err.Error())
+			return
+		}
+		if errors.Is(err, repository.ErrNotFound) || errors.Is(err, repository.ErrUserNotFound) {
+			response.Error(w, http.StatusNotFound, "NOT_FOUND", "user not found")
+			return
+		}
+		response.Error(w, http.StatusInternalServerError, "INTERNAL_ERROR", err.Error())
+		return
+	}
+
+	response.JSON(w, http.StatusCreated, map[string]interface{}{
+		"absence": absence,
+	})
+}
+
+func (h *UserHandler) GetAbsences(w http.ResponseWriter, r *http.Request) {
+	userID := r.URL.Query().Get("user_id")
+	if userID == "" {
+		response.Error(w, http.StatusBadRequest, "INVALID_REQUEST", "user_id query parameter is required")
+		return
+	}
+
+	absences, err := h.userUC.GetAbsences(r.Context(), userID)
+	if err != nil {
+		if err == repository.ErrNotFound {
+			response.Error(w, http.StatusNotFound, "NOT_FOUND", "user not found")
+			return
+		}
+		response.Error(w, http.StatusInternalServerError, "INTERNAL_ERROR", err.Error())
+		return
+	}
+
+	response.JSON(w, http.StatusOK, map[string]interface{}{
+		"user_id":  userID,
+		"absences": absences,
+	})
+}
+
+type CancelAbsenceRequest struct {
+	UserID    string `json:"user_id"`
+	AbsenceID int64  `json:"absence_id"`
+}
+
+func (h *UserHandler) CancelAbsence(w http.ResponseWriter, r *http.Request) {
+	var req CancelAbsenceRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, http.StatusBadRequest, "INVALID_REQUEST", "invalid request body")
+		return
+	}
+
+	if req.UserID == "" || req.AbsenceID == 0 {
+		response.Error(w, http.StatusBadRequest, "INVALID_REQUEST", "user_id and absence_id are required")
+		return
+	}
+
+	if err := h.userUC.CancelAbsence(r.Context(), req.UserID, req.AbsenceID); err != nil {
+		if errors.Is(err, repository.ErrInvalidAbsence) {
+			response.Error(w, http.StatusConflict, "INVALID_ABSENCE", err.Error())
+			return
+		}
+		if errors.Is(err, repository.ErrNotFound) {
+			response.Error(w, http.StatusNotFound, "NOT_FOUND", "absence not found")
+			return
+		}
+		response.Error(w, http.StatusInternalServerError, "INTERNAL_ERROR", err.Error())
+		return
+	}
+
+	response.JSON(w, http.StatusOK, map[string]interface{}{
+		"absence_id": req.AbsenceID,
+	})
+}
+
+type SetWorkingHoursRequest struct {
+	UserID       string                `json:"user_id"`
+	Timezone     string                `json:"timezone"`
+	WorkingHours []entity.WorkingHours `json:"working_hours"`
+}
+
+func (h *UserHandler) SetWorkingHours(w http.ResponseWriter, r *http.Request) {
+	var req SetWorkingHoursRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, http.StatusBadRequest, "INVALID_REQUEST", "invalid request body")
+		return
+	}
+
+	if req.UserID == "" {
+		response.Error(w, http.StatusBadRequest, "INVALID_REQUEST", "user_id is required")
+		return
+	}
+
+	user, err := h.userUC.SetWorkingHours(r.Context(), req.UserID, req.Timezone, req.WorkingHours)
+	if err != nil {
+		if errors.Is(err, repository.ErrInvalidSchedule) {
+			response.Error(w, http.StatusBadRequest, "INVALID_SCHEDULE", err.Error())
+			return
+		}
+		if errors.Is(err, repository.ErrNotFound) {
+			response.Error(w, http.StatusNotFound, "NOT_FOUND", "user not found")
+			return
+		}
 		response.Error(w, http.StatusInternalServerError, "INTERNAL_ERROR", err.Error())
 		return
 	}
@@ -50,6 +301,39 @@ func (h *UserHandler) SetIsActive(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+// GetAvailability принимает необязательный параметр at (RFC 3339),
+// чтобы проверить расписание на произвольный момент
+func (h *UserHandler) GetAvailability(w http.ResponseWriter, r *http.Request) {
+	userID := r.URL.Query().Get("user_id")
+	if userID == "" {
+		response.Error(w, http.StatusBadRequest, "INVALID_REQUEST", "user_id query parameter is required")
+		return
+	}
+
+	var at time.Time
+	if raw := r.URL.Query().Get("at"); raw != "" {
+		var err error
+		if at, err = time.Parse(time.RFC3339, raw); err != nil {
+			response.Error(w, http.StatusBadRequest, "INVALID_REQUEST", "at must be RFC 3339 timestamp")
+			return
+		}
+	}
+
+	availability, err := h.userUC.GetAvailability(r.Context(), userID, at)
+	if err != nil {
+		if err == repository.ErrNotFound {
+			response.Error(w, http.StatusNotFound, "NOT_FOUND", "user not found")
+			return
+		}
+		response.Error(w, http.StatusInternalServerError, "INTERNAL_ERROR", err.Error())
+		return
+	}
+
+	response.JSON(w, http.StatusOK, map[string]interface{}{
+		"availability": availability,
+	})
+}
+
 func (h *UserHandler) GetReview(w http.ResponseWriter, r *http.Request) {
 	userID := r.URL.Query().Get("user_id")
 	if userID == "" {
@@ -57,7 +341,20 @@ func (h *UserHandler) GetReview(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	prs, err := h.userUC.GetReviews(r.Context(), userID)
+	query := r.URL.Query()
+	filter := entity.ReviewFilter{
+		Status:       entity.PRStatus(query.Get("status")),
+		Repository:   query.Get("repository"),
+		SourceBranch: query.Get("source_branch"),
+		TargetBranch: query.Get("target_branch"),
+		Label:        strings.ToLower(strings.TrimSpace(query.Get("label"))),
+	}
+	if filter.Status != "" && !filter.Status.Valid() {
+		response.Error(w, http.StatusBadRequest, "INVALID_REQUEST", "unknown status filter")
+		return
+	}
+
+	prs, err := h.userUC.GetReviews(r.Context(), userID, filter)
 	if err != nil {
 		if err == repository.ErrNotFound {
 			response.Error(w, http.StatusNotFound, "NOT_FOUND", "user not found")
@@ -72,3 +369,139 @@ func (h *UserHandler) GetReview(w http.ResponseWriter, r *http.Request) {
 		"pull_requests": prs,
 	})
 }
+
+type AddExclusionRequest struct {
+	AuthorID   string     `json:"author_id"`
+	ReviewerID string     `json:"reviewer_id"`
+	BothWays   bool       `json:"both_ways"`
+	Reason     string     `json:"reason"`
+	ExpiresAt  *time.Time `json:"expires_at"`
+}
+
+func (h *UserHandler) AddExclusion(w http.ResponseWriter, r *http.Request) {
+	var req AddExclusionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, http.StatusBadRequest, "INVALID_REQUEST", "invalid request body")
+		return
+	}
+
+	if req.AuthorID == "" || req.ReviewerID == "" {
+		response.Error(w, http.StatusBadRequest, "INVALID_REQUEST", "author_id and reviewer_id are required")
+		return
+	}
+
+	exclusion, err := h.userUC.AddExclusion(r.Context(), &entity.ReviewExclusion{
+		AuthorID:   req.AuthorID,
+		ReviewerID: req.ReviewerID,
+		BothWays:   req.BothWays,
+		Reason:     req.Reason,
+		ExpiresAt:  req.ExpiresAt,
+	})
+	if err != nil {
+		if errors.Is(err, repository.ErrInvalidExclusion) {
+			response.Error(w, http.StatusBadRequest, "INVALID_EXCLUSION", err.Error())
+			return
+		}
+		if errors.Is(err, repository.ErrExclusionExists) {
+			response.Error(w, http.StatusConflict, "EXCLUSION_EXISTS", "exclusion for this pair already exists")
+			return
+		}
+		if errors.Is(err, repository.ErrNotFound) || errors.Is(err, repository.ErrUserNotFound) {
+			response.Error(w, http.StatusNotFound, "NOT_FOUND", "user not found")
+			return
+		}
+		response.Error(w, http.StatusInternalServerError, "INTERNAL_ERROR", err.Error())
+		return
+	}
+
+	response.JSON(w, http.StatusCreated, map[string]interface{}{
+		"exclusion": exclusion,
+	})
+}
+
+func (h *UserHandler) GetExclusions(w http.ResponseWriter, r *http.Request) {
+	userID := r.URL.Query().Get("user_id")
+	if userID == "" {
+		response.Error(w, http.StatusBadRequest, "INVALID_REQUEST", "user_id query parameter is required")
+		return
+	}
+
+	exclusions, err := h.userUC.GetExclusions(r.Context(), userID)
+	if err != nil {
+		if err == repository.ErrNotFound {
+			response.Error(w, http.StatusNotFound, "NOT_FOUND", "user not found")
+			return
+		}
+		response.Error(w, http.StatusInternalServerError, "INTERNAL_ERROR", err.Error())
+		return
+	}
+
+	response.JSON(w, http.StatusOK, map[string]interface{}{
+		"user_id":    userID,
+		"exclusions": exclusions,
+	})
+}
+
+type RemoveExclusionRequest struct {
+	ExclusionID int64 `json:"exclusion_id"`
+}
+
+func (h *UserHandler) RemoveExclusion(w http.ResponseWriter, r *http.Request) {
+	var req RemoveExclusionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, http.StatusBadRequest, "INVALID_REQUEST", "invalid request body")
+		return
+	}
+
+	if req.ExclusionID == 0 {
+		response.Error(w, http.StatusBadRequest, "INVALID_REQUEST", "exclusion_id is required")
+		return
+	}
+
+	if err := h.userUC.RemoveExclusion(r.Context(), req.ExclusionID); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			response.Error(w, http.StatusNotFound, "NOT_FOUND", "exclusion not found")
+			return
+		}
+		response.Error(w, http.StatusInternalServerError, "INTERNAL_ERROR", err.Error())
+		return
+	}
+
+	response.JSON(w, http.StatusOK, map[string]interface{}{
+		"exclusion_id": req.ExclusionID,
+	})
+}
+
+type BulkDeactivateRequest struct {
+	UserIDs []string `json:"user_ids"`
+}
+
+// BulkDeactivate выключает пользователей и переназначает их открытые ревью
+func (h *UserHandler) BulkDeactivate(w http.ResponseWriter, r *http.Request) {
+	var req BulkDeactivateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, http.StatusBadRequest, "INVALID_REQUEST", "invalid request body")
+		return
+	}
+
+	if len(req.UserIDs) == 0 {
+		response.Error(w, http.StatusBadRequest, "INVALID_REQUEST", "user_ids is required")
+		return
+	}
+
+	result, err := h.userUC.BulkDeactivate(r.Context(), req.UserIDs)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			response.Error(w, http.StatusNotFound, "NOT_FOUND", "user not found")
+			return
+		}
+		if errors.Is(err, repository.ErrMinActiveMembers) {
+			response.Error(w, http.StatusConflict, "MIN_ACTIVE_MEMBERS", "team would drop below minimum active members")
+			return
+		}
+		response.Error(w, http.StatusInternalServerError, "INTERNAL_ERROR", err.Error())
+		return
+	}
+
+	response.JSON(w, http.StatusOK, result)
+}
@@ -0,0 +1,33 @@
+package middleware
+
+import (
+	"context"
+	"crypto/subtle"
+	"net/http"
+)
+
+// AdminTokenHeader - заголовок с токеном администратора
+const AdminTokenHeader = "X-Admin-Token"
+
+type adminKey struct{}
+
+// Admin помечает запрос как административный, если он пришёл с верным
+// токеном. Пустой token отключает административные операции.
+func Admin(token string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			got := r.Header.Get(AdminTokenHeader)
+			if token != "" && subtle.ConstantTimeCompare([]byte(got), []byte(token)) == 1 {
+				r = r.WithContext(context.WithValue(r.Context(), adminKey{}, true))
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// IsAdmin проверяет, что запрос выполняет администратор
+func IsAdmin(ctx context.Context) bool {
+	admin, _ := ctx.Value(adminKey{}).(bool)
+	return admin
+}
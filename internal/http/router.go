@@ -14,17 +14,22 @@ type Router struct {
 	teamHandler *handler.TeamHandler
 	userHandler *handler.UserHandler
 	prHandler   *handler.PullRequestHandler
+	adminToken  string
 }
 
+// NewRouter создаёт роутер. adminToken открывает административные
+// операции (пусто = выключены).
 func NewRouter(
 	teamHandler *handler.TeamHandler,
 	userHandler *handler.UserHandler,
 	prHandler *handler.PullRequestHandler,
+	adminToken string,
 ) *Router {
 	return &Router{
 		teamHandler: teamHandler,
 		userHandler: userHandler,
 		prHandler:   prHandler,
+		adminToken:  adminToken,
 	}
 }
 
@@ -35,6 +40,7 @@ func (rt *Router) Setup() http.Handler {
 	r.Use(middleware.Logger)
 	r.Use(middleware.Recovery)
 	r.Use(middleware.RequestID)
+	r.Use(middleware.Admin(rt.adminToken))
 
 	// Health check
 	r.Get("/health", func(w http.ResponseWriter, r *http.Request) {
@@ -46,15 +52,57 @@ func (rt *Router) Setup() http.Handler {
 	// Teams
 	r.Post("/team/add", rt.teamHandler.Create)
 	r.Get("/team/get", rt.teamHandler.Get)
+	r.Post("/team/setPolicy", rt.teamHandler.SetPolicy)
+	r.Get("/team/getPolicy", rt.teamHandler.GetPolicy)
+	r.Post("/team/deletePolicy", rt.teamHandler.DeletePolicy)
+	r.Post("/team/setCodeowners", rt.teamHandler.SetCodeOwners)
+	r.Get("/team/getCodeowners", rt.teamHandler.GetCodeOwners)
+	r.Get("/team/pairings", rt.teamHandler.GetPairings)
+	r.Get("/team/stats", rt.teamHandler.GetStats)
+	r.Post("/team/setMandatoryReviewer", rt.teamHandler.SetMandatoryReviewer)
+	r.Post("/team/removeMandatoryReviewer", rt.teamHandler.RemoveMandatoryReviewer)
+	r.Get("/team/getMandatoryReviewers", rt.teamHandler.GetMandatoryReviewers)
+
+	// Repositories
+	r.Post("/repository/set", rt.teamHandler.SetRepository)
+	r.Get("/repository/get", rt.teamHandler.GetRepository)
+	r.Get("/repository/list", rt.teamHandler.ListRepositories)
+	r.Post("/repository/delete", rt.teamHandler.DeleteRepository)
 
 	// Users
 	r.Post("/users/setIsActive", rt.userHandler.SetIsActive)
+	r.Post("/users/bulkDeactivate", rt.userHandler.BulkDeactivate)
 	r.Get("/users/getReview", rt.userHandler.GetReview)
+	r.Post("/users/setTags", rt.userHandler.SetTags)
+	r.Post("/users/setCapacity", rt.userHandler.SetCapacity)
+	r.Post("/users/setSeniority", rt.userHandler.SetSeniority)
+	r.Post("/users/addAbsence", rt.userHandler.AddAbsence)
+	r.Get("/users/getAbsences", rt.userHandler.GetAbsences)
+	r.Post("/users/cancelAbsence", rt.userHandler.CancelAbsence)
+	r.Post("/users/setWorkingHours", rt.userHandler.SetWorkingHours)
+	r.Get("/users/getAvailability", rt.userHandler.GetAvailability)
+	r.Post("/users/addExclusion", rt.userHandler.AddExclusion)
+	r.Get("/users/getExclusions", rt.userHandler.GetExclusions)
+	r.Post("/users/removeExclusion", rt.userHandler.RemoveExclusion)
 
 	// Pull Requests
 	r.Post("/pullRequest/create", rt.prHandler.Create)
+	r.Post("/pullRequest/update", rt.prHandler.Update)
 	r.Post("/pullRequest/merge", rt.prHandler.Merge)
+	r.Post("/pullRequest/close", rt.prHandler.Close)
+	r.Post("/pullRequest/reopen", rt.prHandler.Reopen)
+	r.Post("/pullRequest/ready", rt.prHandler.MarkReady)
 	r.Post("/pullRequest/reassign", rt.prHandler.Reassign)
+	r.Post("/pullRequest/reassignTo", rt.prHandler.ReassignTo)
+	r.Post("/pullRequest/addReviewer", rt.prHandler.AddReviewer)
+	r.Post("/pullRequest/removeReviewer", rt.prHandler.RemoveReviewer)
+	r.Post("/pullRequest/decline", rt.prHandler.Decline)
+	r.Get("/pullRequest/declines", rt.prHandler.GetDeclines)
+	r.Get("/pullRequest/escalations", rt.prHandler.GetEscalations)
+	r.Post("/pullRequest/review", rt.prHandler.SubmitVerdict)
+	r.Get("/pullRequest/approval", rt.prHandler.GetApproval)
+	r.Get("/pullRequest/queue", rt.prHandler.GetQueue)
+	r.Post("/pullRequest/suggest", rt.prHandler.Suggest)
 
 	return r
 }
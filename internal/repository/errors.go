@@ -11,16 +11,65 @@ var (
 	ErrTeamExists   = errors.New("team already exists")
 	ErrTeamNotFound = errors.New("team not found")
 
+	// Policy errors
+	ErrInvalidPolicy    = errors.New("invalid team policy")
+	ErrMinActiveMembers = errors.New("team would drop below minimum active members")
+
+	// CODEOWNERS errors
+	ErrInvalidCodeOwners = errors.New("invalid codeowners file")
+
 	// User errors
 	ErrUserExists   = errors.New("user already exists")
 	ErrUserNotFound = errors.New("user not found")
 
+	// Capacity errors
+	ErrInvalidCapacity = errors.New("invalid reviewer capacity")
+
+	// Absence errors
+	ErrInvalidAbsence = errors.New("invalid absence period")
+
+	// Working hours errors
+	ErrInvalidSchedule = errors.New("invalid working hours schedule")
+
+	// Seniority errors
+	ErrInvalidSeniority = errors.New("invalid seniority level")
+
+	// Exclusion errors
+	ErrInvalidExclusion = errors.New("invalid review exclusion")
+	ErrExclusionExists  = errors.New("review exclusion already exists")
+
+	// Decline errors
+	ErrInvalidDeclineReason = errors.New("invalid decline reason")
+
 	// PR errors
-	ErrPRExists   = errors.New("pull request already exists")
-	ErrPRNotFound = errors.New("pull request not found")
-	ErrPRMerged   = errors.New("pull request is merged")
+	ErrPRExists      = errors.New("pull request already exists")
+	ErrPRNotFound    = errors.New("pull request not found")
+	ErrPRMerged      = errors.New("pull request is merged")
+	ErrPRClosed      = errors.New("pull request is closed")
+	ErrPRDraft       = errors.New("pull request is a draft")
+	ErrInvalidPRSize = errors.New("invalid pull request size metrics")
+	ErrInvalidPRMeta = errors.New("invalid pull request metadata")
+
+	// Repository errors
+	ErrRepositoryNotFound = errors.New("repository not found")
+	ErrInvalidRepository  = errors.New("invalid repository")
+
+	// Verdict errors
+	ErrInvalidVerdict   = errors.New("invalid review verdict")
+	ErrNotApproved      = errors.New("pull request does not have enough approvals")
+	ErrChangesRequested = errors.New("pull request has outstanding change requests")
+	ErrReviewerBlocking = errors.New("reviewer has requested changes")
 
 	// Reviewer errors
-	ErrNotAssigned = errors.New("reviewer not assigned to this PR")
-	ErrNoCandidate = errors.New("no candidate available for assignment")
+	ErrNotAssigned       = errors.New("reviewer not assigned to this PR")
+	ErrNoCandidate       = errors.New("no candidate available for assignment")
+	ErrOwnerUnavailable  = errors.New("no available owner for changed paths")
+	ErrNoCapacity        = errors.New("no reviewer has free capacity")
+	ErrMandatoryReviewer = errors.New("reviewer is mandatory for this PR")
+	ErrExcludedByRule    = errors.New("exclusion rules removed all remaining candidates")
+	ErrAlreadyAssigned   = errors.New("reviewer already assigned to this PR")
+	ErrReviewerInactive  = errors.New("reviewer is inactive")
+	ErrReviewerIsAuthor  = errors.New("author cannot review own PR")
+	ErrPairExcluded      = errors.New("exclusion rule forbids this reviewer for the author")
+	ErrNotTeamMember     = errors.New("reviewer is not a member of the reviewing team or its fallback teams")
 )
@@ -2,6 +2,8 @@ package repository
 
 import (
 	"context"
+	"time"
+
 	"reviewer-service/internal/domain/entity"
 )
 
@@ -16,6 +18,14 @@ type Tx interface {
 	Users() UserRepository
 	PullRequests() PullRequestRepository
 	Stats() StatsRepository
+	Policies() TeamPolicyRepository
+	CodeOwners() CodeOwnersRepository
+	Absences() AbsenceRepository
+	MandatoryReviewers() MandatoryReviewerRepository
+	Exclusions() ExclusionRepository
+	Declines() DeclineRepository
+	Escalations() EscalationRepository
+	Repos() ReposRepository
 
 	Commit() error
 	Rollback() error
@@ -28,6 +38,55 @@ type TeamRepository interface {
 	Exists(ctx context.Context, name string) (bool, error)
 }
 
+// TeamPolicyRepository - политики назначения ревьюверов
+type TeamPolicyRepository interface {
+	GetByTeam(ctx context.Context, teamName string) (*entity.TeamPolicy, error)
+	Upsert(ctx context.Context, policy *entity.TeamPolicy) error
+	Delete(ctx context.Context, teamName string) error
+}
+
+// CodeOwnersRepository - файлы владения путями (CODEOWNERS)
+type CodeOwnersRepository interface {
+	GetByTeam(ctx context.Context, teamName string) (*entity.CodeOwnersFile, error)
+	Upsert(ctx context.Context, file *entity.CodeOwnersFile) error
+}
+
+// MandatoryReviewerRepository - обязательные ревьюверы команд
+type MandatoryReviewerRepository interface {
+	GetByTeam(ctx context.Context, teamName string) ([]*entity.MandatoryReviewer, error)
+	Upsert(ctx context.Context, reviewer *entity.MandatoryReviewer) error
+	Delete(ctx context.Context, teamName, userID string) error
+}
+
+// ExclusionRepository - запреты ревью для пар автор-ревьювер
+type ExclusionRepository interface {
+	Create(ctx context.Context, exclusion *entity.ReviewExclusion) error
+	GetByUser(ctx context.Context, userID string) ([]*entity.ReviewExclusion, error)
+	GetActiveForAuthor(ctx context.Context, authorID string, now time.Time) ([]*entity.ReviewExclusion, error)
+	Delete(ctx context.Context, id int64) error
+}
+
+// DeclineRepository - отказы ревьюверов от ревью
+type DeclineRepository interface {
+	Create(ctx context.Context, decline *entity.ReviewDecline) error
+	GetByPR(ctx context.Context, prID string) ([]*entity.ReviewDecline, error)
+	GetDecliners(ctx context.Context, prID string) ([]string, error)
+}
+
+// ReposRepository - репозитории кода и их команды-владельцы
+type ReposRepository interface {
+	GetByName(ctx context.Context, name string) (*entity.Repository, error)
+	List(ctx context.Context) ([]*entity.Repository, error)
+	Upsert(ctx context.Context, repo *entity.Repository) error
+	Delete(ctx context.Context, name string) error
+}
+
+// EscalationRepository - журнал эскалаций просроченных ревью
+type EscalationRepository interface {
+	Create(ctx context.Context, escalation *entity.ReviewEscalation) error
+	GetByPR(ctx context.Context, prID string) ([]*entity.ReviewEscalation, error)
+}
+
 // UserRepository - операции с пользователями
 type UserRepository interface {
 	Create(ctx context.Context, user *entity.User) error
@@ -38,6 +97,21 @@ type UserRepository interface {
 	GetActiveByTeam(ctx context.Context, teamName string, excludeUserID string) ([]*entity.User, error)
 	SetActive(ctx context.Context, userID string, isActive bool) error
 	BulkDeactivate(ctx context.Context, userIDs []string) error
+	SetTags(ctx context.Context, userID string, tags []string) error
+	SetCapacity(ctx context.Context, userID string, maxOpenReviews *int, capacityPercent *int) error
+	SetSeniority(ctx context.Context, userID string, seniority entity.Seniority) error
+	SetSchedule(ctx context.Context, userID string, timezone string, hours []entity.WorkingHours) error
+}
+
+// AbsenceRepository - запланированные периоды отсутствия
+type AbsenceRepository interface {
+	Create(ctx context.Context, absence *entity.Absence) error
+	GetByUser(ctx context.Context, userID string) ([]*entity.Absence, error)
+	GetByIDForUpdate(ctx context.Context, id int64) (*entity.Absence, error)
+	GetDueToStart(ctx context.Context, now time.Time) ([]*entity.Absence, error)
+	GetDueToFinish(ctx context.Context, now time.Time) ([]*entity.Absence, error)
+	UpdateStatus(ctx context.Context, absence *entity.Absence) error
+	Delete(ctx context.Context, id int64) error
 }
 
 // PullRequestRepository - операции с PR
@@ -46,19 +120,37 @@ type PullRequestRepository interface {
 	Update(ctx context.Context, pr *entity.PullRequest) error
 	GetByID(ctx context.Context, prID string) (*entity.PullRequest, error)
 	GetByIDForUpdate(ctx context.Context, prID string) (*entity.PullRequest, error)
-	GetByReviewer(ctx context.Context, userID string) ([]*entity.PullRequest, error)
+	GetByReviewer(ctx context.Context, userID string, filter entity.ReviewFilter) ([]*entity.PullRequest, error)
 	GetOpenByReviewers(ctx context.Context, userIDs []string) ([]*entity.PullRequest, error)
+	GetAwaiting(ctx context.Context) ([]*entity.PullRequest, error)
+	LockAwaiting(ctx context.Context) ([]*entity.PullRequest, error)
+	GetUnderstaffed(ctx context.Context) ([]*entity.PullRequest, error)
+	LockUnderstaffed(ctx context.Context) ([]*entity.PullRequest, error)
 
 	// Работа с ревьюверами
-	AssignReviewers(ctx context.Context, prID string, userIDs []string) error
-	ReplaceReviewer(ctx context.Context, prID, oldUserID, newUserID string) error
+	AssignReviewers(ctx context.Context, prID string, userIDs []string, source entity.ReviewerSource) error
+	ReplaceReviewer(ctx context.Context, prID, oldUserID, newUserID string, source entity.ReviewerSource) error
+	RemoveReviewer(ctx context.Context, prID, userID string) error
+	GetOpenAssignments(ctx context.Context) ([]*entity.ReviewAssignment, error)
+	GetEscalationStep(ctx context.Context, prID, userID string) (int, error)
+	SetEscalationStep(ctx context.Context, prID, userID string, step int) error
+	SetVerdict(ctx context.Context, prID, userID string, verdict entity.Verdict) error
+	ResumeReviews(ctx context.Context, prID string, closedAt time.Time) error
+	SetLabels(ctx context.Context, prID string, labels []string) error
+	GetVerdicts(ctx context.Context, prID string) ([]*entity.ReviewVerdict, error)
 	IsReviewerAssigned(ctx context.Context, prID, userID string) (bool, error)
 }
 
 // StatsRepository - статистика и аналитика
 type StatsRepository interface {
+	GetOpenReviews(ctx context.Context, userIDs []string) (map[string]int, error)
 	GetWorkload(ctx context.Context, userIDs []string) (map[string]int, error)
 	IncrementAssignment(ctx context.Context, userID string) error
+	DecrementAssignment(ctx context.Context, userID string) error
+	GetLastAssigned(ctx context.Context, userIDs []string) (map[string]time.Time, error)
+	CountMergedSince(ctx context.Context, teamName string, since time.Time) (int, error)
+	GetPairCounts(ctx context.Context, authorID string, reviewerIDs []string, since time.Time) (map[string]int, error)
+	GetPairingMatrix(ctx context.Context, teamName string, since time.Time) ([]*entity.PairStat, error)
 	GetUserStats(ctx context.Context, userID string) (*entity.UserStats, error)
 	GetTeamStats(ctx context.Context) ([]*entity.TeamStats, error)
 }
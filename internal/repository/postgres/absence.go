@@ -0,0 +1,198 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"reviewer-service/internal/domain/entity"
+	"reviewer-service/internal/repository"
+
+	"github.com/lib/pq"
+)
+
+const absenceColumns = `
+            id,
+            user_id,
+            starts_at,
+            ends_at,
+            reason,
+            status,
+            deactivated,
+            created_at`
+
+type AbsenceRepository struct {
+	db Querier
+}
+
+func NewAbsenceRepository(db Querier) *AbsenceRepository {
+	return &AbsenceRepository{db: db}
+}
+
+func (r *AbsenceRepository) Create(ctx context.Context, absence *entity.Absence) error {
+	query := `
+        INSERT INTO user_absences (user_id, starts_at, ends_at, reason, status, created_at)
+        VALUES ($1, $2, $3, $4, $5, NOW())
+        RETURNING id, created_at
+    `
+
+	err := r.db.QueryRowContext(ctx, query,
+		absence.UserID,
+		absence.StartsAt,
+		absence.EndsAt,
+		absence.Reason,
+		absence.Status,
+	).Scan(&absence.ID, &absence.CreatedAt)
+
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok {
+			if pqErr.Code == "23503" { // foreign key violation
+				return repository.ErrUserNotFound
+			}
+			if pqErr.Code == "23514" { // check violation
+				return repository.ErrInvalidAbsence
+			}
+		}
+		return fmt.Errorf("insert absence: %w", err)
+	}
+
+	return nil
+}
+
+// GetByUser возвращает периоды отсутствия пользователя по времени начала
+func (r *AbsenceRepository) GetByUser(ctx context.Context, userID string) ([]*entity.Absence, error) {
+	query := `
+        SELECT ` + absenceColumns + `
+        FROM user_absences
+        WHERE user_id = $1
+        ORDER BY starts_at
+    `
+
+	return r.query(ctx, query, userID)
+}
+
+// GetByIDForUpdate возвращает период отсутствия с блокировкой
+func (r *AbsenceRepository) GetByIDForUpdate(ctx context.Context, id int64) (*entity.Absence, error) {
+	query := `
+        SELECT ` + absenceColumns + `
+        FROM user_absences
+        WHERE id = $1
+        FOR UPDATE
+    `
+
+	absences, err := r.query(ctx, query, id)
+	if err != nil {
+		return nil, err
+	}
+	if len(absences) == 0 {
+		return nil, repository.ErrNotFound
+	}
+
+	return absences[0], nil
+}
+
+// GetDueToStart возвращает запланированные периоды, которые уже начались.
+// Строки, заблокированные другим экземпляром планировщика, пропускаются.
+func (r *AbsenceRepository) GetDueToStart(ctx context.Context, now time.Time) ([]*entity.Absence, error) {
+	query := `
+        SELECT ` + absenceColumns + `
+        FROM user_absences
+        WHERE status = 'SCHEDULED' AND starts_at <= $1
+        ORDER BY starts_at
+        FOR UPDATE SKIP LOCKED
+    `
+
+	return r.query(ctx, query, now)
+}
+
+// GetDueToFinish возвращает активные (или пропущенные целиком) периоды, которые уже закончились
+func (r *AbsenceRepository) GetDueToFinish(ctx context.Context, now time.Time) ([]*entity.Absence, error) {
+	query := `
+        SELECT ` + absenceColumns + `
+        FROM user_absences
+        WHERE status IN ('SCHEDULED', 'ACTIVE') AND ends_at <= $1
+        ORDER BY ends_at
+        FOR UPDATE SKIP LOCKED
+    `
+
+	return r.query(ctx, query, now)
+}
+
+// UpdateStatus меняет состояние периода и отметку о выключении is_active
+func (r *AbsenceRepository) UpdateStatus(ctx context.Context, absence *entity.Absence) error {
+	query := `
+        UPDATE user_absences
+        SET status = $2, deactivated = $3
+        WHERE id = $1
+    `
+
+	result, err := r.db.ExecContext(ctx, query, absence.ID, absence.Status, absence.Deactivated)
+	if err != nil {
+		return fmt.Errorf("update absence: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rows == 0 {
+		return repository.ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *AbsenceRepository) Delete(ctx context.Context, id int64) error {
+	query := `DELETE FROM user_absences WHERE id = $1`
+
+	result, err := r.db.ExecContext(ctx, query, id)
+	if err != nil {
+		return fmt.Errorf("delete absence: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rows == 0 {
+		return repository.ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *AbsenceRepository) query(ctx context.Context, query string, args ...any) ([]*entity.Absence, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("query absences: %w", err)
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			// Log error or handle it appropriately
+			log.Printf("Error closing rows: %v", err)
+		}
+	}()
+
+	var absences []*entity.Absence
+	for rows.Next() {
+		var a entity.Absence
+		if err := rows.Scan(
+			&a.ID,
+			&a.UserID,
+			&a.StartsAt,
+			&a.EndsAt,
+			&a.Reason,
+			&a.Status,
+			&a.Deactivated,
+			&a.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("scan absence: %w", err)
+		}
+		absences = append(absences, &a)
+	}
+
+	return absences, rows.Err()
+}
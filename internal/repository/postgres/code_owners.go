@@ -0,0 +1,69 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"reviewer-service/internal/domain/entity"
+	"reviewer-service/internal/repository"
+
+	"github.com/lib/pq"
+)
+
+type CodeOwnersRepository struct {
+	db Querier
+}
+
+func NewCodeOwnersRepository(db Querier) *CodeOwnersRepository {
+	return &CodeOwnersRepository{db: db}
+}
+
+func (r *CodeOwnersRepository) GetByTeam(ctx context.Context, teamName string) (*entity.CodeOwnersFile, error) {
+	query := `
+        SELECT team_name, content, updated_at
+        FROM team_codeowners
+        WHERE team_name = $1
+    `
+
+	var file entity.CodeOwnersFile
+	err := r.db.QueryRowContext(ctx, query, teamName).Scan(
+		&file.TeamName,
+		&file.Content,
+		&file.UpdatedAt,
+	)
+
+	if err == sql.ErrNoRows {
+		return nil, repository.ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("query codeowners: %w", err)
+	}
+
+	return &file, nil
+}
+
+// Upsert сохраняет файл владения, заменяя предыдущую версию
+func (r *CodeOwnersRepository) Upsert(ctx context.Context, file *entity.CodeOwnersFile) error {
+	query := `
+        INSERT INTO team_codeowners (team_name, content, updated_at)
+        VALUES ($1, $2, NOW())
+        ON CONFLICT (team_name)
+        DO UPDATE SET
+            content = EXCLUDED.content,
+            updated_at = NOW()
+        RETURNING updated_at
+    `
+
+	err := r.db.QueryRowContext(ctx, query, file.TeamName, file.Content).Scan(&file.UpdatedAt)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok {
+			if pqErr.Code == "23503" { // foreign key violation
+				return repository.ErrNotFound
+			}
+		}
+		return fmt.Errorf("upsert codeowners: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,120 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"reviewer-service/internal/domain/entity"
+	"reviewer-service/internal/repository"
+
+	"github.com/lib/pq"
+)
+
+type DeclineRepository struct {
+	db Querier
+}
+
+func NewDeclineRepository(db Querier) *DeclineRepository {
+	return &DeclineRepository{db: db}
+}
+
+func (r *DeclineRepository) Create(ctx context.Context, decline *entity.ReviewDecline) error {
+	query := `
+        INSERT INTO review_declines (pull_request_id, user_id, reason, comment, replaced_by, created_at)
+        VALUES ($1, $2, $3, $4, NULLIF($5, ''), NOW())
+        RETURNING id, created_at
+    `
+
+	err := r.db.QueryRowContext(ctx, query,
+		decline.PullRequestID,
+		decline.UserID,
+		decline.Reason,
+		decline.Comment,
+		decline.ReplacedBy,
+	).Scan(&decline.ID, &decline.CreatedAt)
+
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok {
+			if pqErr.Code == "23503" { // foreign key violation
+				return repository.ErrNotFound
+			}
+			if pqErr.Code == "23514" { // check violation
+				return repository.ErrInvalidDeclineReason
+			}
+		}
+		return fmt.Errorf("insert decline: %w", err)
+	}
+
+	return nil
+}
+
+// GetByPR возвращает отказы по PR в порядке появления
+func (r *DeclineRepository) GetByPR(ctx context.Context, prID string) ([]*entity.ReviewDecline, error) {
+	query := `
+        SELECT id, pull_request_id, user_id, reason, comment, COALESCE(replaced_by, ''), created_at
+        FROM review_declines
+        WHERE pull_request_id = $1
+        ORDER BY created_at, id
+    `
+
+	rows, err := r.db.QueryContext(ctx, query, prID)
+	if err != nil {
+		return nil, fmt.Errorf("query declines: %w", err)
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			log.Printf("Error closing rows: %v", err)
+		}
+	}()
+
+	declines := []*entity.ReviewDecline{}
+	for rows.Next() {
+		var d entity.ReviewDecline
+		if err := rows.Scan(
+			&d.ID,
+			&d.PullRequestID,
+			&d.UserID,
+			&d.Reason,
+			&d.Comment,
+			&d.ReplacedBy,
+			&d.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("scan decline: %w", err)
+		}
+		declines = append(declines, &d)
+	}
+
+	return declines, rows.Err()
+}
+
+// GetDecliners возвращает пользователей, отказавшихся от ревью PR
+func (r *DeclineRepository) GetDecliners(ctx context.Context, prID string) ([]string, error) {
+	query := `
+        SELECT DISTINCT user_id
+        FROM review_declines
+        WHERE pull_request_id = $1
+        ORDER BY user_id
+    `
+
+	rows, err := r.db.QueryContext(ctx, query, prID)
+	if err != nil {
+		return nil, fmt.Errorf("query decliners: %w", err)
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			log.Printf("Error closing rows: %v", err)
+		}
+	}()
+
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("scan decliner: %w", err)
+		}
+		ids = append(ids, id)
+	}
+
+	return ids, rows.Err()
+}
@@ -0,0 +1,80 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"reviewer-service/internal/domain/entity"
+)
+
+type EscalationRepository struct {
+	db Querier
+}
+
+func NewEscalationRepository(db Querier) *EscalationRepository {
+	return &EscalationRepository{db: db}
+}
+
+func (r *EscalationRepository) Create(ctx context.Context, escalation *entity.ReviewEscalation) error {
+	query := `
+        INSERT INTO review_escalations (pull_request_id, user_id, action, step, recipient_id, replaced_by, created_at)
+        VALUES ($1, $2, $3, $4, $5, $6, NOW())
+        RETURNING id, created_at
+    `
+
+	err := r.db.QueryRowContext(ctx, query,
+		escalation.PullRequestID,
+		escalation.UserID,
+		escalation.Action,
+		escalation.Step,
+		escalation.RecipientID,
+		escalation.ReplacedBy,
+	).Scan(&escalation.ID, &escalation.CreatedAt)
+
+	if err != nil {
+		return fmt.Errorf("insert escalation: %w", err)
+	}
+
+	return nil
+}
+
+// GetByPR возвращает эскалации по PR в порядке выполнения
+func (r *EscalationRepository) GetByPR(ctx context.Context, prID string) ([]*entity.ReviewEscalation, error) {
+	query := `
+        SELECT id, pull_request_id, user_id, action, step, recipient_id, replaced_by, created_at
+        FROM review_escalations
+        WHERE pull_request_id = $1
+        ORDER BY created_at, id
+    `
+
+	rows, err := r.db.QueryContext(ctx, query, prID)
+	if err != nil {
+		return nil, fmt.Errorf("query escalations: %w", err)
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			log.Printf("Error closing rows: %v", err)
+		}
+	}()
+
+	escalations := []*entity.ReviewEscalation{}
+	for rows.Next() {
+		var e entity.ReviewEscalation
+		if err := rows.Scan(
+			&e.ID,
+			&e.PullRequestID,
+			&e.UserID,
+			&e.Action,
+			&e.Step,
+			&e.RecipientID,
+			&e.ReplacedBy,
+			&e.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("scan escalation: %w", err)
+		}
+		escalations = append(escalations, &e)
+	}
+
+	return escalations, rows.Err()
+}
@@ -0,0 +1,143 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"reviewer-service/internal/domain/entity"
+	"reviewer-service/internal/repository"
+
+	"github.com/lib/pq"
+)
+
+const exclusionColumns = `
+            id,
+            author_id,
+            reviewer_id,
+            both_ways,
+            reason,
+            expires_at,
+            created_at`
+
+type ExclusionRepository struct {
+	db Querier
+}
+
+func NewExclusionRepository(db Querier) *ExclusionRepository {
+	return &ExclusionRepository{db: db}
+}
+
+func (r *ExclusionRepository) Create(ctx context.Context, exclusion *entity.ReviewExclusion) error {
+	query := `
+        INSERT INTO review_exclusions (author_id, reviewer_id, both_ways, reason, expires_at, created_at)
+        VALUES ($1, $2, $3, $4, $5, NOW())
+        RETURNING id, created_at
+    `
+
+	err := r.db.QueryRowContext(ctx, query,
+		exclusion.AuthorID,
+		exclusion.ReviewerID,
+		exclusion.BothWays,
+		exclusion.Reason,
+		exclusion.ExpiresAt,
+	).Scan(&exclusion.ID, &exclusion.CreatedAt)
+
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok {
+			if pqErr.Code == "23505" { // unique violation
+				return repository.ErrExclusionExists
+			}
+			if pqErr.Code == "23503" { // foreign key violation
+				return repository.ErrUserNotFound
+			}
+			if pqErr.Code == "23514" { // check violation
+				return repository.ErrInvalidExclusion
+			}
+		}
+		return fmt.Errorf("insert exclusion: %w", err)
+	}
+
+	return nil
+}
+
+// GetByUser возвращает все правила, в которых участвует пользователь
+func (r *ExclusionRepository) GetByUser(ctx context.Context, userID string) ([]*entity.ReviewExclusion, error) {
+	query := `
+        SELECT ` + exclusionColumns + `
+        FROM review_exclusions
+        WHERE author_id = $1 OR reviewer_id = $1
+        ORDER BY created_at, id
+    `
+
+	return r.query(ctx, query, userID)
+}
+
+// GetActiveForAuthor возвращает действующие правила, которые могут
+// ограничить ревьюверов PR автора
+func (r *ExclusionRepository) GetActiveForAuthor(
+	ctx context.Context,
+	authorID string,
+	now time.Time,
+) ([]*entity.ReviewExclusion, error) {
+	query := `
+        SELECT ` + exclusionColumns + `
+        FROM review_exclusions
+        WHERE (author_id = $1 OR (both_ways AND reviewer_id = $1))
+          AND (expires_at IS NULL OR expires_at > $2)
+    `
+
+	return r.query(ctx, query, authorID, now)
+}
+
+func (r *ExclusionRepository) Delete(ctx context.Context, id int64) error {
+	query := `DELETE FROM review_exclusions WHERE id = $1`
+
+	result, err := r.db.ExecContext(ctx, query, id)
+	if err != nil {
+		return fmt.Errorf("delete exclusion: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rows == 0 {
+		return repository.ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *ExclusionRepository) query(ctx context.Context, query string, args ...any) ([]*entity.ReviewExclusion, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("query exclusions: %w", err)
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			log.Printf("Error closing rows: %v", err)
+		}
+	}()
+
+	exclusions := []*entity.ReviewExclusion{}
+	for rows.Next() {
+		var e entity.ReviewExclusion
+		if err := rows.Scan(
+			&e.ID,
+			&e.AuthorID,
+			&e.ReviewerID,
+			&e.BothWays,
+			&e.Reason,
+			&e.ExpiresAt,
+			&e.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("scan exclusion: %w", err)
+		}
+		exclusions = append(exclusions, &e)
+	}
+
+	return exclusions, rows.Err()
+}
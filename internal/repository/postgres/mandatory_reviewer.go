@@ -0,0 +1,99 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"reviewer-service/internal/domain/entity"
+	"reviewer-service/internal/repository"
+
+	"github.com/lib/pq"
+)
+
+type MandatoryReviewerRepository struct {
+	db Querier
+}
+
+func NewMandatoryReviewerRepository(db Querier) *MandatoryReviewerRepository {
+	return &MandatoryReviewerRepository{db: db}
+}
+
+// GetByTeam возвращает обязательных ревьюверов команды в порядке добавления
+func (r *MandatoryReviewerRepository) GetByTeam(ctx context.Context, teamName string) ([]*entity.MandatoryReviewer, error) {
+	query := `
+        SELECT team_name, user_id, labels, created_at
+        FROM team_mandatory_reviewers
+        WHERE team_name = $1
+        ORDER BY created_at, user_id
+    `
+
+	rows, err := r.db.QueryContext(ctx, query, teamName)
+	if err != nil {
+		return nil, fmt.Errorf("query mandatory reviewers: %w", err)
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			log.Printf("Error closing rows: %v", err)
+		}
+	}()
+
+	reviewers := []*entity.MandatoryReviewer{}
+	for rows.Next() {
+		var m entity.MandatoryReviewer
+		if err := rows.Scan(&m.TeamName, &m.UserID, pq.Array(&m.Labels), &m.CreatedAt); err != nil {
+			return nil, fmt.Errorf("scan mandatory reviewer: %w", err)
+		}
+		reviewers = append(reviewers, &m)
+	}
+
+	return reviewers, rows.Err()
+}
+
+// Upsert добавляет обязательного ревьювера или заменяет его метки
+func (r *MandatoryReviewerRepository) Upsert(ctx context.Context, reviewer *entity.MandatoryReviewer) error {
+	query := `
+        INSERT INTO team_mandatory_reviewers (team_name, user_id, labels, created_at)
+        VALUES ($1, $2, $3, NOW())
+        ON CONFLICT (team_name, user_id)
+        DO UPDATE SET labels = EXCLUDED.labels
+        RETURNING created_at
+    `
+
+	err := r.db.QueryRowContext(ctx, query,
+		reviewer.TeamName,
+		reviewer.UserID,
+		pq.Array(reviewer.Labels),
+	).Scan(&reviewer.CreatedAt)
+
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok {
+			if pqErr.Code == "23503" { // foreign key violation
+				return repository.ErrNotFound
+			}
+		}
+		return fmt.Errorf("upsert mandatory reviewer: %w", err)
+	}
+
+	return nil
+}
+
+func (r *MandatoryReviewerRepository) Delete(ctx context.Context, teamName, userID string) error {
+	query := `DELETE FROM team_mandatory_reviewers WHERE team_name = $1 AND user_id = $2`
+
+	result, err := r.db.ExecContext(ctx, query, teamName, userID)
+	if err != nil {
+		return fmt.Errorf("delete mandatory reviewer: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rows == 0 {
+		return repository.ErrNotFound
+	}
+
+	return nil
+}
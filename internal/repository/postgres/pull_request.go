@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"log"
 	"strings"
+	"time"
 
 	"reviewer-service/internal/domain/entity"
 	"reviewer-service/internal/repository"
@@ -28,18 +29,41 @@ func (r *PullRequestRepository) Create(ctx context.Context, pr *entity.PullReque
             pull_request_name,
             author_id,
             status,
+            lines_added,
+            lines_removed,
+            files_changed,
+            required_reviewers,
+            ready_at,
+            description,
+            url,
+            repository,
+            source_branch,
+            target_branch,
+            number,
             created_at,
             version
         )
-        VALUES ($1, $2, $3, $4, NOW(), 1)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, NULLIF($15, 0), NOW(), 1)
+        RETURNING created_at, version
     `
 
-	_, err := r.db.ExecContext(ctx, query,
+	err := r.db.QueryRowContext(ctx, query,
 		pr.ID,
 		pr.Name,
 		pr.AuthorID,
 		pr.Status,
-	)
+		pr.LinesAdded,
+		pr.LinesRemoved,
+		pr.FilesChanged,
+		pr.RequiredReviewers,
+		pr.ReadyAt,
+		pr.Description,
+		pr.URL,
+		pr.Repository,
+		pr.SourceBranch,
+		pr.TargetBranch,
+		pr.Number,
+	).Scan(&pr.CreatedAt, &pr.Version)
 
 	if err != nil {
 		if pqErr, ok := err.(*pq.Error); ok {
@@ -49,10 +73,37 @@ func (r *PullRequestRepository) Create(ctx context.Context, pr *entity.PullReque
 			if pqErr.Code == "23503" { // foreign key violation
 				return repository.ErrUserNotFound
 			}
+			if pqErr.Code == "23514" { // check violation
+				return repository.ErrInvalidPRSize
+			}
 		}
 		return fmt.Errorf("insert pr: %w", err)
 	}
 
+	if len(pr.ChangedFiles) > 0 {
+		filesQuery := `
+            INSERT INTO pr_files (pull_request_id, path)
+            SELECT $1, unnest($2::text[])
+            ON CONFLICT DO NOTHING
+        `
+
+		if _, err := r.db.ExecContext(ctx, filesQuery, pr.ID, pq.Array(pr.ChangedFiles)); err != nil {
+			return fmt.Errorf("insert pr files: %w", err)
+		}
+	}
+
+	if len(pr.Labels) > 0 {
+		labelsQuery := `
+            INSERT INTO pr_labels (pull_request_id, label)
+            SELECT $1, unnest($2::text[])
+            ON CONFLICT DO NOTHING
+        `
+
+		if _, err := r.db.ExecContext(ctx, labelsQuery, pr.ID, pq.Array(pr.Labels)); err != nil {
+			return fmt.Errorf("insert pr labels: %w", err)
+		}
+	}
+
 	return nil
 }
 
@@ -63,6 +114,15 @@ func (r *PullRequestRepository) Update(ctx context.Context, pr *entity.PullReque
             pull_request_name = $2,
             status = $3,
             merged_at = $4,
+            required_reviewers = $6,
+            merge_override = $7,
+            closed_at = $8,
+            ready_at = $9,
+            description = $10,
+            url = $11,
+            repository = $12,
+            source_branch = $13,
+            target_branch = $14,
             version = version + 1
         WHERE pull_request_id = $1 AND version = $5
     `
@@ -73,6 +133,15 @@ func (r *PullRequestRepository) Update(ctx context.Context, pr *entity.PullReque
 		pr.Status,
 		pr.MergedAt,
 		pr.Version,
+		pr.RequiredReviewers,
+		pr.MergeOverride,
+		pr.ClosedAt,
+		pr.ReadyAt,
+		pr.Description,
+		pr.URL,
+		pr.Repository,
+		pr.SourceBranch,
+		pr.TargetBranch,
 	)
 
 	if err != nil {
@@ -92,8 +161,9 @@ func (r *PullRequestRepository) Update(ctx context.Context, pr *entity.PullReque
 	return nil
 }
 
-func (r *PullRequestRepository) GetByID(ctx context.Context, prID string) (*entity.PullRequest, error) {
-	query := `
+// selectPRColumns выбирает PR вместе с ревьюверами и изменёнными файлами;
+// запрос дополняется условием WHERE и группировкой по PR
+const selectPRColumns = `
         SELECT
             pr.pull_request_id,
             pr.pull_request_name,
@@ -102,40 +172,63 @@ func (r *PullRequestRepository) GetByID(ctx context.Context, prID string) (*enti
             pr.created_at,
             pr.merged_at,
             pr.version,
+            pr.lines_added,
+            pr.lines_removed,
+            pr.files_changed,
+            pr.required_reviewers,
+            pr.merge_override,
+            pr.closed_at,
+            pr.ready_at,
+            pr.description,
+            pr.url,
+            pr.repository,
+            pr.source_branch,
+            pr.target_branch,
+            COALESCE(pr.number, 0),
             COALESCE(
                 array_agg(r.user_id ORDER BY r.assigned_at)
                 FILTER (WHERE r.user_id IS NOT NULL),
                 '{}'
-            ) as reviewer_ids
+            ) as reviewer_ids,
+            COALESCE(
+                array_agg(r.user_id ORDER BY r.assigned_at)
+                FILTER (WHERE r.source = 'FALLBACK'),
+                '{}'
+            ) as fallback_ids,
+            COALESCE(
+                array_agg(r.user_id ORDER BY r.assigned_at)
+                FILTER (WHERE r.source = 'MANDATORY'),
+                '{}'
+            ) as mandatory_ids,
+            COALESCE(
+                (SELECT array_agg(f.path ORDER BY f.path)
+                 FROM pr_files f
+                 WHERE f.pull_request_id = pr.pull_request_id),
+                '{}'
+            ) as changed_files,
+            COALESCE(
+                (SELECT array_agg(l.label ORDER BY l.label)
+                 FROM pr_labels l
+                 WHERE l.pull_request_id = pr.pull_request_id),
+                '{}'
+            ) as labels
         FROM pull_requests pr
-        LEFT JOIN pr_reviewers r ON pr.pull_request_id = r.pull_request_id
+        LEFT JOIN pr_reviewers r ON pr.pull_request_id = r.pull_request_id`
+
+// selectPRQuery выбирает один PR
+const selectPRQuery = selectPRColumns + `
         WHERE pr.pull_request_id = $1
         GROUP BY pr.pull_request_id
     `
 
-	var pr entity.PullRequest
-	var reviewerIDs []string
-
-	err := r.db.QueryRowContext(ctx, query, prID).Scan(
-		&pr.ID,
-		&pr.Name,
-		&pr.AuthorID,
-		&pr.Status,
-		&pr.CreatedAt,
-		&pr.MergedAt,
-		&pr.Version,
-		pq.Array(&reviewerIDs),
-	)
-
-	if err == sql.ErrNoRows {
-		return nil, repository.ErrNotFound
-	}
-	if err != nil {
-		return nil, fmt.Errorf("query pr: %w", err)
-	}
+// selectPRsQuery выбирает несколько PR одним запросом
+const selectPRsQuery = selectPRColumns + `
+        WHERE pr.pull_request_id = ANY($1)
+        GROUP BY pr.pull_request_id
+    `
 
-	pr.AssignedReviewers = reviewerIDs
-	return &pr, nil
+func (r *PullRequestRepository) GetByID(ctx context.Context, prID string) (*entity.PullRequest, error) {
+	return r.get(ctx, prID)
 }
 
 func (r *PullRequestRepository) GetByIDForUpdate(ctx context.Context, prID string) (*entity.PullRequest, error) {
@@ -156,30 +249,30 @@ func (r *PullRequestRepository) GetByIDForUpdate(ctx context.Context, prID strin
 	}
 
 	// Затем получаем полную информацию о PR
-	query := `
-        SELECT
-            pr.pull_request_id,
-            pr.pull_request_name,
-            pr.author_id,
-            pr.status,
-            pr.created_at,
-            pr.merged_at,
-            pr.version,
-            COALESCE(
-                array_agg(r.user_id ORDER BY r.assigned_at)
-                FILTER (WHERE r.user_id IS NOT NULL),
-                '{}'
-            ) as reviewer_ids
-        FROM pull_requests pr
-        LEFT JOIN pr_reviewers r ON pr.pull_request_id = r.pull_request_id
-        WHERE pr.pull_request_id = $1
-        GROUP BY pr.pull_request_id
-    `
+	return r.get(ctx, prID)
+}
 
+func (r *PullRequestRepository) get(ctx context.Context, prID string) (*entity.PullRequest, error) {
+	pr, err := scanPR(r.db.QueryRowContext(ctx, selectPRQuery, prID))
+	if err == sql.ErrNoRows {
+		return nil, repository.ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("query pr: %w", err)
+	}
+	return pr, nil
+}
+
+// scanPR читает строку selectPRColumns
+func scanPR(row interface{ Scan(dest ...any) error }) (*entity.PullRequest, error) {
 	var pr entity.PullRequest
 	var reviewerIDs []string
+	var fallbackIDs []string
+	var mandatoryIDs []string
+	var changedFiles []string
+	var labels []string
 
-	err = r.db.QueryRowContext(ctx, query, prID).Scan(
+	err := row.Scan(
 		&pr.ID,
 		&pr.Name,
 		&pr.AuthorID,
@@ -187,74 +280,80 @@ func (r *PullRequestRepository) GetByIDForUpdate(ctx context.Context, prID strin
 		&pr.CreatedAt,
 		&pr.MergedAt,
 		&pr.Version,
+		&pr.LinesAdded,
+		&pr.LinesRemoved,
+		&pr.FilesChanged,
+		&pr.RequiredReviewers,
+		&pr.MergeOverride,
+		&pr.ClosedAt,
+		&pr.ReadyAt,
+		&pr.Description,
+		&pr.URL,
+		&pr.Repository,
+		&pr.SourceBranch,
+		&pr.TargetBranch,
+		&pr.Number,
 		pq.Array(&reviewerIDs),
+		pq.Array(&fallbackIDs),
+		pq.Array(&mandatoryIDs),
+		pq.Array(&changedFiles),
+		pq.Array(&labels),
 	)
-
-	if err == sql.ErrNoRows {
-		return nil, repository.ErrNotFound
-	}
 	if err != nil {
-		return nil, fmt.Errorf("query pr: %w", err)
+		return nil, err
 	}
 
 	pr.AssignedReviewers = reviewerIDs
+	pr.FallbackReviewers = fallbackIDs
+	pr.MandatoryReviewers = mandatoryIDs
+	pr.ChangedFiles = changedFiles
+	pr.Labels = labels
 	return &pr, nil
 }
 
-func (r *PullRequestRepository) GetByReviewer(ctx context.Context, userID string) ([]*entity.PullRequest, error) {
+// GetByReviewer возвращает PR, на которые назначен ревьювер, от новых к старым.
+// Пустые поля filter не фильтруют.
+func (r *PullRequestRepository) GetByReviewer(
+	ctx context.Context,
+	userID string,
+	filter entity.ReviewFilter,
+) ([]*entity.PullRequest, error) {
 	query := `
-        SELECT DISTINCT
-            pr.pull_request_id,
-            pr.pull_request_name,
-            pr.author_id,
-            pr.status,
-            pr.created_at,
-            pr.merged_at,
-            pr.version
+        SELECT pr.pull_request_id
         FROM pull_requests pr
         INNER JOIN pr_reviewers r ON pr.pull_request_id = r.pull_request_id
         WHERE r.user_id = $1
-        ORDER BY pr.created_at DESC
+          AND ($2::text = '' OR pr.status = $2)
+          AND ($3::text = '' OR pr.repository = $3)
+          AND ($4::text = '' OR pr.source_branch = $4)
+          AND ($5::text = '' OR pr.target_branch = $5)
+          AND ($6::text = '' OR EXISTS (
+              SELECT 1 FROM pr_labels l
+              WHERE l.pull_request_id = pr.pull_request_id AND l.label = $6
+          ))
+        ORDER BY pr.created_at DESC, pr.pull_request_id
     `
 
-	rows, err := r.db.QueryContext(ctx, query, userID)
-	if err != nil {
-		return nil, fmt.Errorf("query prs by reviewer: %w", err)
-	}
-	defer func() {
-		if err := rows.Close(); err != nil {
-			// Log error or handle it appropriately
-			log.Printf("Error closing rows: %v", err)
-		}
-	}()
-
-	var prs []*entity.PullRequest
-	for rows.Next() {
-		var pr entity.PullRequest
-		if err := rows.Scan(
-			&pr.ID,
-			&pr.Name,
-			&pr.AuthorID,
-			&pr.Status,
-			&pr.CreatedAt,
-			&pr.MergedAt,
-			&pr.Version,
-		); err != nil {
-			return nil, fmt.Errorf("scan pr: %w", err)
-		}
-		prs = append(prs, &pr)
-	}
-
-	return prs, rows.Err()
+	return r.getByQuery(ctx, query,
+		userID,
+		string(filter.Status),
+		filter.Repository,
+		filter.SourceBranch,
+		filter.TargetBranch,
+		filter.Label,
+	)
 }
 
+// GetOpenByReviewers возвращает PR с незавершённым ревью (OPEN и
+// AWAITING_REVIEWERS), где ревьюит кто-то из пользователей, от старых к новым -
+// в том же порядке, в каком PR блокируют очередь и добор
 func (r *PullRequestRepository) GetOpenByReviewers(ctx context.Context, userIDs []string) ([]*entity.PullRequest, error) {
 	if len(userIDs) == 0 {
 		return []*entity.PullRequest{}, nil
 	}
 
 	query := `
-        SELECT DISTINCT
+        SELECT
             pr.pull_request_id,
             pr.pull_request_name,
             pr.author_id,
@@ -264,8 +363,9 @@ func (r *PullRequestRepository) GetOpenByReviewers(ctx context.Context, userIDs
             array_agg(r.user_id) as reviewer_ids
         FROM pull_requests pr
         INNER JOIN pr_reviewers r ON pr.pull_request_id = r.pull_request_id
-        WHERE pr.status = 'OPEN' AND r.user_id = ANY($1)
+        WHERE pr.status IN ('OPEN', 'AWAITING_REVIEWERS') AND r.user_id = ANY($1)
         GROUP BY pr.pull_request_id
+        ORDER BY pr.created_at, pr.pull_request_id
     `
 
 	rows, err := r.db.QueryContext(ctx, query, pq.Array(userIDs))
@@ -303,22 +403,155 @@ func (r *PullRequestRepository) GetOpenByReviewers(ctx context.Context, userIDs
 	return prs, rows.Err()
 }
 
-func (r *PullRequestRepository) AssignReviewers(ctx context.Context, prID string, userIDs []string) error {
+// GetAwaiting возвращает PR, ожидающие ревьюверов, в порядке очереди
+func (r *PullRequestRepository) GetAwaiting(ctx context.Context) ([]*entity.PullRequest, error) {
+	query := `
+        SELECT pull_request_id
+        FROM pull_requests
+        WHERE status = 'AWAITING_REVIEWERS'
+        ORDER BY created_at, pull_request_id
+    `
+
+	return r.getByQuery(ctx, query)
+}
+
+// LockAwaiting блокирует PR, ожидающие ревьюверов, в порядке очереди и
+// возвращает их. PR, заблокированные другой транзакцией, пропускаются.
+func (r *PullRequestRepository) LockAwaiting(ctx context.Context) ([]*entity.PullRequest, error) {
+	query := `
+        SELECT pull_request_id
+        FROM pull_requests
+        WHERE status = 'AWAITING_REVIEWERS'
+        ORDER BY created_at, pull_request_id
+        FOR UPDATE SKIP LOCKED
+    `
+
+	return r.getByQuery(ctx, query)
+}
+
+// GetUnderstaffed возвращает открытые PR, у которых ревьюверов меньше целевого числа,
+// от старых к новым
+func (r *PullRequestRepository) GetUnderstaffed(ctx context.Context) ([]*entity.PullRequest, error) {
+	query := `
+        SELECT pr.pull_request_id
+        FROM pull_requests pr
+        WHERE pr.status = 'OPEN'
+          AND pr.required_reviewers > (
+              SELECT COUNT(*) FROM pr_reviewers r
+              WHERE r.pull_request_id = pr.pull_request_id
+          )
+        ORDER BY pr.created_at, pr.pull_request_id
+    `
+
+	return r.getByQuery(ctx, query)
+}
+
+// LockUnderstaffed блокирует открытые PR, у которых ревьюверов меньше целевого
+// числа, от старых к новым и возвращает их. PR, заблокированные другой
+// транзакцией, пропускаются.
+func (r *PullRequestRepository) LockUnderstaffed(ctx context.Context) ([]*entity.PullRequest, error) {
+	query := `
+        SELECT pr.pull_request_id
+        FROM pull_requests pr
+        WHERE pr.status = 'OPEN'
+          AND pr.required_reviewers > (
+              SELECT COUNT(*) FROM pr_reviewers r
+              WHERE r.pull_request_id = pr.pull_request_id
+          )
+        ORDER BY pr.created_at, pr.pull_request_id
+        FOR UPDATE SKIP LOCKED
+    `
+
+	return r.getByQuery(ctx, query)
+}
+
+// getByQuery загружает PR по идентификаторам, которые вернул query
+func (r *PullRequestRepository) getByQuery(ctx context.Context, query string, args ...any) ([]*entity.PullRequest, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("query pr ids: %w", err)
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			// Log error or handle it appropriately
+			log.Printf("Error closing rows: %v", err)
+		}
+	}()
+
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("scan pr id: %w", err)
+		}
+		ids = append(ids, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return r.getMany(ctx, ids)
+}
+
+// getMany загружает PR одним запросом в порядке ids
+func (r *PullRequestRepository) getMany(ctx context.Context, ids []string) ([]*entity.PullRequest, error) {
+	if len(ids) == 0 {
+		return []*entity.PullRequest{}, nil
+	}
+
+	rows, err := r.db.QueryContext(ctx, selectPRsQuery, pq.Array(ids))
+	if err != nil {
+		return nil, fmt.Errorf("query prs: %w", err)
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			log.Printf("Error closing rows: %v", err)
+		}
+	}()
+
+	byID := make(map[string]*entity.PullRequest, len(ids))
+	for rows.Next() {
+		pr, err := scanPR(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan pr: %w", err)
+		}
+		byID[pr.ID] = pr
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	prs := make([]*entity.PullRequest, 0, len(ids))
+	for _, id := range ids {
+		if pr, ok := byID[id]; ok {
+			prs = append(prs, pr)
+		}
+	}
+
+	return prs, nil
+}
+
+func (r *PullRequestRepository) AssignReviewers(
+	ctx context.Context,
+	prID string,
+	userIDs []string,
+	source entity.ReviewerSource,
+) error {
 	if len(userIDs) == 0 {
 		return nil
 	}
 
 	// Используем многострочный INSERT для производительности
 	valueStrings := make([]string, 0, len(userIDs))
-	valueArgs := make([]any, 0, len(userIDs)*2)
+	valueArgs := make([]any, 0, len(userIDs)*3)
 
 	for i, userID := range userIDs {
-		valueStrings = append(valueStrings, fmt.Sprintf("($%d, $%d, NOW())", i*2+1, i*2+2))
-		valueArgs = append(valueArgs, prID, userID)
+		valueStrings = append(valueStrings, fmt.Sprintf("($%d, $%d, $%d, NOW())", i*3+1, i*3+2, i*3+3))
+		valueArgs = append(valueArgs, prID, userID, source)
 	}
 
 	query := fmt.Sprintf(`
-        INSERT INTO pr_reviewers (pull_request_id, user_id, assigned_at)
+        INSERT INTO pr_reviewers (pull_request_id, user_id, source, assigned_at)
         VALUES %s
         ON CONFLICT (pull_request_id, user_id) DO NOTHING
     `, strings.Join(valueStrings, ","))
@@ -331,7 +564,11 @@ func (r *PullRequestRepository) AssignReviewers(ctx context.Context, prID string
 	return nil
 }
 
-func (r *PullRequestRepository) ReplaceReviewer(ctx context.Context, prID, oldUserID, newUserID string) error {
+func (r *PullRequestRepository) ReplaceReviewer(
+	ctx context.Context,
+	prID, oldUserID, newUserID string,
+	source entity.ReviewerSource,
+) error {
 	// Проверяем, назначен ли уже новый ревьюер
 	isAssigned, err := r.IsReviewerAssigned(ctx, prID, newUserID)
 	if err != nil {
@@ -369,12 +606,12 @@ func (r *PullRequestRepository) ReplaceReviewer(ctx context.Context, prID, oldUs
             WHERE pull_request_id = $1 AND user_id = $2
             RETURNING pull_request_id
         )
-        INSERT INTO pr_reviewers (pull_request_id, user_id, assigned_at)
-        SELECT $1, $3, NOW()
+        INSERT INTO pr_reviewers (pull_request_id, user_id, source, assigned_at)
+        SELECT $1, $3, $4, NOW()
         WHERE EXISTS (SELECT 1 FROM deleted)
     `
 
-	result, err := r.db.ExecContext(ctx, query, prID, oldUserID, newUserID)
+	result, err := r.db.ExecContext(ctx, query, prID, oldUserID, newUserID, source)
 	if err != nil {
 		if pqErr, ok := err.(*pq.Error); ok {
 			if pqErr.Code == "23503" { // foreign key violation
@@ -396,6 +633,216 @@ func (r *PullRequestRepository) ReplaceReviewer(ctx context.Context, prID, oldUs
 	return nil
 }
 
+// RemoveReviewer снимает ревьювера с PR
+func (r *PullRequestRepository) RemoveReviewer(ctx context.Context, prID, userID string) error {
+	query := `
+        DELETE FROM pr_reviewers
+        WHERE pull_request_id = $1 AND user_id = $2
+    `
+
+	result, err := r.db.ExecContext(ctx, query, prID, userID)
+	if err != nil {
+		return fmt.Errorf("remove reviewer: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rows == 0 {
+		return repository.ErrNotAssigned
+	}
+
+	return nil
+}
+
+// GetOpenAssignments возвращает назначения ревьюверов на открытые PR,
+// по которым ещё нет вердикта, вместе с командой, которая ревьюит PR:
+// основной командой-владельцем репозитория или командой автора, от старых к новым
+func (r *PullRequestRepository) GetOpenAssignments(ctx context.Context) ([]*entity.ReviewAssignment, error) {
+	query := `
+        SELECT
+            r.pull_request_id,
+            r.user_id,
+            COALESCE(
+                (SELECT rt.team_name FROM repository_teams rt
+                 WHERE rt.repository = pr.repository
+                 ORDER BY rt.position
+                 LIMIT 1),
+                au.team_name
+            ) as team_name,
+            r.assigned_at + r.paused as sla_start,
+            r.escalation_step
+        FROM pr_reviewers r
+        INNER JOIN pull_requests pr ON r.pull_request_id = pr.pull_request_id
+        INNER JOIN users au ON pr.author_id = au.user_id
+        WHERE pr.status = 'OPEN' AND r.verdict IS NULL
+        ORDER BY sla_start, r.pull_request_id, r.user_id
+    `
+
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("query open assignments: %w", err)
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			log.Printf("Error closing rows: %v", err)
+		}
+	}()
+
+	var assignments []*entity.ReviewAssignment
+	for rows.Next() {
+		var a entity.ReviewAssignment
+		if err := rows.Scan(&a.PullRequestID, &a.UserID, &a.TeamName, &a.SLAStart, &a.EscalationStep); err != nil {
+			return nil, fmt.Errorf("scan assignment: %w", err)
+		}
+		assignments = append(assignments, &a)
+	}
+
+	return assignments, rows.Err()
+}
+
+// GetEscalationStep возвращает, сколько шагов эскалации сделано по назначению
+func (r *PullRequestRepository) GetEscalationStep(ctx context.Context, prID, userID string) (int, error) {
+	query := `
+        SELECT escalation_step
+        FROM pr_reviewers
+        WHERE pull_request_id = $1 AND user_id = $2
+    `
+
+	var step int
+	err := r.db.QueryRowContext(ctx, query, prID, userID).Scan(&step)
+	if err == sql.ErrNoRows {
+		return 0, repository.ErrNotAssigned
+	}
+	if err != nil {
+		return 0, fmt.Errorf("get escalation step: %w", err)
+	}
+
+	return step, nil
+}
+
+// SetEscalationStep запоминает, сколько шагов эскалации сделано по назначению
+func (r *PullRequestRepository) SetEscalationStep(ctx context.Context, prID, userID string, step int) error {
+	query := `
+        UPDATE pr_reviewers
+        SET escalation_step = $3
+        WHERE pull_request_id = $1 AND user_id = $2
+    `
+
+	result, err := r.db.ExecContext(ctx, query, prID, userID, step)
+	if err != nil {
+		return fmt.Errorf("set escalation step: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rows == 0 {
+		return repository.ErrNotAssigned
+	}
+
+	return nil
+}
+
+// SetLabels заменяет метки PR
+func (r *PullRequestRepository) SetLabels(ctx context.Context, prID string, labels []string) error {
+	if _, err := r.db.ExecContext(ctx, `DELETE FROM pr_labels WHERE pull_request_id = $1`, prID); err != nil {
+		return fmt.Errorf("delete pr labels: %w", err)
+	}
+
+	if len(labels) == 0 {
+		return nil
+	}
+
+	query := `
+        INSERT INTO pr_labels (pull_request_id, label)
+        SELECT $1, unnest($2::text[])
+        ON CONFLICT DO NOTHING
+    `
+
+	if _, err := r.db.ExecContext(ctx, query, prID, pq.Array(labels)); err != nil {
+		return fmt.Errorf("insert pr labels: %w", err)
+	}
+
+	return nil
+}
+
+// ResumeReviews добавляет к паузе ревьюверов период, пока PR был закрыт,
+// чтобы SLA не учитывал это время. Время назначения не меняется
+func (r *PullRequestRepository) ResumeReviews(ctx context.Context, prID string, closedAt time.Time) error {
+	query := `
+        UPDATE pr_reviewers
+        SET paused = paused + GREATEST(NOW() - $2::timestamptz, INTERVAL '0')
+        WHERE pull_request_id = $1
+    `
+
+	if _, err := r.db.ExecContext(ctx, query, prID, closedAt); err != nil {
+		return fmt.Errorf("resume reviews: %w", err)
+	}
+
+	return nil
+}
+
+// SetVerdict сохраняет вердикт ревьювера, заменяя предыдущий
+func (r *PullRequestRepository) SetVerdict(ctx context.Context, prID, userID string, verdict entity.Verdict) error {
+	query := `
+        UPDATE pr_reviewers
+        SET verdict = $3, verdict_at = NOW()
+        WHERE pull_request_id = $1 AND user_id = $2
+    `
+
+	result, err := r.db.ExecContext(ctx, query, prID, userID, verdict)
+	if err != nil {
+		return fmt.Errorf("set verdict: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rows == 0 {
+		return repository.ErrNotAssigned
+	}
+
+	return nil
+}
+
+// GetVerdicts возвращает вердикты назначенных ревьюверов PR
+func (r *PullRequestRepository) GetVerdicts(ctx context.Context, prID string) ([]*entity.ReviewVerdict, error) {
+	query := `
+        SELECT pull_request_id, user_id, verdict, verdict_at
+        FROM pr_reviewers
+        WHERE pull_request_id = $1 AND verdict IS NOT NULL
+        ORDER BY verdict_at, user_id
+    `
+
+	rows, err := r.db.QueryContext(ctx, query, prID)
+	if err != nil {
+		return nil, fmt.Errorf("query verdicts: %w", err)
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			log.Printf("Error closing rows: %v", err)
+		}
+	}()
+
+	var verdicts []*entity.ReviewVerdict
+	for rows.Next() {
+		var v entity.ReviewVerdict
+		if err := rows.Scan(&v.PullRequestID, &v.UserID, &v.Verdict, &v.SubmittedAt); err != nil {
+			return nil, fmt.Errorf("scan verdict: %w", err)
+		}
+		verdicts = append(verdicts, &v)
+	}
+
+	return verdicts, rows.Err()
+}
+
 func (r *PullRequestRepository) IsReviewerAssigned(ctx context.Context, prID, userID string) (bool, error) {
 	query := `
         SELECT EXISTS(
@@ -0,0 +1,136 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+
+	"reviewer-service/internal/domain/entity"
+	"reviewer-service/internal/repository"
+
+	"github.com/lib/pq"
+)
+
+type ReposRepository struct {
+	db Querier
+}
+
+func NewReposRepository(db Querier) *ReposRepository {
+	return &ReposRepository{db: db}
+}
+
+// selectRepoQuery выбирает репозитории вместе с командами-владельцами по порядку
+const selectRepoQuery = `
+        SELECT
+            r.name,
+            COALESCE(
+                array_agg(t.team_name ORDER BY t.position)
+                FILTER (WHERE t.team_name IS NOT NULL),
+                '{}'
+            ) as owning_teams,
+            r.created_at
+        FROM repositories r
+        LEFT JOIN repository_teams t ON r.name = t.repository
+    `
+
+// GetByName возвращает репозиторий или ErrNotFound
+func (r *ReposRepository) GetByName(ctx context.Context, name string) (*entity.Repository, error) {
+	query := selectRepoQuery + `
+        WHERE r.name = $1
+        GROUP BY r.name
+    `
+
+	var repo entity.Repository
+	err := r.db.QueryRowContext(ctx, query, name).Scan(&repo.Name, pq.Array(&repo.OwningTeams), &repo.CreatedAt)
+	if err == sql.ErrNoRows {
+		return nil, repository.ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("query repository: %w", err)
+	}
+
+	return &repo, nil
+}
+
+// List возвращает все репозитории по имени
+func (r *ReposRepository) List(ctx context.Context) ([]*entity.Repository, error) {
+	query := selectRepoQuery + `
+        GROUP BY r.name
+        ORDER BY r.name
+    `
+
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("query repositories: %w", err)
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			log.Printf("Error closing rows: %v", err)
+		}
+	}()
+
+	repos := []*entity.Repository{}
+	for rows.Next() {
+		var repo entity.Repository
+		if err := rows.Scan(&repo.Name, pq.Array(&repo.OwningTeams), &repo.CreatedAt); err != nil {
+			return nil, fmt.Errorf("scan repository: %w", err)
+		}
+		repos = append(repos, &repo)
+	}
+
+	return repos, rows.Err()
+}
+
+// Upsert создаёт репозиторий или заменяет его команды-владельцы
+func (r *ReposRepository) Upsert(ctx context.Context, repo *entity.Repository) error {
+	query := `
+        INSERT INTO repositories (name, created_at)
+        VALUES ($1, NOW())
+        ON CONFLICT (name) DO UPDATE SET name = EXCLUDED.name
+        RETURNING created_at
+    `
+
+	if err := r.db.QueryRowContext(ctx, query, repo.Name).Scan(&repo.CreatedAt); err != nil {
+		return fmt.Errorf("upsert repository: %w", err)
+	}
+
+	if _, err := r.db.ExecContext(ctx, `DELETE FROM repository_teams WHERE repository = $1`, repo.Name); err != nil {
+		return fmt.Errorf("delete repository teams: %w", err)
+	}
+
+	teamsQuery := `
+        INSERT INTO repository_teams (repository, team_name, position)
+        SELECT $1, t.team_name, t.position
+        FROM unnest($2::text[]) WITH ORDINALITY AS t(team_name, position)
+    `
+
+	if _, err := r.db.ExecContext(ctx, teamsQuery, repo.Name, pq.Array(repo.OwningTeams)); err != nil {
+		if pqErr, ok := err.(*pq.Error); ok {
+			if pqErr.Code == "23503" { // foreign key violation
+				return repository.ErrTeamNotFound
+			}
+		}
+		return fmt.Errorf("insert repository teams: %w", err)
+	}
+
+	return nil
+}
+
+func (r *ReposRepository) Delete(ctx context.Context, name string) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM repositories WHERE name = $1`, name)
+	if err != nil {
+		return fmt.Errorf("delete repository: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rows == 0 {
+		return repository.ErrNotFound
+	}
+
+	return nil
+}
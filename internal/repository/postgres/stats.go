@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"fmt"
 	"log"
+	"time"
 
 	"reviewer-service/internal/domain/entity"
 	"reviewer-service/internal/repository"
@@ -13,14 +14,46 @@ import (
 )
 
 type StatsRepository struct {
-	db Querier
+	db      Querier
+	buckets entity.SizeBuckets
 }
 
-func NewStatsRepository(db Querier) *StatsRepository {
-	return &StatsRepository{db: db}
+func NewStatsRepository(db Querier, buckets entity.SizeBuckets) *StatsRepository {
+	return &StatsRepository{db: db, buckets: buckets}
 }
 
-func (r *StatsRepository) GetWorkload(ctx context.Context, userIDs []string) (map[string]int, error) {
+// prWeight возвращает SQL-выражение веса ревью PR pr по корзинам размера
+// (как SizeBuckets.Weight). Границы по строкам и файлам, веса и вес сверх
+// границ передаются параметрами $first..$first+3.
+func prWeight(first int) string {
+	return fmt.Sprintf(`
+    COALESCE(
+        (SELECT b.weight
+         FROM unnest($%d::int[], $%d::int[], $%d::int[]) AS b(max_lines, max_files, weight)
+         WHERE pr.lines_added + pr.lines_removed <= b.max_lines
+           AND (b.max_files = 0 OR pr.files_changed <= b.max_files)
+         ORDER BY b.max_lines, b.max_files = 0, b.max_files
+         LIMIT 1),
+        $%d
+    )`, first, first+1, first+2, first+3)
+}
+
+// bucketArgs возвращает параметры для prWeight
+func (r *StatsRepository) bucketArgs() []any {
+	lines := make([]int64, len(r.buckets.Bounded))
+	files := make([]int64, len(r.buckets.Bounded))
+	weights := make([]int64, len(r.buckets.Bounded))
+	for i, b := range r.buckets.Bounded {
+		lines[i] = int64(b.MaxLines)
+		files[i] = int64(b.MaxFiles)
+		weights[i] = int64(b.Weight)
+	}
+	return []any{pq.Array(lines), pq.Array(files), pq.Array(weights), r.buckets.Overflow}
+}
+
+// GetOpenReviews возвращает число незавершённых ревью пользователей:
+// на открытых PR и на PR в очереди за ревьюверами
+func (r *StatsRepository) GetOpenReviews(ctx context.Context, userIDs []string) (map[string]int, error) {
 	if len(userIDs) == 0 {
 		return make(map[string]int), nil
 	}
@@ -31,11 +64,58 @@ func (r *StatsRepository) GetWorkload(ctx context.Context, userIDs []string) (ma
             COUNT(*) as open_count
         FROM pr_reviewers r
         INNER JOIN pull_requests pr ON r.pull_request_id = pr.pull_request_id
-        WHERE r.user_id = ANY($1) AND pr.status = 'OPEN'
+        WHERE r.user_id = ANY($1) AND pr.status IN ('OPEN', 'AWAITING_REVIEWERS')
         GROUP BY r.user_id
     `
 
 	rows, err := r.db.QueryContext(ctx, query, pq.Array(userIDs))
+	if err != nil {
+		return nil, fmt.Errorf("query open reviews: %w", err)
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			log.Printf("Error closing rows: %v", err)
+		}
+	}()
+
+	counts := make(map[string]int, len(userIDs))
+	for _, userID := range userIDs {
+		counts[userID] = 0
+	}
+
+	for rows.Next() {
+		var userID string
+		var count int
+
+		if err := rows.Scan(&userID, &count); err != nil {
+			return nil, fmt.Errorf("scan open reviews: %w", err)
+		}
+
+		counts[userID] = count
+	}
+
+	return counts, rows.Err()
+}
+
+// GetWorkload возвращает нагрузку пользователей - сумму весов
+// незавершённых ревью (OPEN и AWAITING_REVIEWERS) по размеру PR
+func (r *StatsRepository) GetWorkload(ctx context.Context, userIDs []string) (map[string]int, error) {
+	if len(userIDs) == 0 {
+		return make(map[string]int), nil
+	}
+
+	query := `
+        SELECT
+            r.user_id,
+            SUM(` + prWeight(2) + `) as open_load
+        FROM pr_reviewers r
+        INNER JOIN pull_requests pr ON r.pull_request_id = pr.pull_request_id
+        WHERE r.user_id = ANY($1) AND pr.status IN ('OPEN', 'AWAITING_REVIEWERS')
+        GROUP BY r.user_id
+    `
+
+	args := append([]any{pq.Array(userIDs)}, r.bucketArgs()...)
+	rows, err := r.db.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("query workload: %w", err)
 	}
@@ -85,6 +165,172 @@ func (r *StatsRepository) IncrementAssignment(ctx context.Context, userID string
 	return nil
 }
 
+// DecrementAssignment отменяет назначение, снятое вручную.
+// Время последнего назначения не меняется.
+func (r *StatsRepository) DecrementAssignment(ctx context.Context, userID string) error {
+	query := `
+        UPDATE assignment_stats
+        SET assignment_count = GREATEST(assignment_count - 1, 0)
+        WHERE user_id = $1
+    `
+
+	_, err := r.db.ExecContext(ctx, query, userID)
+	if err != nil {
+		return fmt.Errorf("decrement assignment: %w", err)
+	}
+
+	return nil
+}
+
+// GetLastAssigned возвращает время последнего назначения.
+// Пользователи без назначений в результат не попадают.
+func (r *StatsRepository) GetLastAssigned(ctx context.Context, userIDs []string) (map[string]time.Time, error) {
+	if len(userIDs) == 0 {
+		return make(map[string]time.Time), nil
+	}
+
+	query := `
+        SELECT user_id, last_assigned_at
+        FROM assignment_stats
+        WHERE user_id = ANY($1) AND last_assigned_at IS NOT NULL
+    `
+
+	rows, err := r.db.QueryContext(ctx, query, pq.Array(userIDs))
+	if err != nil {
+		return nil, fmt.Errorf("query last assigned: %w", err)
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			// Log error or handle it appropriately
+			log.Printf("Error closing rows: %v", err)
+		}
+	}()
+
+	lastAssigned := make(map[string]time.Time)
+	for rows.Next() {
+		var userID string
+		var at time.Time
+
+		if err := rows.Scan(&userID, &at); err != nil {
+			return nil, fmt.Errorf("scan last assigned: %w", err)
+		}
+
+		lastAssigned[userID] = at
+	}
+
+	return lastAssigned, rows.Err()
+}
+
+// CountMergedSince считает PR авторов команды, смёрженные начиная с since
+func (r *StatsRepository) CountMergedSince(ctx context.Context, teamName string, since time.Time) (int, error) {
+	query := `
+        SELECT COUNT(*)
+        FROM pull_requests pr
+        INNER JOIN users au ON pr.author_id = au.user_id
+        WHERE au.team_name = $1
+          AND pr.status = 'MERGED'
+          AND pr.merged_at >= $2
+    `
+
+	var count int
+	if err := r.db.QueryRowContext(ctx, query, teamName, since).Scan(&count); err != nil {
+		return 0, fmt.Errorf("count merged prs: %w", err)
+	}
+
+	return count, nil
+}
+
+// GetPairCounts считает, сколько PR автора каждый из ревьюверов получил
+// начиная с since. Ревьюверы без общих PR в результате имеют 0.
+func (r *StatsRepository) GetPairCounts(
+	ctx context.Context,
+	authorID string,
+	reviewerIDs []string,
+	since time.Time,
+) (map[string]int, error) {
+	counts := make(map[string]int, len(reviewerIDs))
+	if len(reviewerIDs) == 0 {
+		return counts, nil
+	}
+
+	query := `
+        SELECT r.user_id, COUNT(*)
+        FROM pr_reviewers r
+        INNER JOIN pull_requests pr ON r.pull_request_id = pr.pull_request_id
+        WHERE pr.author_id = $1
+          AND r.user_id = ANY($2)
+          AND r.assigned_at >= $3
+        GROUP BY r.user_id
+    `
+
+	rows, err := r.db.QueryContext(ctx, query, authorID, pq.Array(reviewerIDs), since)
+	if err != nil {
+		return nil, fmt.Errorf("query pair counts: %w", err)
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			log.Printf("Error closing rows: %v", err)
+		}
+	}()
+
+	for _, id := range reviewerIDs {
+		counts[id] = 0
+	}
+
+	for rows.Next() {
+		var userID string
+		var count int
+
+		if err := rows.Scan(&userID, &count); err != nil {
+			return nil, fmt.Errorf("scan pair count: %w", err)
+		}
+
+		counts[userID] = count
+	}
+
+	return counts, rows.Err()
+}
+
+// GetPairingMatrix возвращает пары автор-ревьювер для PR авторов команды
+// начиная с since, по убыванию числа общих ревью
+func (r *StatsRepository) GetPairingMatrix(
+	ctx context.Context,
+	teamName string,
+	since time.Time,
+) ([]*entity.PairStat, error) {
+	query := `
+        SELECT pr.author_id, r.user_id, COUNT(*) as reviews
+        FROM pr_reviewers r
+        INNER JOIN pull_requests pr ON r.pull_request_id = pr.pull_request_id
+        INNER JOIN users au ON pr.author_id = au.user_id
+        WHERE au.team_name = $1
+          AND r.assigned_at >= $2
+        GROUP BY pr.author_id, r.user_id
+        ORDER BY reviews DESC, pr.author_id, r.user_id
+    `
+
+	rows, err := r.db.QueryContext(ctx, query, teamName, since)
+	if err != nil {
+		return nil, fmt.Errorf("query pairing matrix: %w", err)
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			log.Printf("Error closing rows: %v", err)
+		}
+	}()
+
+	pairs := []*entity.PairStat{}
+	for rows.Next() {
+		var p entity.PairStat
+		if err := rows.Scan(&p.AuthorID, &p.ReviewerID, &p.Reviews); err != nil {
+			return nil, fmt.Errorf("scan pair: %w", err)
+		}
+		pairs = append(pairs, &p)
+	}
+
+	return pairs, rows.Err()
+}
+
 func (r *StatsRepository) GetUserStats(ctx context.Context, userID string) (*entity.UserStats, error) {
 	query := `
         SELECT
@@ -95,7 +341,7 @@ func (r *StatsRepository) GetUserStats(ctx context.Context, userID string) (*ent
                 (SELECT COUNT(*)
                  FROM pr_reviewers r
                  INNER JOIN pull_requests pr ON r.pull_request_id = pr.pull_request_id
-                 WHERE r.user_id = u.user_id AND pr.status = 'OPEN'),
+                 WHERE r.user_id = u.user_id AND pr.status IN ('OPEN', 'AWAITING_REVIEWERS')),
                 0
             ) as open_reviews,
             COALESCE(
@@ -132,8 +378,22 @@ func (r *StatsRepository) GetUserStats(ctx context.Context, userID string) (*ent
 	return &stats, nil
 }
 
+// GetTeamStats возвращает статистику команд. OpenReviews и WeightedLoad -
+// незавершённые ревью участников команды (OPEN и AWAITING_REVIEWERS)
+// штуками и с весами по размеру PR. Черновики в статистику не входят.
 func (r *StatsRepository) GetTeamStats(ctx context.Context) ([]*entity.TeamStats, error) {
 	query := `
+        WITH load AS (
+            SELECT
+                ru.team_name,
+                COUNT(*) as open_reviews,
+                SUM(` + prWeight(1) + `) as weighted_load
+            FROM pr_reviewers r
+            INNER JOIN pull_requests pr ON r.pull_request_id = pr.pull_request_id
+            INNER JOIN users ru ON r.user_id = ru.user_id
+            WHERE pr.status IN ('OPEN', 'AWAITING_REVIEWERS')
+            GROUP BY ru.team_name
+        )
         SELECT
             t.team_name,
             COUNT(DISTINCT u.user_id) as total_members,
@@ -142,23 +402,26 @@ func (r *StatsRepository) GetTeamStats(ctx context.Context) ([]*entity.TeamStats
                 (SELECT COUNT(DISTINCT pr.pull_request_id)
                  FROM pull_requests pr
                  INNER JOIN users au ON pr.author_id = au.user_id
-                 WHERE au.team_name = t.team_name),
+                 WHERE au.team_name = t.team_name AND pr.status <> 'DRAFT'),
                 0
             ) as total_prs,
             COALESCE(
                 (SELECT COUNT(DISTINCT pr.pull_request_id)
                  FROM pull_requests pr
                  INNER JOIN users au ON pr.author_id = au.user_id
-                 WHERE au.team_name = t.team_name AND pr.status = 'OPEN'),
+                 WHERE au.team_name = t.team_name AND pr.status IN ('OPEN', 'AWAITING_REVIEWERS')),
                 0
-            ) as open_prs
+            ) as open_prs,
+            COALESCE(MAX(l.open_reviews), 0) as open_reviews,
+            COALESCE(MAX(l.weighted_load), 0) as weighted_load
         FROM teams t
         LEFT JOIN users u ON t.team_name = u.team_name
+        LEFT JOIN load l ON t.team_name = l.team_name
         GROUP BY t.team_name
         ORDER BY t.team_name
     `
 
-	rows, err := r.db.QueryContext(ctx, query)
+	rows, err := r.db.QueryContext(ctx, query, r.bucketArgs()...)
 	if err != nil {
 		return nil, fmt.Errorf("query team stats: %w", err)
 	}
@@ -178,6 +441,8 @@ func (r *StatsRepository) GetTeamStats(ctx context.Context) ([]*entity.TeamStats
 			&s.ActiveMembers,
 			&s.TotalPRs,
 			&s.OpenPRs,
+			&s.OpenReviews,
+			&s.WeightedLoad,
 		); err != nil {
 			return nil, fmt.Errorf("scan team stats: %w", err)
 		}
@@ -0,0 +1,207 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"reviewer-service/internal/domain/entity"
+	"reviewer-service/internal/repository"
+
+	"github.com/lib/pq"
+)
+
+type TeamPolicyRepository struct {
+	db Querier
+}
+
+func NewTeamPolicyRepository(db Querier) *TeamPolicyRepository {
+	return &TeamPolicyRepository{db: db}
+}
+
+// GetByTeam возвращает политику команды или ErrNotFound, если она не настроена
+func (r *TeamPolicyRepository) GetByTeam(ctx context.Context, teamName string) (*entity.TeamPolicy, error) {
+	query := `
+        SELECT
+            team_name,
+            reviewer_count,
+            min_active_members,
+            max_open_reviews,
+            strategy,
+            fallback_teams,
+            ownership_mode,
+            prefer_working_hours,
+            prefer_within_hours,
+            max_off_hours,
+            require_senior,
+            learning_reviewer,
+            anti_affinity_days,
+            anti_affinity_weight,
+            review_sla_hours,
+            escalation_step_hours,
+            escalation_ladder,
+            COALESCE(team_lead_id, ''),
+            required_approvals,
+            max_weighted_load,
+            created_at,
+            updated_at
+        FROM team_policies
+        WHERE team_name = $1
+    `
+
+	var policy entity.TeamPolicy
+	var ladder []string
+	err := r.db.QueryRowContext(ctx, query, teamName).Scan(
+		&policy.TeamName,
+		&policy.ReviewerCount,
+		&policy.MinActiveMembers,
+		&policy.MaxOpenReviews,
+		&policy.Strategy,
+		pq.Array(&policy.FallbackTeams),
+		&policy.OwnershipMode,
+		&policy.PreferWorkingHours,
+		&policy.PreferWithinHours,
+		&policy.MaxOffHours,
+		&policy.RequireSenior,
+		&policy.LearningReviewer,
+		&policy.AntiAffinityDays,
+		&policy.AntiAffinityWeight,
+		&policy.ReviewSLAHours,
+		&policy.EscalationStepHours,
+		pq.Array(&ladder),
+		&policy.TeamLeadID,
+		&policy.RequiredApprovals,
+		&policy.MaxWeightedLoad,
+		&policy.CreatedAt,
+		&policy.UpdatedAt,
+	)
+
+	if err == sql.ErrNoRows {
+		return nil, repository.ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("query team policy: %w", err)
+	}
+
+	for _, action := range ladder {
+		policy.EscalationLadder = append(policy.EscalationLadder, entity.EscalationAction(action))
+	}
+
+	return &policy, nil
+}
+
+// Upsert создаёт или обновляет политику команды
+func (r *TeamPolicyRepository) Upsert(ctx context.Context, policy *entity.TeamPolicy) error {
+	ladder := make([]string, len(policy.EscalationLadder))
+	for i, action := range policy.EscalationLadder {
+		ladder[i] = string(action)
+	}
+
+	query := `
+        INSERT INTO team_policies (
+            team_name,
+            reviewer_count,
+            min_active_members,
+            max_open_reviews,
+            strategy,
+            fallback_teams,
+            ownership_mode,
+            prefer_working_hours,
+            prefer_within_hours,
+            max_off_hours,
+            require_senior,
+            learning_reviewer,
+            anti_affinity_days,
+            anti_affinity_weight,
+            review_sla_hours,
+            escalation_step_hours,
+            escalation_ladder,
+            team_lead_id,
+            required_approvals,
+            max_weighted_load,
+            created_at,
+            updated_at
+        )
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, NULLIF($18, ''), $19, $20, NOW(), NOW())
+        ON CONFLICT (team_name)
+        DO UPDATE SET
+            reviewer_count = EXCLUDED.reviewer_count,
+            min_active_members = EXCLUDED.min_active_members,
+            max_open_reviews = EXCLUDED.max_open_reviews,
+            strategy = EXCLUDED.strategy,
+            fallback_teams = EXCLUDED.fallback_teams,
+            ownership_mode = EXCLUDED.ownership_mode,
+            prefer_working_hours = EXCLUDED.prefer_working_hours,
+            prefer_within_hours = EXCLUDED.prefer_within_hours,
+            max_off_hours = EXCLUDED.max_off_hours,
+            require_senior = EXCLUDED.require_senior,
+            learning_reviewer = EXCLUDED.learning_reviewer,
+            anti_affinity_days = EXCLUDED.anti_affinity_days,
+            anti_affinity_weight = EXCLUDED.anti_affinity_weight,
+            review_sla_hours = EXCLUDED.review_sla_hours,
+            escalation_step_hours = EXCLUDED.escalation_step_hours,
+            escalation_ladder = EXCLUDED.escalation_ladder,
+            team_lead_id = EXCLUDED.team_lead_id,
+            required_approvals = EXCLUDED.required_approvals,
+            max_weighted_load = EXCLUDED.max_weighted_load,
+            updated_at = NOW()
+        RETURNING created_at, updated_at
+    `
+
+	err := r.db.QueryRowContext(ctx, query,
+		policy.TeamName,
+		policy.ReviewerCount,
+		policy.MinActiveMembers,
+		policy.MaxOpenReviews,
+		policy.Strategy,
+		pq.Array(policy.FallbackTeams),
+		policy.OwnershipMode,
+		policy.PreferWorkingHours,
+		policy.PreferWithinHours,
+		policy.MaxOffHours,
+		policy.RequireSenior,
+		policy.LearningReviewer,
+		policy.AntiAffinityDays,
+		policy.AntiAffinityWeight,
+		policy.ReviewSLAHours,
+		policy.EscalationStepHours,
+		pq.Array(ladder),
+		policy.TeamLeadID,
+		policy.RequiredApprovals,
+		policy.MaxWeightedLoad,
+	).Scan(&policy.CreatedAt, &policy.UpdatedAt)
+
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok {
+			if pqErr.Code == "23503" { // foreign key violation
+				return repository.ErrNotFound
+			}
+			if pqErr.Code == "23514" { // check violation
+				return repository.ErrInvalidPolicy
+			}
+		}
+		return fmt.Errorf("upsert team policy: %w", err)
+	}
+
+	return nil
+}
+
+func (r *TeamPolicyRepository) Delete(ctx context.Context, teamName string) error {
+	query := `DELETE FROM team_policies WHERE team_name = $1`
+
+	result, err := r.db.ExecContext(ctx, query, teamName)
+	if err != nil {
+		return fmt.Errorf("delete team policy: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rows == 0 {
+		return repository.ErrNotFound
+	}
+
+	return nil
+}
@@ -5,15 +5,22 @@ import (
 	"database/sql"
 	"fmt"
 
+	"reviewer-service/internal/domain/entity"
 	"reviewer-service/internal/repository"
 )
 
 type TxManager struct {
-	db *sql.DB
+	db          *sql.DB
+	sizeBuckets entity.SizeBuckets
 }
 
 func NewTxManager(db *sql.DB) *TxManager {
-	return &TxManager{db: db}
+	return &TxManager{db: db, sizeBuckets: entity.DefaultSizeBuckets()}
+}
+
+// SetSizeBuckets задаёт веса ревью по размеру PR для расчёта нагрузки
+func (m *TxManager) SetSizeBuckets(buckets entity.SizeBuckets) {
+	m.sizeBuckets = buckets
 }
 
 func (m *TxManager) WithTx(ctx context.Context, fn func(repository.Tx) error) error {
@@ -25,11 +32,19 @@ func (m *TxManager) WithTx(ctx context.Context, fn func(repository.Tx) error) er
 	}
 
 	txRepo := &txRepository{
-		tx:        tx,
-		teamRepo:  NewTeamRepository(tx),
-		userRepo:  NewUserRepository(tx),
-		prRepo:    NewPullRequestRepository(tx),
-		statsRepo: NewStatsRepository(tx),
+		tx:             tx,
+		teamRepo:       NewTeamRepository(tx),
+		userRepo:       NewUserRepository(tx),
+		prRepo:         NewPullRequestRepository(tx),
+		statsRepo:      NewStatsRepository(tx, m.sizeBuckets),
+		policyRepo:     NewTeamPolicyRepository(tx),
+		ownersRepo:     NewCodeOwnersRepository(tx),
+		absenceRepo:    NewAbsenceRepository(tx),
+		mandatoryRepo:  NewMandatoryReviewerRepository(tx),
+		exclusionRepo:  NewExclusionRepository(tx),
+		declineRepo:    NewDeclineRepository(tx),
+		escalationRepo: NewEscalationRepository(tx),
+		reposRepo:      NewReposRepository(tx),
 	}
 
 	if err := fn(txRepo); err != nil {
@@ -47,11 +62,19 @@ func (m *TxManager) WithTx(ctx context.Context, fn func(repository.Tx) error) er
 }
 
 type txRepository struct {
-	tx        *sql.Tx
-	teamRepo  repository.TeamRepository
-	userRepo  repository.UserRepository
-	prRepo    repository.PullRequestRepository
-	statsRepo repository.StatsRepository
+	tx             *sql.Tx
+	teamRepo       repository.TeamRepository
+	userRepo       repository.UserRepository
+	prRepo         repository.PullRequestRepository
+	statsRepo      repository.StatsRepository
+	policyRepo     repository.TeamPolicyRepository
+	ownersRepo     repository.CodeOwnersRepository
+	absenceRepo    repository.AbsenceRepository
+	mandatoryRepo  repository.MandatoryReviewerRepository
+	exclusionRepo  repository.ExclusionRepository
+	declineRepo    repository.DeclineRepository
+	escalationRepo repository.EscalationRepository
+	reposRepo      repository.ReposRepository
 }
 
 func (t *txRepository) Teams() repository.TeamRepository {
@@ -70,6 +93,38 @@ func (t *txRepository) Stats() repository.StatsRepository {
 	return t.statsRepo
 }
 
+func (t *txRepository) Policies() repository.TeamPolicyRepository {
+	return t.policyRepo
+}
+
+func (t *txRepository) CodeOwners() repository.CodeOwnersRepository {
+	return t.ownersRepo
+}
+
+func (t *txRepository) Absences() repository.AbsenceRepository {
+	return t.absenceRepo
+}
+
+func (t *txRepository) MandatoryReviewers() repository.MandatoryReviewerRepository {
+	return t.mandatoryRepo
+}
+
+func (t *txRepository) Exclusions() repository.ExclusionRepository {
+	return t.exclusionRepo
+}
+
+func (t *txRepository) Declines() repository.DeclineRepository {
+	return t.declineRepo
+}
+
+func (t *txRepository) Escalations() repository.EscalationRepository {
+	return t.escalationRepo
+}
+
+func (t *txRepository) Repos() repository.ReposRepository {
+	return t.reposRepo
+}
+
 func (t *txRepository) Commit() error {
 	return t.tx.Commit()
 }
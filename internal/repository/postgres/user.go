@@ -3,6 +3,7 @@ package postgres
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"fmt"
 	"log"
 
@@ -12,6 +13,80 @@ import (
 	"github.com/lib/pq"
 )
 
+// userColumns - колонки пользователя для scanUser (таблица users с алиасом u)
+const userColumns = `
+            u.user_id,
+            u.username,
+            u.team_name,
+            u.is_active,
+            COALESCE(
+                (SELECT array_agg(t.tag ORDER BY t.tag)
+                 FROM user_tags t
+                 WHERE t.user_id = u.user_id),
+                '{}'
+            ) as tags,
+            u.max_open_reviews,
+            u.capacity_percent,
+            u.timezone,
+            COALESCE(
+                (SELECT json_agg(json_build_object(
+                            'weekday', h.weekday,
+                            'start', to_char(h.start_time, 'HH24:MI'),
+                            'end', to_char(h.end_time, 'HH24:MI'))
+                        ORDER BY h.weekday, h.start_time)
+                 FROM user_working_hours h
+                 WHERE h.user_id = u.user_id),
+                '[]'
+            ) as working_hours,
+            u.seniority,
+            u.created_at,
+            u.updated_at`
+
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+func scanUser(row scanner) (*entity.User, error) {
+	var user entity.User
+	var workingHours []byte
+	err := row.Scan(
+		&user.UserID,
+		&user.Username,
+		&user.TeamName,
+		&user.IsActive,
+		pq.Array(&user.Tags),
+		&user.MaxOpenReviews,
+		&user.CapacityPercent,
+		&user.Timezone,
+		&workingHours,
+		&user.Seniority,
+		&user.CreatedAt,
+		&user.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(workingHours, &user.WorkingHours); err != nil {
+		return nil, fmt.Errorf("decode working hours: %w", err)
+	}
+
+	return &user, nil
+}
+
+func scanUsers(rows *sql.Rows) ([]*entity.User, error) {
+	var users []*entity.User
+	for rows.Next() {
+		user, err := scanUser(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan user: %w", err)
+		}
+		users = append(users, user)
+	}
+
+	return users, rows.Err()
+}
+
 type UserRepository struct {
 	db Querier
 }
@@ -109,20 +184,12 @@ func (r *UserRepository) Update(ctx context.Context, user *entity.User) error {
 // GetByID возвращает пользователя по ID
 func (r *UserRepository) GetByID(ctx context.Context, userID string) (*entity.User, error) {
 	query := `
-        SELECT user_id, username, team_name, is_active, created_at, updated_at
-        FROM users
-        WHERE user_id = $1
+        SELECT ` + userColumns + `
+        FROM users u
+        WHERE u.user_id = $1
     `
 
-	var user entity.User
-	err := r.db.QueryRowContext(ctx, query, userID).Scan(
-		&user.UserID,
-		&user.Username,
-		&user.TeamName,
-		&user.IsActive,
-		&user.CreatedAt,
-		&user.UpdatedAt,
-	)
+	user, err := scanUser(r.db.QueryRowContext(ctx, query, userID))
 
 	if err == sql.ErrNoRows {
 		return nil, repository.ErrNotFound
@@ -131,15 +198,15 @@ func (r *UserRepository) GetByID(ctx context.Context, userID string) (*entity.Us
 		return nil, fmt.Errorf("query user: %w", err)
 	}
 
-	return &user, nil
+	return user, nil
 }
 
 func (r *UserRepository) GetByTeam(ctx context.Context, teamName string) ([]*entity.User, error) {
 	query := `
-        SELECT user_id, username, team_name, is_active, created_at, updated_at
-        FROM users
-        WHERE team_name = $1
-        ORDER BY username
+        SELECT ` + userColumns + `
+        FROM users u
+        WHERE u.team_name = $1
+        ORDER BY u.username
     `
 
 	rows, err := r.db.QueryContext(ctx, query, teamName)
@@ -153,23 +220,7 @@ func (r *UserRepository) GetByTeam(ctx context.Context, teamName string) ([]*ent
 		}
 	}()
 
-	var users []*entity.User
-	for rows.Next() {
-		var user entity.User
-		if err := rows.Scan(
-			&user.UserID,
-			&user.Username,
-			&user.TeamName,
-			&user.IsActive,
-			&user.CreatedAt,
-			&user.UpdatedAt,
-		); err != nil {
-			return nil, fmt.Errorf("scan user: %w", err)
-		}
-		users = append(users, &user)
-	}
-
-	return users, rows.Err()
+	return scanUsers(rows)
 }
 
 func (r *UserRepository) GetActiveByTeam(
@@ -178,12 +229,12 @@ func (r *UserRepository) GetActiveByTeam(
 	excludeUserID string,
 ) ([]*entity.User, error) {
 	query := `
-        SELECT user_id, username, team_name, is_active, created_at, updated_at
-        FROM users
-        WHERE team_name = $1
-          AND is_active = true
-          AND user_id != $2
-        ORDER BY username
+        SELECT ` + userColumns + `
+        FROM users u
+        WHERE u.team_name = $1
+          AND u.is_active = true
+          AND u.user_id != $2
+        ORDER BY u.username
     `
 
 	rows, err := r.db.QueryContext(ctx, query, teamName, excludeUserID)
@@ -197,23 +248,7 @@ func (r *UserRepository) GetActiveByTeam(
 		}
 	}()
 
-	var users []*entity.User
-	for rows.Next() {
-		var user entity.User
-		if err := rows.Scan(
-			&user.UserID,
-			&user.Username,
-			&user.TeamName,
-			&user.IsActive,
-			&user.CreatedAt,
-			&user.UpdatedAt,
-		); err != nil {
-			return nil, fmt.Errorf("scan user: %w", err)
-		}
-		users = append(users, &user)
-	}
-
-	return users, rows.Err()
+	return scanUsers(rows)
 }
 
 func (r *UserRepository) SetActive(ctx context.Context, userID string, isActive bool) error {
@@ -258,3 +293,164 @@ func (r *UserRepository) BulkDeactivate(ctx context.Context, userIDs []string) e
 
 	return nil
 }
+
+// SetTags заменяет теги экспертизы пользователя
+func (r *UserRepository) SetTags(ctx context.Context, userID string, tags []string) error {
+	deleteQuery := `DELETE FROM user_tags WHERE user_id = $1`
+
+	if _, err := r.db.ExecContext(ctx, deleteQuery, userID); err != nil {
+		return fmt.Errorf("delete user tags: %w", err)
+	}
+
+	if len(tags) == 0 {
+		return nil
+	}
+
+	insertQuery := `
+        INSERT INTO user_tags (user_id, tag)
+        SELECT $1, unnest($2::text[])
+        ON CONFLICT DO NOTHING
+    `
+
+	if _, err := r.db.ExecContext(ctx, insertQuery, userID, pq.Array(tags)); err != nil {
+		if pqErr, ok := err.(*pq.Error); ok {
+			if pqErr.Code == "23503" { // foreign key violation
+				return repository.ErrNotFound
+			}
+		}
+		return fmt.Errorf("insert user tags: %w", err)
+	}
+
+	return nil
+}
+
+// SetCapacity задаёт личный лимит открытых ревью и долю ставки
+func (r *UserRepository) SetCapacity(
+	ctx context.Context,
+	userID string,
+	maxOpenReviews *int,
+	capacityPercent *int,
+) error {
+	query := `
+        UPDATE users
+        SET max_open_reviews = $2, capacity_percent = COALESCE($3, capacity_percent), updated_at = NOW()
+        WHERE user_id = $1
+    `
+
+	result, err := r.db.ExecContext(ctx, query, userID, maxOpenReviews, capacityPercent)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok {
+			if pqErr.Code == "23514" { // check violation
+				return repository.ErrInvalidCapacity
+			}
+		}
+		return fmt.Errorf("set user capacity: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rows == 0 {
+		return repository.ErrNotFound
+	}
+
+	return nil
+}
+
+// SetSeniority задаёт уровень пользователя
+func (r *UserRepository) SetSeniority(ctx context.Context, userID string, seniority entity.Seniority) error {
+	query := `
+        UPDATE users
+        SET seniority = $2, updated_at = NOW()
+        WHERE user_id = $1
+    `
+
+	result, err := r.db.ExecContext(ctx, query, userID, seniority)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok {
+			if pqErr.Code == "23514" { // check violation
+				return repository.ErrInvalidSeniority
+			}
+		}
+		return fmt.Errorf("set user seniority: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rows == 0 {
+		return repository.ErrNotFound
+	}
+
+	return nil
+}
+
+// SetSchedule заменяет часовой пояс и рабочие часы пользователя
+func (r *UserRepository) SetSchedule(
+	ctx context.Context,
+	userID string,
+	timezone string,
+	hours []entity.WorkingHours,
+) error {
+	updateQuery := `
+        UPDATE users
+        SET timezone = $2, updated_at = NOW()
+        WHERE user_id = $1
+    `
+
+	result, err := r.db.ExecContext(ctx, updateQuery, userID, timezone)
+	if err != nil {
+		return fmt.Errorf("set user timezone: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rows == 0 {
+		return repository.ErrNotFound
+	}
+
+	deleteQuery := `DELETE FROM user_working_hours WHERE user_id = $1`
+
+	if _, err := r.db.ExecContext(ctx, deleteQuery, userID); err != nil {
+		return fmt.Errorf("delete working hours: %w", err)
+	}
+
+	if len(hours) == 0 {
+		return nil
+	}
+
+	weekdays := make([]int64, len(hours))
+	starts := make([]string, len(hours))
+	ends := make([]string, len(hours))
+	for i, h := range hours {
+		weekdays[i] = int64(h.Weekday)
+		starts[i] = h.Start
+		ends[i] = h.End
+	}
+
+	insertQuery := `
+        INSERT INTO user_working_hours (user_id, weekday, start_time, end_time)
+        SELECT $1, w, s, e
+        FROM unnest($2::smallint[], $3::time[], $4::time[]) AS t(w, s, e)
+        ON CONFLICT DO NOTHING
+    `
+
+	_, err = r.db.ExecContext(ctx, insertQuery, userID, pq.Array(weekdays), pq.Array(starts), pq.Array(ends))
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok {
+			if pqErr.Code == "23514" { // check violation
+				return repository.ErrInvalidSchedule
+			}
+		}
+		return fmt.Errorf("insert working hours: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,233 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"reviewer-service/internal/domain/entity"
+	"reviewer-service/internal/repository"
+)
+
+// AddAbsence регистрирует период отсутствия пользователя
+func (uc *UserUseCase) AddAbsence(ctx context.Context, absence *entity.Absence) (*entity.Absence, error) {
+	if !absence.EndsAt.After(absence.StartsAt) {
+		return nil, fmt.Errorf("%w: ends_at must be after starts_at", repository.ErrInvalidAbsence)
+	}
+	if !absence.EndsAt.After(time.Now()) {
+		return nil, fmt.Errorf("%w: absence is already over", repository.ErrInvalidAbsence)
+	}
+
+	err := uc.txManager.WithTx(ctx, func(tx repository.Tx) error {
+		if _, err := tx.Users().GetByID(ctx, absence.UserID); err != nil {
+			return err
+		}
+
+		existing, err := tx.Absences().GetByUser(ctx, absence.UserID)
+		if err != nil {
+			return err
+		}
+
+		for _, other := range existing {
+			if other.IsPending() && other.Overlaps(absence) {
+				return fmt.Errorf("%w: overlaps absence %d", repository.ErrInvalidAbsence, other.ID)
+			}
+		}
+
+		absence.Status = entity.AbsenceScheduled
+		return tx.Absences().Create(ctx, absence)
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return absence, nil
+}
+
+// GetAbsences возвращает периоды отсутствия пользователя
+func (uc *UserUseCase) GetAbsences(ctx context.Context, userID string) ([]*entity.Absence, error) {
+	var result []*entity.Absence
+
+	err := uc.txManager.WithTx(ctx, func(tx repository.Tx) error {
+		if _, err := tx.Users().GetByID(ctx, userID); err != nil {
+			return err
+		}
+
+		absences, err := tx.Absences().GetByUser(ctx, userID)
+		if err != nil {
+			return err
+		}
+
+		result = absences
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// CancelAbsence отменяет запланированный период, а начавшийся - завершает досрочно
+func (uc *UserUseCase) CancelAbsence(ctx context.Context, userID string, absenceID int64) error {
+	return uc.txManager.WithTx(ctx, func(tx repository.Tx) error {
+		absence, err := tx.Absences().GetByIDForUpdate(ctx, absenceID)
+		if err != nil {
+			return err
+		}
+		if absence.UserID != userID {
+			return repository.ErrNotFound
+		}
+
+		switch absence.Status {
+		case entity.AbsenceScheduled:
+			return tx.Absences().Delete(ctx, absenceID)
+		case entity.AbsenceActive:
+			if err := finishAbsence(ctx, tx, absence); err != nil {
+				return err
+			}
+			return fillVacancies(ctx, tx, uc.selector)
+		default:
+			return fmt.Errorf("%w: absence is already finished", repository.ErrInvalidAbsence)
+		}
+	})
+}
+
+// ApplyAbsences переключает is_active на границах периодов отсутствия:
+// завершившиеся периоды возвращают пользователя, начавшиеся - выключают его
+// и переназначают его открытые ревью. Каждый период обрабатывается в своей
+// транзакции: ошибка по одному не мешает остальным и возвращается вместе
+// с остальными ошибками. Вызывается фоновым планировщиком.
+func (uc *UserUseCase) ApplyAbsences(ctx context.Context, now time.Time) error {
+	var finishing, starting []*entity.Absence
+
+	err := uc.txManager.WithTx(ctx, func(tx repository.Tx) error {
+		var err error
+		if finishing, err = tx.Absences().GetDueToFinish(ctx, now); err != nil {
+			return err
+		}
+		starting, err = tx.Absences().GetDueToStart(ctx, now)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("get due absences: %w", err)
+	}
+
+	var errs []error
+
+	for _, due := range finishing {
+		err := uc.txManager.WithTx(ctx, func(tx repository.Tx) error {
+			absence, err := tx.Absences().GetByIDForUpdate(ctx, due.ID)
+			if err != nil {
+				return err
+			}
+			// Могли отменить параллельно
+			if !absence.IsPending() {
+				return nil
+			}
+
+			if err := finishAbsence(ctx, tx, absence); err != nil {
+				return err
+			}
+
+			// Вернувшийся ревьювер может забрать PR из очереди
+			// или добрать недоукомплектованный
+			return fillVacancies(ctx, tx, uc.selector)
+		})
+		if err != nil {
+			errs = append(errs, fmt.Errorf("finish absence %d of %s: %w", due.ID, due.UserID, err))
+		}
+	}
+
+	for _, due := range starting {
+		err := uc.txManager.WithTx(ctx, func(tx repository.Tx) error {
+			absence, err := tx.Absences().GetByIDForUpdate(ctx, due.ID)
+			if err != nil {
+				return err
+			}
+			// Могли отменить или завершить выше
+			if absence.Status != entity.AbsenceScheduled {
+				return nil
+			}
+
+			return uc.startAbsence(ctx, tx, absence)
+		})
+		if err != nil {
+			errs = append(errs, fmt.Errorf("start absence %d of %s: %w", due.ID, due.UserID, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// startAbsence выключает пользователя и передаёт его открытые ревью коллегам.
+// Ревью, для которых замены нет или на которых он запросил изменения,
+// остаются за пользователем. Если без него
+// в команде останется меньше активных участников, чем требует политика,
+// возвращает ErrMinActiveMembers, и период начнётся при следующей проверке.
+func (uc *UserUseCase) startAbsence(ctx context.Context, tx repository.Tx, absence *entity.Absence) error {
+	user, err := tx.Users().GetByID(ctx, absence.UserID)
+	if err != nil {
+		return err
+	}
+
+	// Если пользователь уже выключен вручную, возвращать его будет не нам
+	if user.IsActive {
+		if err := checkMinActiveMembers(ctx, tx, []string{user.UserID}); err != nil {
+			return err
+		}
+		if err := tx.Users().SetActive(ctx, user.UserID, false); err != nil {
+			return err
+		}
+		absence.Deactivated = true
+	}
+
+	prs, err := tx.PullRequests().GetOpenByReviewers(ctx, []string{user.UserID})
+	if err != nil {
+		return err
+	}
+
+	for _, open := range prs {
+		pr, err := tx.PullRequests().GetByIDForUpdate(ctx, open.ID)
+		if err != nil {
+			return err
+		}
+		if !pr.InReview() || !pr.HasReviewer(user.UserID) {
+			continue
+		}
+
+		blocking, err := isBlocking(ctx, tx, pr.ID, user.UserID)
+		if err != nil {
+			return err
+		}
+		if blocking {
+			continue
+		}
+
+		_, err = replaceReviewer(ctx, tx, uc.selector, pr, user.UserID)
+		if selectionMiss(err) {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("reassign pr %s: %w", pr.ID, err)
+		}
+	}
+
+	absence.Status = entity.AbsenceActive
+	return tx.Absences().UpdateStatus(ctx, absence)
+}
+
+// finishAbsence возвращает пользователя, если его выключал планировщик
+func finishAbsence(ctx context.Context, tx repository.Tx, absence *entity.Absence) error {
+	if absence.Deactivated {
+		if err := tx.Users().SetActive(ctx, absence.UserID, true); err != nil {
+			return err
+		}
+	}
+
+	absence.Status = entity.AbsenceFinished
+	return tx.Absences().UpdateStatus(ctx, absence)
+}
@@ -0,0 +1,252 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"reviewer-service/internal/domain/entity"
+	"reviewer-service/internal/domain/service"
+	"reviewer-service/internal/repository"
+)
+
+// queueRateWindow - окно, по темпу мёржей в котором оценивается ожидание в очереди
+const queueRateWindow = 7 * 24 * time.Hour
+
+// processBacklog назначает ревьюверов PR из очереди ожидания, пока у кого-то
+// есть свободное место. Вызывается в транзакции операции, освободившей место
+// или добавившей кандидатов: мёрж, переназначение, активация, изменение лимитов,
+// добавление участников в команду. PR очереди блокируются в порядке очереди,
+// а заблокированные другой транзакцией пропускаются - их разберёт она.
+// Недобор у открытых PR восполняет fillVacancies.
+func processBacklog(ctx context.Context, tx repository.Tx, selector *service.ReviewerSelector) error {
+	awaiting, err := tx.PullRequests().LockAwaiting(ctx)
+	if err != nil {
+		return fmt.Errorf("lock awaiting prs: %w", err)
+	}
+
+	for _, pr := range awaiting {
+		// Могли смёржить или обработать параллельно
+		if !pr.IsAwaitingReviewers() {
+			continue
+		}
+
+		author, err := tx.Users().GetByID(ctx, pr.AuthorID)
+		if err != nil {
+			return fmt.Errorf("get author: %w", err)
+		}
+
+		team, policy, err := reviewTeam(ctx, tx, pr, author.TeamName)
+		if err != nil {
+			return err
+		}
+
+		decliners, err := tx.Declines().GetDecliners(ctx, pr.ID)
+		if err != nil {
+			return err
+		}
+
+		// Обязательные и назначенные вручную ревьюверы остаются на PR -
+		// подбираем только недостающих
+		reviewers, err := selector.SelectAdditional(ctx, tx, service.SelectionRequest{
+			PullRequestID:   pr.ID,
+			TeamName:        team,
+			AuthorID:        pr.AuthorID,
+			ExcludeUserIDs:  decliners,
+			AssignedUserIDs: pr.AssignedReviewers,
+			ChangedFiles:    pr.ChangedFiles,
+			Labels:          pr.Labels,
+			Policy:          policy,
+		}, pr.Shortfall())
+		if selectionMiss(err) || (err == nil && len(reviewers) == 0 && pr.Shortfall() > 0) {
+			// Подходящих ревьюверов пока нет - PR остаётся в очереди
+			// и не мешает операции, которая разбирает очередь
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("select reviewers: %w", err)
+		}
+
+		if err := pr.TransitionTo(entity.StatusOpen, time.Now()); err != nil {
+			return err
+		}
+		if err := tx.PullRequests().Update(ctx, pr); err != nil {
+			return err
+		}
+
+		if err := assignReviewers(ctx, tx, pr, team, reviewers); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// fillVacancies разбирает очередь, а затем добирает ревьюверов открытым PR,
+// у которых их меньше целевого числа. Вызывается в транзакции операции,
+// добавившей кандидатов: активация, возвращение из отсутствия, изменение
+// лимитов, создание команды. Недоукомплектованные PR блокируются от старых
+// к новым, заблокированные другой транзакцией пропускаются.
+func fillVacancies(ctx context.Context, tx repository.Tx, selector *service.ReviewerSelector) error {
+	if err := processBacklog(ctx, tx, selector); err != nil {
+		return err
+	}
+
+	understaffed, err := tx.PullRequests().LockUnderstaffed(ctx)
+	if err != nil {
+		return fmt.Errorf("lock understaffed prs: %w", err)
+	}
+
+	for _, pr := range understaffed {
+		if err := topUp(ctx, tx, selector, pr); err != nil {
+			return fmt.Errorf("top up pr %s: %w", pr.ID, err)
+		}
+	}
+
+	return nil
+}
+
+// TopUpReviewers добирает ревьюверов открытым PR, у которых их меньше
+// целевого числа, от старых к новым. Каждый PR обрабатывается в своей
+// транзакции, поэтому ошибка по одному PR не мешает остальным.
+// Вызывается фоновой задачей на случай, если fillVacancies что-то упустил.
+func (uc *PullRequestUseCase) TopUpReviewers(ctx context.Context, now time.Time) error {
+	var understaffed []*entity.PullRequest
+
+	err := uc.txManager.WithTx(ctx, func(tx repository.Tx) error {
+		var err error
+		understaffed, err = tx.PullRequests().GetUnderstaffed(ctx)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("get understaffed prs: %w", err)
+	}
+
+	var errs []error
+	for _, candidate := range understaffed {
+		err := uc.txManager.WithTx(ctx, func(tx repository.Tx) error {
+			return topUpPR(ctx, tx, uc.selector, candidate.ID)
+		})
+		if err != nil {
+			errs = append(errs, fmt.Errorf("top up pr %s: %w", candidate.ID, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// topUpPR блокирует PR и добирает ему ревьюверов
+func topUpPR(ctx context.Context, tx repository.Tx, selector *service.ReviewerSelector, prID string) error {
+	pr, err := tx.PullRequests().GetByIDForUpdate(ctx, prID)
+	if err != nil {
+		return err
+	}
+
+	return topUp(ctx, tx, selector, pr)
+}
+
+// topUp добирает ревьюверов одному открытому PR, заблокированному
+// транзакцией. Отказавшиеся от PR повторно не назначаются.
+func topUp(ctx context.Context, tx repository.Tx, selector *service.ReviewerSelector, pr *entity.PullRequest) error {
+	// Могли смёржить или доукомплектовать параллельно
+	if pr.Status != entity.StatusOpen || pr.Shortfall() == 0 {
+		return nil
+	}
+
+	author, err := tx.Users().GetByID(ctx, pr.AuthorID)
+	if err != nil {
+		return fmt.Errorf("get author: %w", err)
+	}
+
+	team, policy, err := reviewTeam(ctx, tx, pr, author.TeamName)
+	if err != nil {
+		return err
+	}
+
+	decliners, err := tx.Declines().GetDecliners(ctx, pr.ID)
+	if err != nil {
+		return err
+	}
+
+	reviewers, err := selector.SelectAdditional(ctx, tx, service.SelectionRequest{
+		PullRequestID:   pr.ID,
+		TeamName:        team,
+		AuthorID:        pr.AuthorID,
+		ExcludeUserIDs:  decliners,
+		AssignedUserIDs: pr.AssignedReviewers,
+		ChangedFiles:    pr.ChangedFiles,
+		Labels:          pr.Labels,
+		Policy:          policy,
+	}, pr.Shortfall())
+	if selectionMiss(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("select reviewers: %w", err)
+	}
+
+	if len(reviewers) == 0 {
+		return nil
+	}
+
+	return assignReviewers(ctx, tx, pr, team, reviewers)
+}
+
+// selectionMiss сообщает, что подбор не нашёл подходящих ревьюверов.
+// PR с такой ошибкой остаётся ждать, а не прерывает транзакцию.
+func selectionMiss(err error) bool {
+	return errors.Is(err, repository.ErrNoCapacity) ||
+		errors.Is(err, repository.ErrOwnerUnavailable) ||
+		errors.Is(err, repository.ErrNoCandidate)
+}
+
+// buildQueue возвращает очередь ожидания с позициями внутри команды,
+// которая ревьюит PR, и оценкой времени ожидания
+func buildQueue(ctx context.Context, tx repository.Tx) ([]*entity.QueueEntry, error) {
+	awaiting, err := tx.PullRequests().GetAwaiting(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get awaiting prs: %w", err)
+	}
+
+	since := time.Now().Add(-queueRateWindow)
+	positions := make(map[string]int)
+	merged := make(map[string]int)
+
+	entries := make([]*entity.QueueEntry, 0, len(awaiting))
+	for _, pr := range awaiting {
+		author, err := tx.Users().GetByID(ctx, pr.AuthorID)
+		if err != nil {
+			return nil, fmt.Errorf("get author: %w", err)
+		}
+
+		team, _, err := reviewTeam(ctx, tx, pr, author.TeamName)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := merged[team]; !ok {
+			count, err := tx.Stats().CountMergedSince(ctx, team, since)
+			if err != nil {
+				return nil, err
+			}
+			merged[team] = count
+		}
+
+		positions[team]++
+		entry := &entity.QueueEntry{
+			PullRequestID: pr.ID,
+			TeamName:      team,
+			Position:      positions[team],
+			WaitingSince:  pr.CreatedAt,
+		}
+
+		if wait := entity.EstimateWait(entry.Position, merged[team], queueRateWindow); wait != nil {
+			seconds := int64(wait.Seconds())
+			entry.EstimatedWaitSeconds = &seconds
+		}
+
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
@@ -0,0 +1,236 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"reviewer-service/internal/domain/entity"
+	"reviewer-service/internal/domain/service"
+	"reviewer-service/internal/repository"
+)
+
+func TestProcessBacklog_UnstaffablePRStaysQueued(t *testing.T) {
+	ctx := context.Background()
+
+	// PR встал в очередь до того, как команда включила REQUIRE,
+	// а единственного владельца его путей назначить нельзя
+	queued := &entity.PullRequest{
+		ID:                "pr1",
+		AuthorID:          "author",
+		Status:            entity.StatusAwaitingReviewers,
+		RequiredReviewers: 2,
+		ChangedFiles:      []string{"api/handler.go"},
+	}
+
+	tx := &mockTx{
+		usersRepo: &mockUsersRepo{
+			getByIDFn: func(ctx context.Context, userID string) (*entity.User, error) {
+				if userID == "ghost" {
+					return nil, repository.ErrNotFound
+				}
+				return &entity.User{UserID: userID, TeamName: "backend", IsActive: true}, nil
+			},
+		},
+		prRepo: &mockPRRepo{
+			getAwaitingFn: func(ctx context.Context) ([]*entity.PullRequest, error) {
+				return []*entity.PullRequest{{ID: queued.ID}}, nil
+			},
+			getByIDFn: func(ctx context.Context, id string) (*entity.PullRequest, error) {
+				return queued, nil
+			},
+		},
+		policyRepo: &mockPolicyRepo{
+			getByTeamFn: func(ctx context.Context, teamName string) (*entity.TeamPolicy, error) {
+				policy := entity.DefaultTeamPolicy(teamName)
+				policy.OwnershipMode = entity.OwnershipRequire
+				return policy, nil
+			},
+		},
+		ownersRepo: &mockCodeOwnersRepo{content: "api/ @ghost"},
+	}
+
+	if err := processBacklog(ctx, tx, service.NewReviewerSelector()); err != nil {
+		t.Fatalf("processBacklog() error = %v, want nil", err)
+	}
+	if queued.Status != entity.StatusAwaitingReviewers {
+		t.Errorf("status = %v, want %v", queued.Status, entity.StatusAwaitingReviewers)
+	}
+}
+
+func TestProcessBacklog_KeepsManualReviewer(t *testing.T) {
+	ctx := context.Background()
+
+	// Пока PR ждал в очереди, на него вручную назначили ревьювера,
+	// а один из кандидатов от него отказался
+	queued := &entity.PullRequest{
+		ID:                "pr1",
+		AuthorID:          "author",
+		Status:            entity.StatusAwaitingReviewers,
+		RequiredReviewers: 2,
+		AssignedReviewers: []string{"manual"},
+	}
+
+	members := []*entity.User{
+		{UserID: "manual", TeamName: "backend", IsActive: true},
+		{UserID: "decliner", TeamName: "backend", IsActive: true},
+		{UserID: "free", TeamName: "backend", IsActive: true},
+	}
+
+	var assigned []string
+	stats := &mockStatsRepo{}
+	tx := &mockTx{
+		usersRepo: &mockUsersRepo{
+			getByIDFn: func(ctx context.Context, userID string) (*entity.User, error) {
+				return &entity.User{UserID: userID, TeamName: "backend", IsActive: true}, nil
+			},
+			getActiveFn: func(ctx context.Context, teamName, excludeID string) ([]*entity.User, error) {
+				return members, nil
+			},
+		},
+		prRepo: &mockPRRepo{
+			getAwaitingFn: func(ctx context.Context) ([]*entity.PullRequest, error) {
+				return []*entity.PullRequest{{ID: queued.ID}}, nil
+			},
+			getByIDFn: func(ctx context.Context, id string) (*entity.PullRequest, error) {
+				return queued, nil
+			},
+			assignFn: func(ctx context.Context, prID string, userIDs []string, source entity.ReviewerSource) error {
+				assigned = append(assigned, userIDs...)
+				return nil
+			},
+		},
+		policyRepo:  &mockPolicyRepo{},
+		statsRepo:   stats,
+		declineRepo: &mockDeclineRepo{decliners: []string{"decliner"}},
+	}
+
+	if err := processBacklog(ctx, tx, service.NewReviewerSelector()); err != nil {
+		t.Fatalf("processBacklog() error = %v", err)
+	}
+
+	if len(assigned) != 1 || assigned[0] != "free" {
+		t.Errorf("assigned = %v, want [free]", assigned)
+	}
+	if stats.increments["manual"] != 0 {
+		t.Errorf("manual reviewer counted again: %v", stats.increments)
+	}
+	if queued.Status != entity.StatusOpen {
+		t.Errorf("status = %v, want %v", queued.Status, entity.StatusOpen)
+	}
+}
+
+func TestPullRequestUseCase_TopUpReviewers_ContinuesAfterFailure(t *testing.T) {
+	ctx := context.Background()
+
+	// Первый PR загрузить не удаётся, второму не хватает одного ревьювера
+	understaffed := &entity.PullRequest{
+		ID:                "pr2",
+		AuthorID:          "author",
+		Status:            entity.StatusOpen,
+		RequiredReviewers: 2,
+		AssignedReviewers: []string{"busy"},
+	}
+	loadErr := errors.New("load failed")
+
+	var assigned []string
+	tx := &mockTx{
+		usersRepo: &mockUsersRepo{
+			getByIDFn: func(ctx context.Context, userID string) (*entity.User, error) {
+				return &entity.User{UserID: userID, TeamName: "backend", IsActive: true}, nil
+			},
+			getActiveFn: func(ctx context.Context, teamName, excludeID string) ([]*entity.User, error) {
+				return []*entity.User{
+					{UserID: "busy", TeamName: "backend", IsActive: true},
+					{UserID: "free", TeamName: "backend", IsActive: true},
+				}, nil
+			},
+		},
+		prRepo: &mockPRRepo{
+			getUnderstaffedFn: func(ctx context.Context) ([]*entity.PullRequest, error) {
+				return []*entity.PullRequest{{ID: "pr1"}, {ID: understaffed.ID}}, nil
+			},
+			getByIDFn: func(ctx context.Context, id string) (*entity.PullRequest, error) {
+				if id == "pr1" {
+					return nil, loadErr
+				}
+				return understaffed, nil
+			},
+			assignFn: func(ctx context.Context, prID string, userIDs []string, source entity.ReviewerSource) error {
+				assigned = append(assigned, userIDs...)
+				return nil
+			},
+		},
+		policyRepo: &mockPolicyRepo{},
+	}
+
+	txManager := &mockTxManager{
+		withTxFn: func(ctx context.Context, fn func(repository.Tx) error) error {
+			return fn(tx)
+		},
+	}
+	uc := NewPullRequestUseCase(txManager, service.NewReviewerSelector())
+
+	err := uc.TopUpReviewers(ctx, time.Now())
+	if !errors.Is(err, loadErr) {
+		t.Errorf("TopUpReviewers() error = %v, want %v", err, loadErr)
+	}
+	if len(assigned) != 1 || assigned[0] != "free" {
+		t.Errorf("assigned = %v, want [free]", assigned)
+	}
+}
+
+func TestUserUseCase_SetActive_TopsUpUnderstaffed(t *testing.T) {
+	ctx := context.Background()
+
+	understaffed := &entity.PullRequest{
+		ID:                "pr1",
+		AuthorID:          "author",
+		Status:            entity.StatusOpen,
+		RequiredReviewers: 2,
+		AssignedReviewers: []string{"busy"},
+	}
+
+	var assigned []string
+	tx := &mockTx{
+		usersRepo: &mockUsersRepo{
+			getByIDFn: func(ctx context.Context, userID string) (*entity.User, error) {
+				return &entity.User{UserID: userID, TeamName: "backend", IsActive: true}, nil
+			},
+			getActiveFn: func(ctx context.Context, teamName, excludeID string) ([]*entity.User, error) {
+				return []*entity.User{
+					{UserID: "busy", TeamName: "backend", IsActive: true},
+					{UserID: "back", TeamName: "backend", IsActive: true},
+				}, nil
+			},
+		},
+		prRepo: &mockPRRepo{
+			getUnderstaffedFn: func(ctx context.Context) ([]*entity.PullRequest, error) {
+				return []*entity.PullRequest{understaffed}, nil
+			},
+			getByIDFn: func(ctx context.Context, id string) (*entity.PullRequest, error) {
+				return understaffed, nil
+			},
+			assignFn: func(ctx context.Context, prID string, userIDs []string, source entity.ReviewerSource) error {
+				assigned = append(assigned, userIDs...)
+				return nil
+			},
+		},
+		policyRepo: &mockPolicyRepo{},
+	}
+
+	txManager := &mockTxManager{
+		withTxFn: func(ctx context.Context, fn func(repository.Tx) error) error {
+			return fn(tx)
+		},
+	}
+	uc := NewUserUseCase(txManager, service.NewReviewerSelector())
+
+	if _, err := uc.SetActive(ctx, "back", true); err != nil {
+		t.Fatalf("SetActive() error = %v", err)
+	}
+	if len(assigned) != 1 || assigned[0] != "back" {
+		t.Errorf("assigned = %v, want [back]", assigned)
+	}
+}
@@ -0,0 +1,65 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"reviewer-service/internal/domain/entity"
+	"reviewer-service/internal/domain/service"
+	"reviewer-service/internal/repository"
+)
+
+// SetCodeOwners проверяет и сохраняет файл владения команды.
+// Возвращает разобранные правила.
+func (uc *TeamUseCase) SetCodeOwners(
+	ctx context.Context,
+	file *entity.CodeOwnersFile,
+) ([]entity.CodeOwnersRule, error) {
+	codeOwners, err := service.ParseCodeOwners(file.Content)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", repository.ErrInvalidCodeOwners, err)
+	}
+
+	err = uc.txManager.WithTx(ctx, func(tx repository.Tx) error {
+		if err := ensureTeamExists(ctx, tx, file.TeamName); err != nil {
+			return err
+		}
+
+		return tx.CodeOwners().Upsert(ctx, file)
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return codeOwners.Rules, nil
+}
+
+// GetCodeOwners возвращает файл владения команды и его разобранные правила
+func (uc *TeamUseCase) GetCodeOwners(
+	ctx context.Context,
+	teamName string,
+) (*entity.CodeOwnersFile, []entity.CodeOwnersRule, error) {
+	var result *entity.CodeOwnersFile
+
+	err := uc.txManager.WithTx(ctx, func(tx repository.Tx) error {
+		file, err := tx.CodeOwners().GetByTeam(ctx, teamName)
+		if err != nil {
+			return err
+		}
+
+		result = file
+		return nil
+	})
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	codeOwners, err := service.ParseCodeOwners(result.Content)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return result, codeOwners.Rules, nil
+}
@@ -0,0 +1,136 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"reviewer-service/internal/domain/entity"
+	"reviewer-service/internal/repository"
+)
+
+// BulkDeactivate выключает пользователей и в той же транзакции передаёт
+// их открытые ревью активным коллегам. Ревью, для которых замены нет
+// или на которых пользователь запросил изменения, остаются за ним
+// и попадают в отчёт.
+func (uc *UserUseCase) BulkDeactivate(ctx context.Context, userIDs []string) (*entity.BulkDeactivation, error) {
+	ids := uniqueIDs(userIDs)
+	var result *entity.BulkDeactivation
+
+	err := uc.txManager.WithTx(ctx, func(tx repository.Tx) error {
+		// Заодно проверяет, что все пользователи существуют
+		if err := checkMinActiveMembers(ctx, tx, ids); err != nil {
+			return err
+		}
+
+		// Сначала выключаем всех, чтобы замены не выбирались среди них же
+		if err := tx.Users().BulkDeactivate(ctx, ids); err != nil {
+			return err
+		}
+
+		prs, err := tx.PullRequests().GetOpenByReviewers(ctx, ids)
+		if err != nil {
+			return err
+		}
+
+		result = &entity.BulkDeactivation{
+			Deactivated:  ids,
+			PullRequests: []*entity.PRReassignment{},
+		}
+
+		seen := make(map[string]bool)
+		for _, open := range prs {
+			if seen[open.ID] {
+				continue
+			}
+			seen[open.ID] = true
+
+			report, err := uc.reassignDeactivated(ctx, tx, open.ID, ids)
+			if err != nil {
+				return err
+			}
+			if report != nil {
+				result.PullRequests = append(result.PullRequests, report)
+			}
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// reassignDeactivated заменяет на PR всех ревьюверов из userIDs.
+// Возвращает nil, если PR уже не открыт или их на нём нет.
+func (uc *UserUseCase) reassignDeactivated(
+	ctx context.Context,
+	tx repository.Tx,
+	prID string,
+	userIDs []string,
+) (*entity.PRReassignment, error) {
+	pr, err := tx.PullRequests().GetByIDForUpdate(ctx, prID)
+	if err != nil {
+		return nil, err
+	}
+	if !pr.InReview() {
+		return nil, nil
+	}
+
+	report := &entity.PRReassignment{
+		PullRequestID: pr.ID,
+		Reassigned:    []entity.ReviewerSwap{},
+		NotReassigned: []string{},
+		Blocking:      []string{},
+	}
+
+	for _, userID := range userIDs {
+		if !pr.HasReviewer(userID) {
+			continue
+		}
+
+		blocking, err := isBlocking(ctx, tx, pr.ID, userID)
+		if err != nil {
+			return nil, err
+		}
+		if blocking {
+			report.Blocking = append(report.Blocking, userID)
+			continue
+		}
+
+		newReviewer, err := replaceReviewer(ctx, tx, uc.selector, pr, userID)
+		if selectionMiss(err) {
+			report.NotReassigned = append(report.NotReassigned, userID)
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("reassign pr %s: %w", pr.ID, err)
+		}
+
+		report.Reassigned = append(report.Reassigned, entity.ReviewerSwap{
+			OldUserID: userID,
+			NewUserID: newReviewer.UserID,
+		})
+	}
+
+	if len(report.Reassigned) == 0 && len(report.NotReassigned) == 0 && len(report.Blocking) == 0 {
+		return nil, nil
+	}
+
+	return report, nil
+}
+
+func uniqueIDs(ids []string) []string {
+	seen := make(map[string]bool, len(ids))
+	result := make([]string, 0, len(ids))
+	for _, id := range ids {
+		if id == "" || seen[id] {
+			continue
+		}
+		seen[id] = true
+		result = append(result, id)
+	}
+	return result
+}
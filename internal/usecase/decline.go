@@ -0,0 +1,108 @@
+package usecase
+
+import (
+	"context"
+
+	"reviewer-service/internal/domain/entity"
+	"reviewer-service/internal/repository"
+)
+
+// Decline фиксирует отказ ревьювера от ревью с причиной и заменяет его
+// так же, как Reassign. Если замены нет, ревьювер всё равно снимается,
+// а место доберёт TopUpReviewers. Обязательный ревьювер и ревьювер,
+// запросивший изменения, отказаться не могут.
+func (uc *PullRequestUseCase) Decline(
+	ctx context.Context,
+	prID, userID string,
+	reason entity.DeclineReason,
+	comment string,
+) (*entity.PullRequest, *entity.ReviewDecline, error) {
+	if !reason.Valid() {
+		return nil, nil, repository.ErrInvalidDeclineReason
+	}
+
+	var result *entity.PullRequest
+	var decline *entity.ReviewDecline
+
+	err := uc.txManager.WithTx(ctx, func(tx repository.Tx) error {
+		pr, err := lockOpenPR(ctx, tx, prID)
+		if err != nil {
+			return err
+		}
+
+		if !pr.HasReviewer(userID) {
+			return repository.ErrNotAssigned
+		}
+
+		if pr.IsMandatoryReviewer(userID) {
+			return repository.ErrMandatoryReviewer
+		}
+
+		if err := checkNotBlocking(ctx, tx, pr.ID, userID, false); err != nil {
+			return err
+		}
+
+		// Замена не выбирает тех, кто уже отказался от этого PR
+		decline = &entity.ReviewDecline{
+			PullRequestID: pr.ID,
+			UserID:        userID,
+			Reason:        reason,
+			Comment:       comment,
+		}
+
+		newReviewer, err := replaceReviewer(ctx, tx, uc.selector, pr, userID)
+		switch {
+		case err == nil:
+			decline.ReplacedBy = newReviewer.UserID
+		case selectionMiss(err):
+			// Замены нет - снимаем без неё, целевое число ревьюверов не меняется
+			if err := dropReviewer(ctx, tx, pr, userID); err != nil {
+				return err
+			}
+		default:
+			return err
+		}
+
+		if err := tx.Declines().Create(ctx, decline); err != nil {
+			return err
+		}
+
+		// У отказавшегося освободилось место
+		if err := processBacklog(ctx, tx, uc.selector); err != nil {
+			return err
+		}
+
+		result = pr
+		return nil
+	})
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return result, decline, nil
+}
+
+// GetDeclines возвращает отказы ревьюверов по PR
+func (uc *PullRequestUseCase) GetDeclines(ctx context.Context, prID string) ([]*entity.ReviewDecline, error) {
+	var result []*entity.ReviewDecline
+
+	err := uc.txManager.WithTx(ctx, func(tx repository.Tx) error {
+		if _, err := tx.PullRequests().GetByID(ctx, prID); err != nil {
+			return err
+		}
+
+		declines, err := tx.Declines().GetByPR(ctx, prID)
+		if err != nil {
+			return err
+		}
+		result = declines
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
@@ -0,0 +1,69 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"reviewer-service/internal/domain/entity"
+	"reviewer-service/internal/domain/service"
+	"reviewer-service/internal/repository"
+)
+
+func TestPullRequestUseCase_Decline_WithoutReplacement(t *testing.T) {
+	ctx := context.Background()
+
+	// Кроме отказавшегося в команде никого нет
+	pr := &entity.PullRequest{
+		ID:                "pr1",
+		AuthorID:          "author",
+		Status:            entity.StatusOpen,
+		RequiredReviewers: 2,
+		AssignedReviewers: []string{"r1", "r2"},
+	}
+
+	stats := &mockStatsRepo{}
+	declines := &mockDeclineRepo{}
+	txManager := &mockTxManager{
+		withTxFn: func(ctx context.Context, fn func(repository.Tx) error) error {
+			return fn(&mockTx{
+				usersRepo: &mockUsersRepo{
+					getByIDFn: func(ctx context.Context, userID string) (*entity.User, error) {
+						return &entity.User{UserID: userID, TeamName: "backend", IsActive: true}, nil
+					},
+				},
+				prRepo: &mockPRRepo{
+					getByIDFn: func(ctx context.Context, id string) (*entity.PullRequest, error) {
+						return pr, nil
+					},
+				},
+				policyRepo:  &mockPolicyRepo{},
+				statsRepo:   stats,
+				declineRepo: declines,
+			})
+		},
+	}
+
+	uc := NewPullRequestUseCase(txManager, service.NewReviewerSelector())
+
+	result, decline, err := uc.Decline(ctx, "pr1", "r2", entity.DeclineOverloaded, "")
+	if err != nil {
+		t.Fatalf("Decline() error = %v", err)
+	}
+
+	if decline.ReplacedBy != "" {
+		t.Errorf("ReplacedBy = %q, want empty", decline.ReplacedBy)
+	}
+	if len(declines.created) != 1 {
+		t.Errorf("declines recorded = %d, want 1", len(declines.created))
+	}
+	if result.HasReviewer("r2") {
+		t.Errorf("reviewers = %v, r2 should be removed", result.AssignedReviewers)
+	}
+	if stats.decrements["r2"] != 1 {
+		t.Errorf("decrements = %v, want r2 released", stats.decrements)
+	}
+	// Место остаётся за PR - его доберёт фоновая задача
+	if result.RequiredReviewers != 2 {
+		t.Errorf("RequiredReviewers = %d, want 2", result.RequiredReviewers)
+	}
+}
@@ -0,0 +1,196 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"reviewer-service/internal/domain/entity"
+	"reviewer-service/internal/domain/service"
+	"reviewer-service/internal/repository"
+)
+
+// Notifier доставляет уведомления об эскалациях ревьюверам и лидам команд
+type Notifier interface {
+	Notify(ctx context.Context, escalation *entity.ReviewEscalation) error
+}
+
+// SetNotifier задаёт доставку уведомлений об эскалациях.
+// Без неё эскалации только записываются в журнал.
+func (uc *PullRequestUseCase) SetNotifier(notifier Notifier) {
+	uc.notifier = notifier
+}
+
+// ApplyEscalations проходит по назначениям на открытые PR и для тех, кто
+// не уложился в SLA команды автора, выполняет очередной шаг эскалации.
+// За один проход по назначению выполняется не больше одного шага.
+// Каждое назначение обрабатывается в своей транзакции: ошибка по одному
+// не мешает остальным и возвращается вместе с остальными ошибками.
+// Вызывается фоновой задачей.
+func (uc *PullRequestUseCase) ApplyEscalations(ctx context.Context, now time.Time) error {
+	var assignments []*entity.ReviewAssignment
+
+	err := uc.txManager.WithTx(ctx, func(tx repository.Tx) error {
+		var err error
+		assignments, err = tx.PullRequests().GetOpenAssignments(ctx)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("get open assignments: %w", err)
+	}
+
+	policies := make(map[string]*entity.TeamPolicy)
+	var errs []error
+
+	for _, a := range assignments {
+		var escalation *entity.ReviewEscalation
+
+		err := uc.txManager.WithTx(ctx, func(tx repository.Tx) error {
+			escalation = nil
+
+			policy, ok := policies[a.TeamName]
+			if !ok {
+				var err error
+				if policy, err = service.LoadTeamPolicy(ctx, tx, a.TeamName); err != nil {
+					return err
+				}
+				policies[a.TeamName] = policy
+			}
+
+			if policy.ReviewSLAHours == 0 {
+				return nil
+			}
+
+			reviewer, err := tx.Users().GetByID(ctx, a.UserID)
+			if err != nil {
+				return err
+			}
+
+			action, due := policy.DueEscalation(reviewer.WorkingTimeBetween(a.SLAStart, now), a.EscalationStep)
+			if !due {
+				return nil
+			}
+
+			escalation, err = uc.escalate(ctx, tx, a, action, policy)
+			if err != nil {
+				return err
+			}
+
+			// У снятого ревьювера освободилось место
+			if escalation != nil && escalation.ReplacedBy != "" {
+				return processBacklog(ctx, tx, uc.selector)
+			}
+			return nil
+		})
+		if err != nil {
+			errs = append(errs, fmt.Errorf("escalate %s on pr %s: %w", a.UserID, a.PullRequestID, err))
+			continue
+		}
+
+		// Уведомляем только о зафиксированных эскалациях
+		if escalation != nil && escalation.RecipientID != "" && uc.notifier != nil {
+			if err := uc.notifier.Notify(ctx, escalation); err != nil {
+				errs = append(errs, fmt.Errorf("notify %s: %w", escalation.RecipientID, err))
+			}
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// escalate выполняет шаг эскалации и записывает его в журнал.
+// Возвращает nil, если назначение уже не актуально.
+func (uc *PullRequestUseCase) escalate(
+	ctx context.Context,
+	tx repository.Tx,
+	a *entity.ReviewAssignment,
+	action entity.EscalationAction,
+	policy *entity.TeamPolicy,
+) (*entity.ReviewEscalation, error) {
+	pr, err := tx.PullRequests().GetByIDForUpdate(ctx, a.PullRequestID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Могли смёржить или переназначить параллельно
+	if pr.Status != entity.StatusOpen || !pr.HasReviewer(a.UserID) {
+		return nil, nil
+	}
+
+	// Шаг читался без блокировки - его мог уже сделать параллельный запуск
+	step, err := tx.PullRequests().GetEscalationStep(ctx, pr.ID, a.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if step != a.EscalationStep {
+		return nil, nil
+	}
+
+	escalation := &entity.ReviewEscalation{
+		PullRequestID: pr.ID,
+		UserID:        a.UserID,
+		Action:        action,
+		Step:          a.EscalationStep + 1,
+	}
+
+	switch action {
+	case entity.EscalationNotify:
+		escalation.RecipientID = a.UserID
+	case entity.EscalationAlertLead:
+		escalation.RecipientID = policy.TeamLeadID
+	case entity.EscalationReassign:
+		// Обязательного ревьювера автоматически не меняем - переходим к следующему шагу
+		if pr.IsMandatoryReviewer(a.UserID) {
+			break
+		}
+
+		newReviewer, err := replaceReviewer(ctx, tx, uc.selector, pr, a.UserID)
+		if selectionMiss(err) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		escalation.ReplacedBy = newReviewer.UserID
+	}
+
+	if err := tx.Escalations().Create(ctx, escalation); err != nil {
+		return nil, err
+	}
+
+	// У нового ревьювера своё назначение и свой отсчёт SLA
+	if escalation.ReplacedBy != "" {
+		return escalation, nil
+	}
+
+	if err := tx.PullRequests().SetEscalationStep(ctx, pr.ID, a.UserID, escalation.Step); err != nil {
+		return nil, err
+	}
+
+	return escalation, nil
+}
+
+// GetEscalations возвращает журнал эскалаций по PR
+func (uc *PullRequestUseCase) GetEscalations(ctx context.Context, prID string) ([]*entity.ReviewEscalation, error) {
+	var result []*entity.ReviewEscalation
+
+	err := uc.txManager.WithTx(ctx, func(tx repository.Tx) error {
+		if _, err := tx.PullRequests().GetByID(ctx, prID); err != nil {
+			return err
+		}
+
+		escalations, err := tx.Escalations().GetByPR(ctx, prID)
+		if err != nil {
+			return err
+		}
+		result = escalations
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
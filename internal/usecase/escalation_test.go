@@ -0,0 +1,134 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"reviewer-service/internal/domain/entity"
+	"reviewer-service/internal/domain/service"
+	"reviewer-service/internal/repository"
+)
+
+type mockNotifier struct {
+	sent []*entity.ReviewEscalation
+}
+
+func (m *mockNotifier) Notify(ctx context.Context, escalation *entity.ReviewEscalation) error {
+	m.sent = append(m.sent, escalation)
+	return nil
+}
+
+func TestPullRequestUseCase_ApplyEscalations_ContinuesAfterFailure(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+
+	// Первое назначение обработать не удаётся, второе просрочено
+	assignments := []*entity.ReviewAssignment{
+		{PullRequestID: "pr1", UserID: "broken", TeamName: "backend", SLAStart: now.Add(-48 * time.Hour)},
+		{PullRequestID: "pr2", UserID: "slow", TeamName: "backend", SLAStart: now.Add(-48 * time.Hour)},
+	}
+	loadErr := errors.New("load failed")
+
+	escalations := &mockEscalationRepo{}
+	tx := &mockTx{
+		usersRepo: &mockUsersRepo{
+			getByIDFn: func(ctx context.Context, userID string) (*entity.User, error) {
+				if userID == "broken" {
+					return nil, loadErr
+				}
+				return &entity.User{UserID: userID, TeamName: "backend", IsActive: true}, nil
+			},
+		},
+		prRepo: &mockPRRepo{
+			getOpenAssignmentsFn: func(ctx context.Context) ([]*entity.ReviewAssignment, error) {
+				return assignments, nil
+			},
+			getByIDFn: func(ctx context.Context, id string) (*entity.PullRequest, error) {
+				return &entity.PullRequest{ID: id, Status: entity.StatusOpen, AssignedReviewers: []string{"broken", "slow"}}, nil
+			},
+		},
+		policyRepo: &mockPolicyRepo{
+			getByTeamFn: func(ctx context.Context, teamName string) (*entity.TeamPolicy, error) {
+				policy := entity.DefaultTeamPolicy(teamName)
+				policy.ReviewSLAHours = 24
+				return policy, nil
+			},
+		},
+		escalationRepo: escalations,
+	}
+
+	txManager := &mockTxManager{
+		withTxFn: func(ctx context.Context, fn func(repository.Tx) error) error {
+			return fn(tx)
+		},
+	}
+	notifier := &mockNotifier{}
+	uc := NewPullRequestUseCase(txManager, service.NewReviewerSelector())
+	uc.SetNotifier(notifier)
+
+	err := uc.ApplyEscalations(ctx, now)
+	if !errors.Is(err, loadErr) {
+		t.Errorf("ApplyEscalations() error = %v, want %v", err, loadErr)
+	}
+
+	if len(escalations.created) != 1 || escalations.created[0].UserID != "slow" ||
+		escalations.created[0].Action != entity.EscalationNotify {
+		t.Fatalf("escalations = %v, want NOTIFY for slow", escalations.created)
+	}
+	if len(notifier.sent) != 1 || notifier.sent[0].RecipientID != "slow" {
+		t.Errorf("notifications = %v, want one to slow", notifier.sent)
+	}
+}
+
+func TestPullRequestUseCase_ApplyEscalations_SkipsStaleStep(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+
+	// Назначение прочитано до того, как параллельный запуск сделал шаг
+	assignments := []*entity.ReviewAssignment{
+		{PullRequestID: "pr1", UserID: "slow", TeamName: "backend", SLAStart: now.Add(-48 * time.Hour)},
+	}
+
+	escalations := &mockEscalationRepo{}
+	tx := &mockTx{
+		usersRepo: &mockUsersRepo{
+			getByIDFn: func(ctx context.Context, userID string) (*entity.User, error) {
+				return &entity.User{UserID: userID, TeamName: "backend", IsActive: true}, nil
+			},
+		},
+		prRepo: &mockPRRepo{
+			getOpenAssignmentsFn: func(ctx context.Context) ([]*entity.ReviewAssignment, error) {
+				return assignments, nil
+			},
+			getByIDFn: func(ctx context.Context, id string) (*entity.PullRequest, error) {
+				return &entity.PullRequest{ID: id, Status: entity.StatusOpen, AssignedReviewers: []string{"slow"}}, nil
+			},
+			escalationSteps: map[string]int{"slow": 1},
+		},
+		policyRepo: &mockPolicyRepo{
+			getByTeamFn: func(ctx context.Context, teamName string) (*entity.TeamPolicy, error) {
+				policy := entity.DefaultTeamPolicy(teamName)
+				policy.ReviewSLAHours = 24
+				return policy, nil
+			},
+		},
+		escalationRepo: escalations,
+	}
+
+	txManager := &mockTxManager{
+		withTxFn: func(ctx context.Context, fn func(repository.Tx) error) error {
+			return fn(tx)
+		},
+	}
+	uc := NewPullRequestUseCase(txManager, service.NewReviewerSelector())
+
+	if err := uc.ApplyEscalations(ctx, now); err != nil {
+		t.Fatalf("ApplyEscalations() error = %v", err)
+	}
+
+	if len(escalations.created) != 0 {
+		t.Errorf("escalations = %v, want none for already escalated step", escalations.created)
+	}
+}
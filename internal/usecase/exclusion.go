@@ -0,0 +1,72 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"reviewer-service/internal/domain/entity"
+	"reviewer-service/internal/repository"
+)
+
+// AddExclusion запрещает ReviewerID ревьюить PR AuthorID (а при BothWays - и наоборот).
+// Уже назначенные ревью правило не снимает.
+func (uc *UserUseCase) AddExclusion(
+	ctx context.Context,
+	exclusion *entity.ReviewExclusion,
+) (*entity.ReviewExclusion, error) {
+	if exclusion.AuthorID == exclusion.ReviewerID {
+		return nil, fmt.Errorf("%w: author and reviewer must differ", repository.ErrInvalidExclusion)
+	}
+	if exclusion.ExpiresAt != nil && !exclusion.ExpiresAt.After(time.Now()) {
+		return nil, fmt.Errorf("%w: expires_at must be in the future", repository.ErrInvalidExclusion)
+	}
+
+	err := uc.txManager.WithTx(ctx, func(tx repository.Tx) error {
+		for _, id := range []string{exclusion.AuthorID, exclusion.ReviewerID} {
+			if _, err := tx.Users().GetByID(ctx, id); err != nil {
+				return err
+			}
+		}
+
+		return tx.Exclusions().Create(ctx, exclusion)
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return exclusion, nil
+}
+
+// GetExclusions возвращает правила, в которых участвует пользователь, включая истёкшие
+func (uc *UserUseCase) GetExclusions(ctx context.Context, userID string) ([]*entity.ReviewExclusion, error) {
+	var result []*entity.ReviewExclusion
+
+	err := uc.txManager.WithTx(ctx, func(tx repository.Tx) error {
+		if _, err := tx.Users().GetByID(ctx, userID); err != nil {
+			return err
+		}
+
+		exclusions, err := tx.Exclusions().GetByUser(ctx, userID)
+		if err != nil {
+			return err
+		}
+
+		result = exclusions
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// RemoveExclusion удаляет правило исключения
+func (uc *UserUseCase) RemoveExclusion(ctx context.Context, exclusionID int64) error {
+	return uc.txManager.WithTx(ctx, func(tx repository.Tx) error {
+		return tx.Exclusions().Delete(ctx, exclusionID)
+	})
+}
@@ -0,0 +1,166 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"reviewer-service/internal/domain/entity"
+	"reviewer-service/internal/repository"
+)
+
+// Close закрывает PR без merge (идемпотентная операция).
+// Ревьюверы остаются на PR, но нагрузка с них снимается сразу.
+func (uc *PullRequestUseCase) Close(ctx context.Context, prID string) (*entity.PullRequest, error) {
+	var result *entity.PullRequest
+
+	err := uc.txManager.WithTx(ctx, func(tx repository.Tx) error {
+		pr, err := tx.PullRequests().GetByIDForUpdate(ctx, prID)
+		if err != nil {
+			return err
+		}
+
+		if pr.Status == entity.StatusClosed {
+			result = pr
+			return nil
+		}
+		if pr.Status == entity.StatusMerged {
+			return repository.ErrPRMerged
+		}
+
+		if err := pr.TransitionTo(entity.StatusClosed, time.Now()); err != nil {
+			return err
+		}
+		if err := tx.PullRequests().Update(ctx, pr); err != nil {
+			return err
+		}
+
+		// Освободилось место - разбираем очередь
+		if err := processBacklog(ctx, tx, uc.selector); err != nil {
+			return err
+		}
+
+		result = pr
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// Reopen открывает закрытый PR заново (идемпотентная операция).
+// Активные ревьюверы возвращаются на PR, неактивные заменяются,
+// а если замены нет - снимаются, и место сразу добирается из свободных.
+// Закрытый черновик остаётся черновиком.
+func (uc *PullRequestUseCase) Reopen(ctx context.Context, prID string) (*entity.PullRequest, error) {
+	var result *entity.PullRequest
+
+	err := uc.txManager.WithTx(ctx, func(tx repository.Tx) error {
+		pr, err := tx.PullRequests().GetByIDForUpdate(ctx, prID)
+		if err != nil {
+			return err
+		}
+
+		if pr.Status == entity.StatusMerged {
+			return repository.ErrPRMerged
+		}
+		if pr.Status != entity.StatusClosed {
+			result = pr
+			return nil
+		}
+
+		if pr.ReadyAt == nil {
+			if err := pr.TransitionTo(entity.StatusDraft, time.Now()); err != nil {
+				return err
+			}
+			if err := tx.PullRequests().Update(ctx, pr); err != nil {
+				return err
+			}
+
+			result = pr
+			return nil
+		}
+
+		// Время, пока PR был закрыт, не идёт в SLA ревью
+		if pr.ClosedAt != nil {
+			if err := tx.PullRequests().ResumeReviews(ctx, pr.ID, *pr.ClosedAt); err != nil {
+				return err
+			}
+		}
+
+		if err := uc.restoreReviewers(ctx, tx, pr); err != nil {
+			return err
+		}
+
+		// Без ревьюверов PR встаёт в очередь, как при создании
+		next := entity.StatusOpen
+		if len(pr.AssignedReviewers) == 0 && pr.RequiredReviewers > 0 {
+			next = entity.StatusAwaitingReviewers
+		}
+		if err := pr.TransitionTo(next, time.Now()); err != nil {
+			return err
+		}
+		if err := tx.PullRequests().Update(ctx, pr); err != nil {
+			return err
+		}
+
+		// Открытому PR добираем недостающих, PR без ревьюверов
+		// разбирается вместе с остальной очередью
+		if err := topUpPR(ctx, tx, uc.selector, pr.ID); err != nil {
+			return err
+		}
+		if err := processBacklog(ctx, tx, uc.selector); err != nil {
+			return err
+		}
+
+		// Ревьюверов могли назначить - возвращаем свежий PR
+		result, err = tx.PullRequests().GetByID(ctx, pr.ID)
+		return err
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// restoreReviewers заменяет на переоткрываемом PR ревьюверов,
+// которые за время закрытия стали неактивными. Запросившие изменения
+// остаются на PR, чтобы не снять блокировку merge. Снятые без замены
+// остаются в статистике назначений.
+func (uc *PullRequestUseCase) restoreReviewers(ctx context.Context, tx repository.Tx, pr *entity.PullRequest) error {
+	for _, userID := range append([]string(nil), pr.AssignedReviewers...) {
+		user, err := tx.Users().GetByID(ctx, userID)
+		if err != nil {
+			return err
+		}
+		if user.IsActive {
+			continue
+		}
+
+		blocking, err := isBlocking(ctx, tx, pr.ID, userID)
+		if err != nil {
+			return err
+		}
+		if blocking {
+			continue
+		}
+
+		_, err = replaceReviewer(ctx, tx, uc.selector, pr, userID)
+		if err == nil {
+			continue
+		}
+		if !selectionMiss(err) {
+			return err
+		}
+
+		if err := unassignReviewer(ctx, tx, pr, userID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,448 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"reviewer-service/internal/domain/entity"
+	"reviewer-service/internal/domain/service"
+	"reviewer-service/internal/repository"
+)
+
+func TestPullRequestUseCase_Close(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name    string
+		status  entity.PRStatus
+		wantErr error
+	}{
+		{"open", entity.StatusOpen, nil},
+		{"awaiting reviewers", entity.StatusAwaitingReviewers, nil},
+		{"already closed", entity.StatusClosed, nil},
+		{"merged", entity.StatusMerged, repository.ErrPRMerged},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			txManager := &mockTxManager{
+				withTxFn: func(ctx context.Context, fn func(repository.Tx) error) error {
+					return fn(&mockTx{
+						prRepo: &mockPRRepo{
+							getByIDFn: func(ctx context.Context, id string) (*entity.PullRequest, error) {
+								return &entity.PullRequest{ID: id, AuthorID: "author", Status: tt.status}, nil
+							},
+						},
+					})
+				},
+			}
+
+			uc := NewPullRequestUseCase(txManager, service.NewReviewerSelector())
+
+			pr, err := uc.Close(ctx, "pr1")
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Close() error = %v, want %v", err, tt.wantErr)
+			}
+			if err == nil && pr.Status != entity.StatusClosed {
+				t.Errorf("Close() status = %v, want CLOSED", pr.Status)
+			}
+		})
+	}
+}
+
+func TestPullRequestUseCase_Reopen_Merged(t *testing.T) {
+	txManager := &mockTxManager{
+		withTxFn: func(ctx context.Context, fn func(repository.Tx) error) error {
+			return fn(&mockTx{
+				prRepo: &mockPRRepo{
+					getByIDFn: func(ctx context.Context, id string) (*entity.PullRequest, error) {
+						return &entity.PullRequest{ID: id, Status: entity.StatusMerged}, nil
+					},
+				},
+			})
+		},
+	}
+
+	uc := NewPullRequestUseCase(txManager, service.NewReviewerSelector())
+
+	if _, err := uc.Reopen(context.Background(), "pr1"); !errors.Is(err, repository.ErrPRMerged) {
+		t.Errorf("Reopen() error = %v, want %v", err, repository.ErrPRMerged)
+	}
+}
+
+func TestPullRequestUseCase_CreatePR_Draft(t *testing.T) {
+	txManager := &mockTxManager{
+		withTxFn: func(ctx context.Context, fn func(repository.Tx) error) error {
+			return fn(&mockTx{
+				usersRepo: &mockUsersRepo{
+					getByIDFn: func(ctx context.Context, userID string) (*entity.User, error) {
+						return &entity.User{UserID: userID, TeamName: "backend", IsActive: true}, nil
+					},
+				},
+				prRepo: &mockPRRepo{},
+			})
+		},
+	}
+
+	uc := NewPullRequestUseCase(txManager, service.NewReviewerSelector())
+
+	pr, err := uc.CreatePR(context.Background(), &entity.PullRequest{
+		ID:       "pr1",
+		AuthorID: "author",
+		Status:   entity.StatusDraft,
+	})
+	if err != nil {
+		t.Fatalf("CreatePR() error = %v", err)
+	}
+	if pr.Status != entity.StatusDraft || pr.ReadyAt != nil {
+		t.Errorf("CreatePR() status = %v, readyAt = %v, want DRAFT without readyAt", pr.Status, pr.ReadyAt)
+	}
+	if len(pr.AssignedReviewers) != 0 || pr.RequiredReviewers != 0 {
+		t.Errorf("CreatePR() draft got reviewers %v (required %d)", pr.AssignedReviewers, pr.RequiredReviewers)
+	}
+}
+
+func TestPullRequestUseCase_MarkReady_Status(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  entity.PRStatus
+		wantErr error
+	}{
+		{"already open", entity.StatusOpen, nil},
+		{"awaiting reviewers", entity.StatusAwaitingReviewers, nil},
+		{"merged", entity.StatusMerged, repository.ErrPRMerged},
+		{"closed", entity.StatusClosed, repository.ErrPRClosed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			txManager := &mockTxManager{
+				withTxFn: func(ctx context.Context, fn func(repository.Tx) error) error {
+					return fn(&mockTx{
+						prRepo: &mockPRRepo{
+							getByIDFn: func(ctx context.Context, id string) (*entity.PullRequest, error) {
+								return &entity.PullRequest{ID: id, Status: tt.status}, nil
+							},
+						},
+					})
+				},
+			}
+
+			uc := NewPullRequestUseCase(txManager, service.NewReviewerSelector())
+
+			pr, err := uc.MarkReady(context.Background(), "pr1")
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("MarkReady() error = %v, want %v", err, tt.wantErr)
+			}
+			if err == nil && pr.Status != tt.status {
+				t.Errorf("MarkReady() status = %v, want %v", pr.Status, tt.status)
+			}
+		})
+	}
+}
+
+func TestPullRequestUseCase_Close_ReleasesLoad(t *testing.T) {
+	ctx := context.Background()
+
+	// У r1 занято единственное место - PR из очереди ждёт его
+	closing := &entity.PullRequest{
+		ID:                "pr1",
+		AuthorID:          "author",
+		Status:            entity.StatusOpen,
+		RequiredReviewers: 1,
+		AssignedReviewers: []string{"r1"},
+	}
+	queued := &entity.PullRequest{
+		ID:                "pr2",
+		AuthorID:          "author",
+		Status:            entity.StatusAwaitingReviewers,
+		RequiredReviewers: 1,
+	}
+	prs := map[string]*entity.PullRequest{closing.ID: closing, queued.ID: queued}
+
+	stats := &mockStatsRepo{workload: map[string]int{"r1": 1}}
+	assigned := make(map[string][]string)
+
+	txManager := &mockTxManager{
+		withTxFn: func(ctx context.Context, fn func(repository.Tx) error) error {
+			return fn(&mockTx{
+				usersRepo: &mockUsersRepo{
+					getByIDFn: func(ctx context.Context, userID string) (*entity.User, error) {
+						return &entity.User{UserID: userID, TeamName: "backend", IsActive: true}, nil
+					},
+					getActiveFn: func(ctx context.Context, teamName, excludeID string) ([]*entity.User, error) {
+						return []*entity.User{{UserID: "r1", TeamName: "backend", IsActive: true}}, nil
+					},
+				},
+				prRepo: &mockPRRepo{
+					getByIDFn: func(ctx context.Context, id string) (*entity.PullRequest, error) {
+						return prs[id], nil
+					},
+					getAwaitingFn: func(ctx context.Context) ([]*entity.PullRequest, error) {
+						return []*entity.PullRequest{{ID: queued.ID}}, nil
+					},
+					// Нагрузку считают только открытые PR
+					updateFn: func(ctx context.Context, pr *entity.PullRequest) error {
+						if pr.ID == closing.ID && pr.Status == entity.StatusClosed {
+							stats.workload["r1"] = 0
+						}
+						return nil
+					},
+					assignFn: func(ctx context.Context, prID string, userIDs []string, source entity.ReviewerSource) error {
+						assigned[prID] = append(assigned[prID], userIDs...)
+						return nil
+					},
+				},
+				policyRepo: &mockPolicyRepo{
+					getByTeamFn: func(ctx context.Context, teamName string) (*entity.TeamPolicy, error) {
+						return &entity.TeamPolicy{TeamName: teamName, ReviewerCount: 1, MaxOpenReviews: 1}, nil
+					},
+				},
+				statsRepo: stats,
+			})
+		},
+	}
+
+	uc := NewPullRequestUseCase(txManager, service.NewReviewerSelector())
+
+	if _, err := uc.Close(ctx, closing.ID); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	if closing.Status != entity.StatusClosed {
+		t.Errorf("closed pr status = %v, want CLOSED", closing.Status)
+	}
+	// Ревьювер остаётся на закрытом PR
+	if !closing.HasReviewer("r1") {
+		t.Errorf("closed pr reviewers = %v, want r1 kept", closing.AssignedReviewers)
+	}
+	if queued.Status != entity.StatusOpen || len(assigned[queued.ID]) != 1 || assigned[queued.ID][0] != "r1" {
+		t.Errorf("queued pr status = %v, assigned = %v; want OPEN with r1", queued.Status, assigned[queued.ID])
+	}
+}
+
+func TestPullRequestUseCase_Reopen_RestoresReviewers(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name          string
+		assigned      []string
+		required      int      // 0 = по числу назначенных
+		team          []string // активные участники команды
+		blocking      []string // запросили изменения
+		wantReviewers []string
+		wantStatus    entity.PRStatus
+		wantDropped   []string
+	}{
+		{
+			name:          "replaces or drops inactive reviewers",
+			assigned:      []string{"r1", "gone1", "gone2"},
+			team:          []string{"r1", "spare"},
+			wantReviewers: []string{"r1", "spare"},
+			wantStatus:    entity.StatusOpen,
+			wantDropped:   []string{"gone2"},
+		},
+		{
+			name:          "keeps inactive reviewer who requested changes",
+			assigned:      []string{"r1", "gone1"},
+			team:          []string{"r1", "spare"},
+			blocking:      []string{"gone1"},
+			wantReviewers: []string{"r1", "gone1"},
+			wantStatus:    entity.StatusOpen,
+		},
+		{
+			name:          "tops up open PR short of reviewers",
+			assigned:      []string{"r1"},
+			required:      2,
+			team:          []string{"r1", "spare"},
+			wantReviewers: []string{"r1", "spare"},
+			wantStatus:    entity.StatusOpen,
+		},
+		{
+			name:          "queues when nobody is left",
+			assigned:      []string{"gone1"},
+			team:          []string{},
+			wantReviewers: []string{},
+			wantStatus:    entity.StatusAwaitingReviewers,
+			wantDropped:   []string{"gone1"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			readyAt := time.Now().Add(-2 * time.Hour)
+			closedAt := time.Now().Add(-time.Hour)
+			required := tt.required
+			if required == 0 {
+				required = len(tt.assigned)
+			}
+			pr := &entity.PullRequest{
+				ID:                "pr1",
+				AuthorID:          "author",
+				Status:            entity.StatusClosed,
+				RequiredReviewers: required,
+				AssignedReviewers: append([]string{}, tt.assigned...),
+				ReadyAt:           &readyAt,
+				ClosedAt:          &closedAt,
+			}
+
+			active := make(map[string]bool)
+			for _, id := range tt.team {
+				active[id] = true
+			}
+
+			stats := &mockStatsRepo{}
+			txManager := &mockTxManager{
+				withTxFn: func(ctx context.Context, fn func(repository.Tx) error) error {
+					return fn(&mockTx{
+						usersRepo: &mockUsersRepo{
+							getByIDFn: func(ctx context.Context, userID string) (*entity.User, error) {
+								isActive := active[userID] || userID == "author"
+								return &entity.User{UserID: userID, TeamName: "backend", IsActive: isActive}, nil
+							},
+							getActiveFn: func(ctx context.Context, teamName, excludeID string) ([]*entity.User, error) {
+								var users []*entity.User
+								for _, id := range tt.team {
+									users = append(users, &entity.User{UserID: id, TeamName: "backend", IsActive: true})
+								}
+								return users, nil
+							},
+						},
+						prRepo: &mockPRRepo{
+							getByIDFn: func(ctx context.Context, id string) (*entity.PullRequest, error) {
+								return pr, nil
+							},
+							getVerdictsFn: func(ctx context.Context, prID string) ([]*entity.ReviewVerdict, error) {
+								var verdicts []*entity.ReviewVerdict
+								for _, id := range tt.blocking {
+									verdicts = append(verdicts, &entity.ReviewVerdict{
+										PullRequestID: prID,
+										UserID:        id,
+										Verdict:       entity.VerdictChangesRequested,
+									})
+								}
+								return verdicts, nil
+							},
+						},
+						statsRepo: stats,
+					})
+				},
+			}
+
+			uc := NewPullRequestUseCase(txManager, service.NewReviewerSelector())
+
+			result, err := uc.Reopen(ctx, pr.ID)
+			if err != nil {
+				t.Fatalf("Reopen() error = %v", err)
+			}
+
+			if result.Status != tt.wantStatus {
+				t.Errorf("status = %v, want %v", result.Status, tt.wantStatus)
+			}
+			if !slices.Equal(result.AssignedReviewers, tt.wantReviewers) {
+				t.Errorf("reviewers = %v, want %v", result.AssignedReviewers, tt.wantReviewers)
+			}
+			for _, id := range tt.wantDropped {
+				if result.HasReviewer(id) || stats.decrements[id] != 0 {
+					t.Errorf("reviewers = %v, decrements = %v; want %s dropped with stats kept",
+						result.AssignedReviewers, stats.decrements, id)
+				}
+			}
+		})
+	}
+}
+
+func TestPullRequestUseCase_MarkReady_Draft(t *testing.T) {
+	tests := []struct {
+		name          string
+		workload      map[string]int
+		mandatory     []*entity.MandatoryReviewer
+		wantStatus    entity.PRStatus
+		wantReviewers []string
+		wantRequired  int
+	}{
+		{
+			name:          "selects reviewers",
+			workload:      map[string]int{"r1": 0, "r2": 1},
+			wantStatus:    entity.StatusOpen,
+			wantReviewers: []string{"r1", "r2"},
+			wantRequired:  2,
+		},
+		{
+			name:          "adds mandatory reviewers on top",
+			workload:      map[string]int{"r1": 0, "r2": 1},
+			mandatory:     []*entity.MandatoryReviewer{{TeamName: "backend", UserID: "lead"}},
+			wantStatus:    entity.StatusOpen,
+			wantReviewers: []string{"r1", "r2", "lead"},
+			wantRequired:  3,
+		},
+		{
+			name:          "queues when nobody has capacity",
+			workload:      map[string]int{"r1": 2, "r2": 2},
+			wantStatus:    entity.StatusAwaitingReviewers,
+			wantReviewers: nil,
+			wantRequired:  2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			draft := &entity.PullRequest{ID: "pr1", AuthorID: "author", Status: entity.StatusDraft}
+
+			members := []*entity.User{
+				{UserID: "r1", TeamName: "backend", IsActive: true},
+				{UserID: "r2", TeamName: "backend", IsActive: true},
+			}
+
+			txManager := &mockTxManager{
+				withTxFn: func(ctx context.Context, fn func(repository.Tx) error) error {
+					return fn(&mockTx{
+						usersRepo: &mockUsersRepo{
+							getByIDFn: func(ctx context.Context, userID string) (*entity.User, error) {
+								return &entity.User{UserID: userID, TeamName: "backend", IsActive: true}, nil
+							},
+							getActiveFn: func(ctx context.Context, teamName, excludeID string) ([]*entity.User, error) {
+								return members, nil
+							},
+						},
+						prRepo: &mockPRRepo{
+							getByIDFn: func(ctx context.Context, id string) (*entity.PullRequest, error) {
+								return draft, nil
+							},
+						},
+						policyRepo: &mockPolicyRepo{
+							getByTeamFn: func(ctx context.Context, teamName string) (*entity.TeamPolicy, error) {
+								return &entity.TeamPolicy{TeamName: teamName, ReviewerCount: 2, MaxOpenReviews: 2}, nil
+							},
+						},
+						statsRepo:     &mockStatsRepo{workload: tt.workload},
+						mandatoryRepo: &mockMandatoryRepo{rules: tt.mandatory},
+					})
+				},
+			}
+
+			uc := NewPullRequestUseCase(txManager, service.NewReviewerSelector())
+
+			pr, err := uc.MarkReady(context.Background(), draft.ID)
+			if err != nil {
+				t.Fatalf("MarkReady() error = %v", err)
+			}
+
+			if pr.Status != tt.wantStatus {
+				t.Errorf("status = %v, want %v", pr.Status, tt.wantStatus)
+			}
+			if pr.ReadyAt == nil {
+				t.Error("ReadyAt is nil, want set")
+			}
+			if pr.RequiredReviewers != tt.wantRequired {
+				t.Errorf("RequiredReviewers = %d, want %d", pr.RequiredReviewers, tt.wantRequired)
+			}
+			if !slices.Equal(pr.AssignedReviewers, tt.wantReviewers) {
+				t.Errorf("reviewers = %v, want %v", pr.AssignedReviewers, tt.wantReviewers)
+			}
+		})
+	}
+}
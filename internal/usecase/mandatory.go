@@ -0,0 +1,150 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"reviewer-service/internal/domain/entity"
+	"reviewer-service/internal/repository"
+)
+
+// GetMandatoryReviewers возвращает обязательных ревьюверов команды
+func (uc *TeamUseCase) GetMandatoryReviewers(ctx context.Context, teamName string) ([]*entity.MandatoryReviewer, error) {
+	var result []*entity.MandatoryReviewer
+
+	err := uc.txManager.WithTx(ctx, func(tx repository.Tx) error {
+		if err := ensureTeamExists(ctx, tx, teamName); err != nil {
+			return err
+		}
+
+		reviewers, err := tx.MandatoryReviewers().GetByTeam(ctx, teamName)
+		if err != nil {
+			return err
+		}
+
+		result = reviewers
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// SetMandatoryReviewer добавляет обязательного ревьювера команды
+// или заменяет метки, на PR с которыми он нужен
+func (uc *TeamUseCase) SetMandatoryReviewer(
+	ctx context.Context,
+	reviewer *entity.MandatoryReviewer,
+) (*entity.MandatoryReviewer, error) {
+	reviewer.Labels = entity.NormalizeTags(reviewer.Labels)
+
+	err := uc.txManager.WithTx(ctx, func(tx repository.Tx) error {
+		if err := ensureTeamExists(ctx, tx, reviewer.TeamName); err != nil {
+			return err
+		}
+
+		return tx.MandatoryReviewers().Upsert(ctx, reviewer)
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return reviewer, nil
+}
+
+// RemoveMandatoryReviewer убирает обязательного ревьювера команды.
+// На уже открытых PR он остаётся.
+func (uc *TeamUseCase) RemoveMandatoryReviewer(ctx context.Context, teamName, userID string) error {
+	return uc.txManager.WithTx(ctx, func(tx repository.Tx) error {
+		return tx.MandatoryReviewers().Delete(ctx, teamName, userID)
+	})
+}
+
+// mandatoryReviewers возвращает активных обязательных ревьюверов команды,
+// которые нужны на PR и ещё не назначены. Правила исключения пар действуют
+// и на них на момент now.
+func mandatoryReviewers(
+	ctx context.Context,
+	tx repository.Tx,
+	teamName string,
+	pr *entity.PullRequest,
+	now time.Time,
+) ([]*entity.User, error) {
+	rules, err := tx.MandatoryReviewers().GetByTeam(ctx, teamName)
+	if err != nil {
+		return nil, fmt.Errorf("get mandatory reviewers: %w", err)
+	}
+
+	if len(rules) == 0 {
+		return nil, nil
+	}
+
+	exclusions, err := tx.Exclusions().GetActiveForAuthor(ctx, pr.AuthorID, now)
+	if err != nil {
+		return nil, fmt.Errorf("get exclusions: %w", err)
+	}
+	blocked := entity.BlockedReviewers(exclusions, pr.AuthorID, now)
+
+	var result []*entity.User
+	for _, rule := range rules {
+		if rule.UserID == pr.AuthorID || pr.HasReviewer(rule.UserID) || !rule.AppliesTo(pr.Labels) {
+			continue
+		}
+		if blocked[rule.UserID] {
+			continue
+		}
+
+		user, err := tx.Users().GetByID(ctx, rule.UserID)
+		if err == repository.ErrNotFound {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		// Выключенный или отсутствующий ревьювер PR не блокирует
+		if !user.IsActive {
+			continue
+		}
+
+		result = append(result, user)
+	}
+
+	return result, nil
+}
+
+// assignMandatory назначает обязательных ревьюверов сверх сбалансированного
+// выбора. Лимиты открытых ревью на них не действуют, но назначения
+// учитываются в статистике, как и остальные.
+func assignMandatory(
+	ctx context.Context,
+	tx repository.Tx,
+	pr *entity.PullRequest,
+	reviewers []*entity.User,
+) error {
+	if len(reviewers) == 0 {
+		return nil
+	}
+
+	ids := userIDs(reviewers)
+
+	if err := tx.PullRequests().AssignReviewers(ctx, pr.ID, ids, entity.SourceMandatory); err != nil {
+		return fmt.Errorf("assign mandatory reviewers: %w", err)
+	}
+
+	for _, id := range ids {
+		if err := tx.Stats().IncrementAssignment(ctx, id); err != nil {
+			return fmt.Errorf("increment assignment: %w", err)
+		}
+	}
+
+	pr.AssignedReviewers = append(pr.AssignedReviewers, ids...)
+	pr.MandatoryReviewers = append(pr.MandatoryReviewers, ids...)
+
+	return nil
+}
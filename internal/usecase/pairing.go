@@ -0,0 +1,54 @@
+package usecase
+
+import (
+	"context"
+
+	"reviewer-service/internal/domain/entity"
+	"reviewer-service/internal/domain/service"
+	"reviewer-service/internal/repository"
+)
+
+// GetPairingMatrix возвращает, кто чьи PR ревьюил в команде за последние days дней.
+// days = 0 - окно anti-affinity из политики, а если оно выключено - 30 дней.
+func (uc *TeamUseCase) GetPairingMatrix(ctx context.Context, teamName string, days int) (*entity.PairingMatrix, error) {
+	var result *entity.PairingMatrix
+
+	err := uc.txManager.WithTx(ctx, func(tx repository.Tx) error {
+		if err := ensureTeamExists(ctx, tx, teamName); err != nil {
+			return err
+		}
+
+		if days == 0 {
+			policy, err := service.LoadTeamPolicy(ctx, tx, teamName)
+			if err != nil {
+				return err
+			}
+
+			days = policy.AntiAffinityDays
+			if days == 0 {
+				days = entity.DefaultPairingWindowDays
+			}
+		}
+
+		since := uc.selector.Now().AddDate(0, 0, -days)
+
+		pairs, err := tx.Stats().GetPairingMatrix(ctx, teamName, since)
+		if err != nil {
+			return err
+		}
+
+		result = &entity.PairingMatrix{
+			TeamName:   teamName,
+			WindowDays: days,
+			Since:      since,
+			Pairs:      pairs,
+		}
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
@@ -0,0 +1,61 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"reviewer-service/internal/domain/entity"
+	"reviewer-service/internal/repository"
+)
+
+// UpdatePR меняет название и метаданные PR в любом статусе.
+// Ревьюверы при смене меток или репозитория не перевыбираются.
+func (uc *PullRequestUseCase) UpdatePR(
+	ctx context.Context,
+	prID string,
+	update entity.PRUpdate,
+) (*entity.PullRequest, error) {
+	var result *entity.PullRequest
+
+	err := uc.txManager.WithTx(ctx, func(tx repository.Tx) error {
+		pr, err := tx.PullRequests().GetByIDForUpdate(ctx, prID)
+		if err != nil {
+			return err
+		}
+
+		labels := pr.Labels
+		repo := pr.Repository
+		update.Apply(pr)
+
+		if pr.Name == "" {
+			return fmt.Errorf("%w: pull_request_name must not be empty", repository.ErrInvalidPRMeta)
+		}
+		if err := pr.PRMetadata.Validate(); err != nil {
+			return fmt.Errorf("%w: %v", repository.ErrInvalidPRMeta, err)
+		}
+		// Номер PR задан внутри репозитория и входит в его идентификатор
+		if pr.Repository != repo && pr.Number > 0 {
+			return fmt.Errorf("%w: repository of PR %d cannot change", repository.ErrInvalidPRMeta, pr.Number)
+		}
+
+		if err := tx.PullRequests().Update(ctx, pr); err != nil {
+			return err
+		}
+
+		if !slices.Equal(labels, pr.Labels) {
+			if err := tx.PullRequests().SetLabels(ctx, pr.ID, pr.Labels); err != nil {
+				return err
+			}
+		}
+
+		result = pr
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
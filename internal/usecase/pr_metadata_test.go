@@ -0,0 +1,105 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"reviewer-service/internal/domain/entity"
+	"reviewer-service/internal/domain/service"
+	"reviewer-service/internal/repository"
+)
+
+func TestPullRequestUseCase_UpdatePR(t *testing.T) {
+	ctx := context.Background()
+
+	str := func(s string) *string { return &s }
+
+	tests := []struct {
+		name    string
+		update  entity.PRUpdate
+		wantErr error
+	}{
+		{"metadata", entity.PRUpdate{URL: str("https://git.local/api/pull/7"), TargetBranch: str("main")}, nil},
+		{"invalid url", entity.PRUpdate{URL: str("git.local/api/pull/7")}, repository.ErrInvalidPRMeta},
+		{"empty name", entity.PRUpdate{Name: str("")}, repository.ErrInvalidPRMeta},
+		{"move numbered pr", entity.PRUpdate{Repository: str("web")}, repository.ErrInvalidPRMeta},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			txManager := &mockTxManager{
+				withTxFn: func(ctx context.Context, fn func(repository.Tx) error) error {
+					return fn(&mockTx{
+						prRepo: &mockPRRepo{
+							getByIDFn: func(ctx context.Context, id string) (*entity.PullRequest, error) {
+								return &entity.PullRequest{
+									ID:         id,
+									Name:       "Add search",
+									Status:     entity.StatusMerged,
+									PRMetadata: entity.PRMetadata{Repository: "api", Number: 7},
+								}, nil
+							},
+						},
+					})
+				},
+			}
+
+			uc := NewPullRequestUseCase(txManager, service.NewReviewerSelector())
+
+			pr, err := uc.UpdatePR(ctx, "pr1", tt.update)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("UpdatePR() error = %v, want %v", err, tt.wantErr)
+			}
+			if err == nil && (pr.URL != *tt.update.URL || pr.Name != "Add search") {
+				t.Errorf("UpdatePR() = %+v", pr)
+			}
+		})
+	}
+}
+
+func TestPullRequestUseCase_CreatePR_RepositoryKey(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name    string
+		id      string
+		meta    entity.PRMetadata
+		wantErr error
+	}{
+		{"unregistered repository", "pr1", entity.PRMetadata{Repository: "legacy-tools"}, nil},
+		{"derived key", entity.PRKey("api", 7), entity.PRMetadata{Repository: "api", Number: 7}, nil},
+		{"reserved key without number", "api#7", entity.PRMetadata{}, repository.ErrInvalidPRMeta},
+		{"key of another number", "api#7", entity.PRMetadata{Repository: "api", Number: 8}, repository.ErrInvalidPRMeta},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			txManager := &mockTxManager{
+				withTxFn: func(ctx context.Context, fn func(repository.Tx) error) error {
+					return fn(&mockTx{
+						usersRepo: &mockUsersRepo{
+							getByIDFn: func(ctx context.Context, userID string) (*entity.User, error) {
+								return &entity.User{UserID: userID, TeamName: "backend", IsActive: true}, nil
+							},
+						},
+						prRepo: &mockPRRepo{},
+					})
+				},
+			}
+
+			uc := NewPullRequestUseCase(txManager, service.NewReviewerSelector())
+
+			_, err := uc.CreatePR(ctx, &entity.PullRequest{
+				ID:         tt.id,
+				Name:       "Add search",
+				AuthorID:   "author",
+				Status:     entity.StatusDraft,
+				PRMetadata: tt.meta,
+			})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("CreatePR() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -2,22 +2,23 @@ package usecase
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
 	"reviewer-service/internal/domain/entity"
 	"reviewer-service/internal/domain/service"
 	"reviewer-service/internal/repository"
-	"reviewer-service/internal/repository/postgres"
 )
 
 type PullRequestUseCase struct {
-	txManager *postgres.TxManager
+	txManager repository.TxManager
 	selector  *service.ReviewerSelector
+	notifier  Notifier // nil = эскалации только в журнале
 }
 
 func NewPullRequestUseCase(
-	txManager *postgres.TxManager,
+	txManager repository.TxManager,
 	selector *service.ReviewerSelector,
 ) *PullRequestUseCase {
 	return &PullRequestUseCase{
@@ -26,57 +27,159 @@ func NewPullRequestUseCase(
 	}
 }
 
-// CreatePR создаёт PR и назначает ревьюверов атомарно
+// CreatePR создаёт PR и назначает ревьюверов атомарно.
+// Черновик (StatusDraft) создаётся без ревьюверов - их выберет MarkReady.
 func (uc *PullRequestUseCase) CreatePR(
 	ctx context.Context,
-	prID, prName, authorID string,
+	pr *entity.PullRequest,
 ) (*entity.PullRequest, error) {
+	if err := pr.PRMetadata.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %v", repository.ErrInvalidPRMeta, err)
+	}
+
+	// Идентификаторы вида repository#number заняты номерами PR в репозиториях
+	if repo, number, ok := entity.ParsePRKey(pr.ID); ok && (repo != pr.Repository || number != pr.Number) {
+		return nil, fmt.Errorf("%w: pull_request_id %q is reserved for PR %d in repository %q",
+			repository.ErrInvalidPRMeta, pr.ID, number, repo)
+	}
+
 	var result *entity.PullRequest
 
 	err := uc.txManager.WithTx(ctx, func(tx repository.Tx) error {
 		// 1. Получаем автора
-		author, err := tx.Users().GetByID(ctx, authorID)
+		author, err := tx.Users().GetByID(ctx, pr.AuthorID)
 		if err != nil {
 			return fmt.Errorf("get author: %w", err)
 		}
 
-		// 2. Создаём PR
-		pr := &entity.PullRequest{
-			ID:       prID,
-			Name:     prName,
-			AuthorID: authorID,
-			Status:   entity.StatusOpen,
+		pr.Labels = entity.NormalizeTags(pr.Labels)
+
+		if pr.IsDraft() {
+			pr.ReadyAt = nil
+			if err := tx.PullRequests().Create(ctx, pr); err != nil {
+				return fmt.Errorf("create pr: %w", err)
+			}
+
+			result = pr
+			return nil
+		}
+
+		pr.Status = entity.StatusOpen
+		now := time.Now()
+		pr.ReadyAt = &now
+
+		// 2. Читаем политику команды, которая ревьюит PR, в той же транзакции
+		team, policy, err := reviewTeam(ctx, tx, pr, author.TeamName)
+		if err != nil {
+			return err
+		}
+
+		// 3. Обязательные ревьюверы идут сверх сбалансированного выбора
+		mandatory, err := mandatoryReviewers(ctx, tx, team, pr, uc.selector.Now())
+		if err != nil {
+			return err
 		}
 
+		// 4. Создаём PR. Недостающих до целевого числа ревьюверов
+		// доберём, когда появятся свободные кандидаты.
+		pr.RequiredReviewers = policy.ReviewerCount + len(mandatory)
+
 		if err := tx.PullRequests().Create(ctx, pr); err != nil {
 			return fmt.Errorf("create pr: %w", err)
 		}
 
-		// 3. Выбираем ревьюверов (передаём tx!)
-		reviewers, err := uc.selector.Select(ctx, tx, author.TeamName, authorID)
+		// 5. Выбираем ревьюверов (передаём tx!)
+		reviewers, queued, err := uc.selectInitial(ctx, tx, pr, team, policy, mandatory)
 		if err != nil {
-			return fmt.Errorf("select reviewers: %w", err)
+			return err
 		}
-
-		// 4. Назначаем их
-		if len(reviewers) > 0 {
-			reviewerIDs := make([]string, len(reviewers))
-			for i, r := range reviewers {
-				reviewerIDs[i] = r.UserID
+		if queued {
+			// Свободных ревьюверов нет - PR встаёт в очередь
+			pr.Status = entity.StatusAwaitingReviewers
+			if err := tx.PullRequests().Update(ctx, pr); err != nil {
+				return fmt.Errorf("enqueue pr: %w", err)
 			}
+		}
 
-			if err := tx.PullRequests().AssignReviewers(ctx, prID, reviewerIDs); err != nil {
-				return fmt.Errorf("assign reviewers: %w", err)
-			}
+		// 6. Назначаем их
+		if err := assignReviewers(ctx, tx, pr, team, reviewers); err != nil {
+			return err
+		}
+		if err := assignMandatory(ctx, tx, pr, mandatory); err != nil {
+			return err
+		}
 
-			// Обновляем статистику
-			for _, reviewerID := range reviewerIDs {
-				if err := tx.Stats().IncrementAssignment(ctx, reviewerID); err != nil {
-					return fmt.Errorf("increment assignment: %w", err)
-				}
-			}
+		result = pr
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// MarkReady переводит черновик в ревью и назначает ревьюверов так же,
+// как при создании PR (идемпотентная операция)
+func (uc *PullRequestUseCase) MarkReady(ctx context.Context, prID string) (*entity.PullRequest, error) {
+	var result *entity.PullRequest
+
+	err := uc.txManager.WithTx(ctx, func(tx repository.Tx) error {
+		pr, err := tx.PullRequests().GetByIDForUpdate(ctx, prID)
+		if err != nil {
+			return err
+		}
+
+		switch pr.Status {
+		case entity.StatusDraft:
+		case entity.StatusMerged:
+			return repository.ErrPRMerged
+		case entity.StatusClosed:
+			return repository.ErrPRClosed
+		default:
+			// Уже в ревью
+			result = pr
+			return nil
+		}
+
+		author, err := tx.Users().GetByID(ctx, pr.AuthorID)
+		if err != nil {
+			return fmt.Errorf("get author: %w", err)
+		}
+
+		team, policy, err := reviewTeam(ctx, tx, pr, author.TeamName)
+		if err != nil {
+			return err
+		}
 
-			pr.AssignedReviewers = reviewerIDs
+		mandatory, err := mandatoryReviewers(ctx, tx, team, pr, uc.selector.Now())
+		if err != nil {
+			return err
+		}
+
+		reviewers, queued, err := uc.selectInitial(ctx, tx, pr, team, policy, mandatory)
+		if err != nil {
+			return err
+		}
+
+		next := entity.StatusOpen
+		if queued {
+			next = entity.StatusAwaitingReviewers
+		}
+		if err := pr.TransitionTo(next, time.Now()); err != nil {
+			return err
+		}
+		pr.RequiredReviewers = policy.ReviewerCount + len(mandatory)
+		if err := tx.PullRequests().Update(ctx, pr); err != nil {
+			return err
+		}
+
+		if err := assignReviewers(ctx, tx, pr, team, reviewers); err != nil {
+			return err
+		}
+		if err := assignMandatory(ctx, tx, pr, mandatory); err != nil {
+			return err
 		}
 
 		result = pr
@@ -90,10 +193,53 @@ func (uc *PullRequestUseCase) CreatePR(
 	return result, nil
 }
 
-// Merge помечает PR как merged (идемпотентная операция)
+// selectInitial выбирает ревьюверов для PR, который только что стал готов к ревью.
+// Обязательные ревьюверы в выбор не входят. queued = свободных ревьюверов нет
+// и PR нужно поставить в очередь. Если выбрать некого, PR остаётся
+// недоукомплектованным, а причина записывается в pr.Unselected.
+func (uc *PullRequestUseCase) selectInitial(
+	ctx context.Context,
+	tx repository.Tx,
+	pr *entity.PullRequest,
+	team string,
+	policy *entity.TeamPolicy,
+	mandatory []*entity.User,
+) ([]*entity.User, bool, error) {
+	reviewers, err := uc.selector.Select(ctx, tx, service.SelectionRequest{
+		PullRequestID:  pr.ID,
+		TeamName:       team,
+		AuthorID:       pr.AuthorID,
+		ExcludeUserIDs: userIDs(mandatory),
+		ChangedFiles:   pr.ChangedFiles,
+		Labels:         pr.Labels,
+		Policy:         policy,
+	})
+	if errors.Is(err, repository.ErrNoCapacity) {
+		return nil, true, nil
+	}
+	if errors.Is(err, repository.ErrExcludedByRule) {
+		// Кандидатов отсеяли правила исключения пар - ревьюверов
+		// доберёт TopUpReviewers, когда правила или команда изменятся
+		pr.Unselected = entity.UnselectedExcludedByRule
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("select reviewers: %w", err)
+	}
+	if len(reviewers) == 0 && policy.ReviewerCount > 0 {
+		pr.Unselected = entity.UnselectedNoCandidate
+	}
+
+	return reviewers, false, nil
+}
+
+// Merge помечает PR как merged (идемпотентная операция).
+// Нужны required_approvals одобрений и ни одного CHANGES_REQUESTED;
+// force обходит проверку и отмечается в PR как merge_override.
 func (uc *PullRequestUseCase) Merge(
 	ctx context.Context,
 	prID string,
+	force bool,
 ) (*entity.PullRequest, error) {
 	var result *entity.PullRequest
 
@@ -109,16 +255,42 @@ func (uc *PullRequestUseCase) Merge(
 			result = pr
 			return nil
 		}
+		if pr.Status == entity.StatusClosed {
+			return repository.ErrPRClosed
+		}
+		if pr.Status == entity.StatusDraft {
+			return repository.ErrPRDraft
+		}
+
+		approval, err := approvalStatus(ctx, tx, pr)
+		if err != nil {
+			return err
+		}
+		if !approval.Mergeable() {
+			switch {
+			case force:
+				pr.MergeOverride = true
+			case len(approval.ChangesRequested) > 0:
+				return repository.ErrChangesRequested
+			default:
+				return repository.ErrNotApproved
+			}
+		}
 
 		// Merging
-		pr.Status = entity.StatusMerged
-		now := time.Now()
-		pr.MergedAt = &now
+		if err := pr.TransitionTo(entity.StatusMerged, time.Now()); err != nil {
+			return err
+		}
 
 		if err := tx.PullRequests().Update(ctx, pr); err != nil {
 			return err
 		}
 
+		// Освободилось место - разбираем очередь
+		if err := processBacklog(ctx, tx, uc.selector); err != nil {
+			return err
+		}
+
 		result = pr
 		return nil
 	})
@@ -130,25 +302,23 @@ func (uc *PullRequestUseCase) Merge(
 	return result, nil
 }
 
-// Reassign переназначает ревьювера
+// Reassign переназначает ревьювера. Обязательного ревьювера и ревьювера,
+// запросившего изменения, можно заменить только принудительно (force).
 func (uc *PullRequestUseCase) Reassign(
 	ctx context.Context,
 	prID, oldUserID string,
+	force bool,
 ) (*entity.PullRequest, string, error) {
 	var result *entity.PullRequest
 	var newReviewerID string
 
 	err := uc.txManager.WithTx(ctx, func(tx repository.Tx) error {
 		// 1. Проверяем PR
-		pr, err := tx.PullRequests().GetByIDForUpdate(ctx, prID)
+		pr, err := lockOpenPR(ctx, tx, prID)
 		if err != nil {
 			return err
 		}
 
-		if pr.Status == entity.StatusMerged {
-			return repository.ErrPRMerged
-		}
-
 		// 2. Проверяем что oldUser назначен
 		isAssigned, err := tx.PullRequests().IsReviewerAssigned(ctx, prID, oldUserID)
 		if err != nil {
@@ -158,53 +328,307 @@ func (uc *PullRequestUseCase) Reassign(
 			return repository.ErrNotAssigned
 		}
 
-		// 3. Получаем старого ревьювера для определения команды
-		oldUser, err := tx.Users().GetByID(ctx, oldUserID)
-		if err != nil {
+		if pr.IsMandatoryReviewer(oldUserID) && !force {
+			return repository.ErrMandatoryReviewer
+		}
+
+		if err := checkNotBlocking(ctx, tx, pr.ID, oldUserID, force); err != nil {
 			return err
 		}
 
-		// 4. Выбираем замену из ЕГО команды (передаём tx!)
-		newReviewer, err := uc.selector.SelectReplacement(
-			ctx,
-			tx,
-			oldUser.TeamName,
-			[]string{oldUserID, pr.AuthorID},
-		)
+		// 3. Выбираем замену и атомарно меняем ревьювера
+		newReviewer, err := replaceReviewer(ctx, tx, uc.selector, pr, oldUserID)
 		if err != nil {
 			return err
 		}
 
-		if newReviewer == nil {
-			return repository.ErrNoCandidate
+		// У старого ревьювера освободилось место
+		if err := processBacklog(ctx, tx, uc.selector); err != nil {
+			return err
+		}
+
+		result = pr
+		newReviewerID = newReviewer.UserID
+		return nil
+	})
+
+	if err != nil {
+		return nil, "", err
+	}
+
+	return result, newReviewerID, nil
+}
+
+// Suggest подбирает ревьюверов для гипотетического PR без записи в БД
+// и объясняет решение по каждому кандидату. Пустой teamName - команда,
+// которая ревьюит PR: владелец репозитория или команда автора.
+func (uc *PullRequestUseCase) Suggest(
+	ctx context.Context,
+	pr *entity.PullRequest,
+	teamName string,
+) (*entity.SelectionExplanation, error) {
+	var result *entity.SelectionExplanation
+
+	err := uc.txManager.WithTx(ctx, func(tx repository.Tx) error {
+		author, err := tx.Users().GetByID(ctx, pr.AuthorID)
+		if err != nil {
+			return fmt.Errorf("get author: %w", err)
+		}
+
+		var policy *entity.TeamPolicy
+		if teamName == "" {
+			teamName, policy, err = reviewTeam(ctx, tx, pr, author.TeamName)
+		} else {
+			policy, err = service.LoadTeamPolicy(ctx, tx, teamName)
+		}
+		if err != nil {
+			return fmt.Errorf("load policy: %w", err)
+		}
+
+		labels := entity.NormalizeTags(pr.Labels)
+		if labels == nil {
+			labels = []string{}
+		}
+		files := pr.ChangedFiles
+		if files == nil {
+			files = []string{}
 		}
 
-		// 5. Атомарная замена
-		if err := tx.PullRequests().ReplaceReviewer(ctx, prID, oldUserID, newReviewer.UserID); err != nil {
+		pr.Labels = labels
+		mandatory, err := mandatoryReviewers(ctx, tx, teamName, pr, uc.selector.Now())
+		if err != nil {
 			return err
 		}
 
-		// 6. Обновляем статистику
-		if err := tx.Stats().IncrementAssignment(ctx, newReviewer.UserID); err != nil {
+		result, err = uc.selector.Explain(ctx, tx, service.SelectionRequest{
+			PullRequestID:  pr.ID,
+			TeamName:       teamName,
+			AuthorID:       pr.AuthorID,
+			ExcludeUserIDs: userIDs(mandatory),
+			ChangedFiles:   files,
+			Labels:         labels,
+			Policy:         policy,
+		})
+		if err != nil {
 			return err
 		}
 
-		// Обновляем PR объект
-		for i, id := range pr.AssignedReviewers {
-			if id == oldUserID {
-				pr.AssignedReviewers[i] = newReviewer.UserID
-				break
-			}
+		result.Mandatory = userIDs(mandatory)
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// GetQueue возвращает очередь PR, ожидающих ревьюверов.
+// Пустой teamName - очередь всех команд.
+func (uc *PullRequestUseCase) GetQueue(ctx context.Context, teamName string) ([]*entity.QueueEntry, error) {
+	var result []*entity.QueueEntry
+
+	err := uc.txManager.WithTx(ctx, func(tx repository.Tx) error {
+		entries, err := buildQueue(ctx, tx)
+		if err != nil {
+			return err
 		}
 
-		result = pr
-		newReviewerID = newReviewer.UserID
+		result = make([]*entity.QueueEntry, 0, len(entries))
+		for _, entry := range entries {
+			if teamName == "" || entry.TeamName == teamName {
+				result = append(result, entry)
+			}
+		}
 		return nil
 	})
 
 	if err != nil {
-		return nil, "", err
+		return nil, err
 	}
 
-	return result, newReviewerID, nil
+	return result, nil
+}
+
+// GetQueueEntry возвращает позицию PR в очереди ожидания
+func (uc *PullRequestUseCase) GetQueueEntry(ctx context.Context, prID string) (*entity.QueueEntry, error) {
+	entries, err := uc.GetQueue(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+
+	for _, entry := range entries {
+		if entry.PullRequestID == prID {
+			return entry, nil
+		}
+	}
+
+	return nil, repository.ErrNotFound
+}
+
+// replaceReviewer подбирает замену ревьюверу из его команды, меняет его на PR,
+// обновляет статистику и объект pr. Отказавшиеся от PR в замену не попадают.
+// Если замены нет, возвращает ErrNoCandidate.
+func replaceReviewer(
+	ctx context.Context,
+	tx repository.Tx,
+	selector *service.ReviewerSelector,
+	pr *entity.PullRequest,
+	oldUserID string,
+) (*entity.User, error) {
+	// Получаем старого ревьювера для определения команды
+	oldUser, err := tx.Users().GetByID(ctx, oldUserID)
+	if err != nil {
+		return nil, err
+	}
+
+	author, err := tx.Users().GetByID(ctx, pr.AuthorID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Источник замены считается относительно команды, которая ревьюит PR
+	team, _, err := reviewTeam(ctx, tx, pr, author.TeamName)
+	if err != nil {
+		return nil, err
+	}
+
+	// Читаем политику его команды
+	policy, err := service.LoadTeamPolicy(ctx, tx, oldUser.TeamName)
+	if err != nil {
+		return nil, err
+	}
+
+	decliners, err := tx.Declines().GetDecliners(ctx, pr.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Выбираем замену из ЕГО команды (передаём tx!)
+	newReviewer, err := selector.SelectReplacement(ctx, tx, service.SelectionRequest{
+		PullRequestID:   pr.ID,
+		TeamName:        oldUser.TeamName,
+		AuthorID:        pr.AuthorID,
+		ExcludeUserIDs:  append([]string{oldUserID}, decliners...),
+		AssignedUserIDs: removeID(append([]string{}, pr.AssignedReviewers...), oldUserID),
+		ChangedFiles:    pr.ChangedFiles,
+		Labels:          pr.Labels,
+		Policy:          policy,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if newReviewer == nil {
+		return nil, repository.ErrNoCandidate
+	}
+
+	if err := swapReviewer(ctx, tx, pr, team, oldUserID, newReviewer); err != nil {
+		return nil, err
+	}
+
+	return newReviewer, nil
+}
+
+// swapReviewer атомарно меняет ревьювера на PR, обновляет статистику и объект pr
+func swapReviewer(
+	ctx context.Context,
+	tx repository.Tx,
+	pr *entity.PullRequest,
+	team string,
+	oldUserID string,
+	newReviewer *entity.User,
+) error {
+	// Атомарная замена
+	source := reviewerSource(team, newReviewer)
+	if err := tx.PullRequests().ReplaceReviewer(ctx, pr.ID, oldUserID, newReviewer.UserID, source); err != nil {
+		return err
+	}
+
+	// Обновляем статистику
+	if err := tx.Stats().IncrementAssignment(ctx, newReviewer.UserID); err != nil {
+		return err
+	}
+
+	// Обновляем PR объект
+	for i, id := range pr.AssignedReviewers {
+		if id == oldUserID {
+			pr.AssignedReviewers[i] = newReviewer.UserID
+			break
+		}
+	}
+	pr.FallbackReviewers = removeID(pr.FallbackReviewers, oldUserID)
+	pr.MandatoryReviewers = removeID(pr.MandatoryReviewers, oldUserID)
+	if source == entity.SourceFallback {
+		pr.FallbackReviewers = append(pr.FallbackReviewers, newReviewer.UserID)
+	}
+
+	return nil
+}
+
+// assignReviewers назначает ревьюверов на PR с учётом источника
+// (команда, которая ревьюит PR, или резервная команда) и обновляет статистику
+func assignReviewers(
+	ctx context.Context,
+	tx repository.Tx,
+	pr *entity.PullRequest,
+	team string,
+	reviewers []*entity.User,
+) error {
+	bySource := make(map[entity.ReviewerSource][]string)
+	for _, r := range reviewers {
+		source := reviewerSource(team, r)
+		bySource[source] = append(bySource[source], r.UserID)
+	}
+
+	for _, source := range []entity.ReviewerSource{entity.SourceTeam, entity.SourceFallback} {
+		ids := bySource[source]
+		if len(ids) == 0 {
+			continue
+		}
+
+		if err := tx.PullRequests().AssignReviewers(ctx, pr.ID, ids, source); err != nil {
+			return fmt.Errorf("assign reviewers: %w", err)
+		}
+	}
+
+	// Обновляем статистику
+	for _, r := range reviewers {
+		if err := tx.Stats().IncrementAssignment(ctx, r.UserID); err != nil {
+			return fmt.Errorf("increment assignment: %w", err)
+		}
+
+		pr.AssignedReviewers = append(pr.AssignedReviewers, r.UserID)
+	}
+	pr.FallbackReviewers = append(pr.FallbackReviewers, bySource[entity.SourceFallback]...)
+
+	return nil
+}
+
+// reviewerSource определяет источник ревьювера относительно команды,
+// которая ревьюит PR
+func reviewerSource(team string, reviewer *entity.User) entity.ReviewerSource {
+	if reviewer.TeamName != team {
+		return entity.SourceFallback
+	}
+	return entity.SourceTeam
+}
+
+func userIDs(users []*entity.User) []string {
+	ids := make([]string, len(users))
+	for i, u := range users {
+		ids[i] = u.UserID
+	}
+	return ids
+}
+
+func removeID(ids []string, id string) []string {
+	result := ids[:0]
+	for _, v := range ids {
+		if v != id {
+			result = append(result, v)
+		}
+	}
+	return result
 }
@@ -0,0 +1,168 @@
+package usecase
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"reviewer-service/internal/domain/entity"
+	"reviewer-service/internal/domain/service"
+	"reviewer-service/internal/repository"
+)
+
+func TestPullRequestUseCase_Suggest(t *testing.T) {
+	ctx := context.Background()
+
+	users := map[string]*entity.User{
+		"author": {UserID: "author", TeamName: "backend", IsActive: true},
+		"lead":   {UserID: "lead", TeamName: "backend", IsActive: true},
+		"alice":  {UserID: "alice", TeamName: "backend", IsActive: true},
+		"bob":    {UserID: "bob", TeamName: "backend", IsActive: true},
+		"carol":  {UserID: "carol", TeamName: "backend", IsActive: true},
+	}
+	team := func(ctx context.Context, teamName string) ([]*entity.User, error) {
+		var result []*entity.User
+		for _, u := range users {
+			if u.TeamName == teamName {
+				result = append(result, u)
+			}
+		}
+		return result, nil
+	}
+
+	newTxManager := func(policy *entity.TeamPolicy, workload map[string]int) *mockTxManager {
+		return &mockTxManager{
+			withTxFn: func(ctx context.Context, fn func(repository.Tx) error) error {
+				return fn(&mockTx{
+					usersRepo: &mockUsersRepo{
+						getByIDFn: func(ctx context.Context, userID string) (*entity.User, error) {
+							return users[userID], nil
+						},
+						getByTeamFn: team,
+						getActiveFn: func(ctx context.Context, teamName, excludeID string) ([]*entity.User, error) {
+							members, _ := team(ctx, teamName)
+							var active []*entity.User
+							for _, u := range members {
+								if u.IsActive && u.UserID != excludeID {
+									active = append(active, u)
+								}
+							}
+							return active, nil
+						},
+					},
+					policyRepo: &mockPolicyRepo{
+						getByTeamFn: func(ctx context.Context, teamName string) (*entity.TeamPolicy, error) {
+							return policy, nil
+						},
+					},
+					statsRepo: &mockStatsRepo{workload: workload},
+					mandatoryRepo: &mockMandatoryRepo{rules: []*entity.MandatoryReviewer{
+						{TeamName: "backend", UserID: "lead"},
+					}},
+				})
+			},
+		}
+	}
+
+	t.Run("explains the pick", func(t *testing.T) {
+		policy := &entity.TeamPolicy{TeamName: "backend", ReviewerCount: 2, MaxOpenReviews: 3}
+		uc := NewPullRequestUseCase(
+			newTxManager(policy, map[string]int{"alice": 1, "bob": 0, "carol": 3}),
+			service.NewReviewerSelector(),
+		)
+
+		exp, err := uc.Suggest(ctx, &entity.PullRequest{ID: "pr1", AuthorID: "author"}, "")
+		if err != nil {
+			t.Fatalf("Suggest() error = %v", err)
+		}
+
+		if !slices.Equal(exp.Mandatory, []string{"lead"}) {
+			t.Errorf("Mandatory = %v, want [lead]", exp.Mandatory)
+		}
+		if !slices.Equal(exp.Selected, []string{"bob", "alice"}) {
+			t.Errorf("Selected = %v, want [bob alice]", exp.Selected)
+		}
+
+		excluded := make(map[string]string)
+		for _, c := range exp.Candidates {
+			excluded[c.UserID] = c.Excluded
+		}
+		want := map[string]string{
+			"author": entity.ExcludedAuthor,
+			"lead":   entity.ExcludedAssigned,
+			"carol":  entity.ExcludedAtCapacity,
+		}
+		for id, reason := range want {
+			if excluded[id] != reason {
+				t.Errorf("%s excluded = %q, want %q", id, excluded[id], reason)
+			}
+		}
+	})
+
+	t.Run("reports capacity instead of failing", func(t *testing.T) {
+		policy := &entity.TeamPolicy{TeamName: "backend", ReviewerCount: 1, MaxOpenReviews: 1}
+		uc := NewPullRequestUseCase(
+			newTxManager(policy, map[string]int{"alice": 1, "bob": 1, "carol": 1}),
+			service.NewReviewerSelector(),
+		)
+
+		exp, err := uc.Suggest(ctx, &entity.PullRequest{ID: "pr1", AuthorID: "author"}, "backend")
+		if err != nil {
+			t.Fatalf("Suggest() error = %v", err)
+		}
+
+		if len(exp.Selected) != 0 || !exp.AwaitingReviewers || exp.Unselected != entity.UnselectedAtCapacity {
+			t.Errorf("Selected = %v, awaiting = %v, unselected = %q; want none, true, %q",
+				exp.Selected, exp.AwaitingReviewers, exp.Unselected, entity.UnselectedAtCapacity)
+		}
+	})
+}
+
+func TestPullRequestUseCase_CreatePR_ExcludedByRule(t *testing.T) {
+	ctx := context.Background()
+
+	users := map[string]*entity.User{
+		"author": {UserID: "author", TeamName: "backend", IsActive: true},
+		"alice":  {UserID: "alice", TeamName: "backend", IsActive: true},
+	}
+	assigned := 0
+
+	txManager := &mockTxManager{
+		withTxFn: func(ctx context.Context, fn func(repository.Tx) error) error {
+			return fn(&mockTx{
+				usersRepo: &mockUsersRepo{
+					getByIDFn: func(ctx context.Context, userID string) (*entity.User, error) {
+						return users[userID], nil
+					},
+					getActiveFn: func(ctx context.Context, teamName, excludeID string) ([]*entity.User, error) {
+						return []*entity.User{users["alice"]}, nil
+					},
+				},
+				prRepo: &mockPRRepo{
+					assignFn: func(ctx context.Context, prID string, userIDs []string, source entity.ReviewerSource) error {
+						assigned += len(userIDs)
+						return nil
+					},
+				},
+				exclusionRepo: &mockExclusionRepo{exclusions: []*entity.ReviewExclusion{
+					{AuthorID: "author", ReviewerID: "alice"},
+				}},
+			})
+		},
+	}
+
+	uc := NewPullRequestUseCase(txManager, service.NewReviewerSelector())
+
+	pr, err := uc.CreatePR(ctx, &entity.PullRequest{ID: "pr1", AuthorID: "author", Status: entity.StatusOpen})
+	if err != nil {
+		t.Fatalf("CreatePR() error = %v", err)
+	}
+
+	if pr.Status != entity.StatusOpen || assigned != 0 || pr.Shortfall() == 0 {
+		t.Errorf("status = %s, assigned = %d, shortfall = %d; want understaffed OPEN PR",
+			pr.Status, assigned, pr.Shortfall())
+	}
+	if pr.Unselected != entity.UnselectedExcludedByRule {
+		t.Errorf("Unselected = %q, want %q", pr.Unselected, entity.UnselectedExcludedByRule)
+	}
+}
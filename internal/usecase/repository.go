@@ -0,0 +1,96 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"reviewer-service/internal/domain/entity"
+	"reviewer-service/internal/repository"
+)
+
+// GetRepository возвращает репозиторий с командами-владельцами
+func (uc *TeamUseCase) GetRepository(ctx context.Context, name string) (*entity.Repository, error) {
+	var result *entity.Repository
+
+	err := uc.txManager.WithTx(ctx, func(tx repository.Tx) error {
+		repo, err := tx.Repos().GetByName(ctx, name)
+		if err == repository.ErrNotFound {
+			return repository.ErrRepositoryNotFound
+		}
+		if err != nil {
+			return err
+		}
+
+		result = repo
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// ListRepositories возвращает все зарегистрированные репозитории
+func (uc *TeamUseCase) ListRepositories(ctx context.Context) ([]*entity.Repository, error) {
+	var result []*entity.Repository
+
+	err := uc.txManager.WithTx(ctx, func(tx repository.Tx) error {
+		repos, err := tx.Repos().List(ctx)
+		if err != nil {
+			return err
+		}
+
+		result = repos
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// SetRepository регистрирует репозиторий или заменяет его команды-владельцы.
+// Первая команда ревьюит PR репозитория, остальные подключаются как резервные.
+// Уже назначенные ревьюверы не меняются, а PR из очереди перейдут
+// к новым командам при следующем разборе очереди.
+func (uc *TeamUseCase) SetRepository(ctx context.Context, repo *entity.Repository) (*entity.Repository, error) {
+	repo.OwningTeams = uniqueIDs(repo.OwningTeams)
+	if repo.Name == "" {
+		return nil, fmt.Errorf("%w: repository must not be empty", repository.ErrInvalidRepository)
+	}
+	if len(repo.OwningTeams) == 0 {
+		return nil, fmt.Errorf("%w: owning_teams must not be empty", repository.ErrInvalidRepository)
+	}
+
+	err := uc.txManager.WithTx(ctx, func(tx repository.Tx) error {
+		for _, team := range repo.OwningTeams {
+			if err := ensureTeamExists(ctx, tx, team); err != nil {
+				return err
+			}
+		}
+
+		return tx.Repos().Upsert(ctx, repo)
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return repo, nil
+}
+
+// DeleteRepository снимает регистрацию репозитория.
+// Его PR снова ревьюит команда автора.
+func (uc *TeamUseCase) DeleteRepository(ctx context.Context, name string) error {
+	return uc.txManager.WithTx(ctx, func(tx repository.Tx) error {
+		err := tx.Repos().Delete(ctx, name)
+		if err == repository.ErrNotFound {
+			return repository.ErrRepositoryNotFound
+		}
+		return err
+	})
+}
@@ -0,0 +1,251 @@
+package usecase
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"reviewer-service/internal/domain/entity"
+	"reviewer-service/internal/repository"
+)
+
+// AddReviewer вручную назначает на PR выбранного пользователя
+func (uc *PullRequestUseCase) AddReviewer(ctx context.Context, prID, userID string) (*entity.PullRequest, error) {
+	var result *entity.PullRequest
+
+	err := uc.txManager.WithTx(ctx, func(tx repository.Tx) error {
+		pr, err := lockOpenPR(ctx, tx, prID)
+		if err != nil {
+			return err
+		}
+
+		if pr.HasReviewer(userID) {
+			return repository.ErrAlreadyAssigned
+		}
+
+		reviewer, team, err := checkManualReviewer(ctx, tx, pr, userID, uc.selector.Now())
+		if err != nil {
+			return err
+		}
+
+		if err := assignReviewers(ctx, tx, pr, team, []*entity.User{reviewer}); err != nil {
+			return err
+		}
+
+		result = pr
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// RemoveReviewer снимает ревьювера с PR без замены и отменяет его назначение
+// в статистике. Обязательного ревьювера и ревьювера, запросившего изменения,
+// можно снять только принудительно (force).
+func (uc *PullRequestUseCase) RemoveReviewer(
+	ctx context.Context,
+	prID, userID string,
+	force bool,
+) (*entity.PullRequest, error) {
+	var result *entity.PullRequest
+
+	err := uc.txManager.WithTx(ctx, func(tx repository.Tx) error {
+		pr, err := lockOpenPR(ctx, tx, prID)
+		if err != nil {
+			return err
+		}
+
+		if !pr.HasReviewer(userID) {
+			return repository.ErrNotAssigned
+		}
+
+		if pr.IsMandatoryReviewer(userID) && !force {
+			return repository.ErrMandatoryReviewer
+		}
+
+		if err := checkNotBlocking(ctx, tx, pr.ID, userID, force); err != nil {
+			return err
+		}
+
+		if err := dropReviewer(ctx, tx, pr, userID); err != nil {
+			return err
+		}
+
+		// Снятие вручную уменьшает целевое число, иначе место сразу доберут обратно
+		if pr.RequiredReviewers > len(pr.AssignedReviewers) {
+			pr.RequiredReviewers = len(pr.AssignedReviewers)
+			if err := tx.PullRequests().Update(ctx, pr); err != nil {
+				return err
+			}
+		}
+
+		// У снятого ревьювера освободилось место
+		if err := processBacklog(ctx, tx, uc.selector); err != nil {
+			return err
+		}
+
+		result = pr
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// ReassignTo заменяет ревьювера на явно указанного пользователя.
+// Обязательного ревьювера и ревьювера, запросившего изменения,
+// можно заменить только принудительно (force).
+func (uc *PullRequestUseCase) ReassignTo(
+	ctx context.Context,
+	prID, oldUserID, newUserID string,
+	force bool,
+) (*entity.PullRequest, error) {
+	var result *entity.PullRequest
+
+	err := uc.txManager.WithTx(ctx, func(tx repository.Tx) error {
+		pr, err := lockOpenPR(ctx, tx, prID)
+		if err != nil {
+			return err
+		}
+
+		if !pr.HasReviewer(oldUserID) {
+			return repository.ErrNotAssigned
+		}
+
+		if pr.IsMandatoryReviewer(oldUserID) && !force {
+			return repository.ErrMandatoryReviewer
+		}
+
+		if err := checkNotBlocking(ctx, tx, pr.ID, oldUserID, force); err != nil {
+			return err
+		}
+
+		if pr.HasReviewer(newUserID) {
+			return repository.ErrAlreadyAssigned
+		}
+
+		reviewer, team, err := checkManualReviewer(ctx, tx, pr, newUserID, uc.selector.Now())
+		if err != nil {
+			return err
+		}
+
+		if err := swapReviewer(ctx, tx, pr, team, oldUserID, reviewer); err != nil {
+			return err
+		}
+
+		// У старого ревьювера освободилось место
+		if err := processBacklog(ctx, tx, uc.selector); err != nil {
+			return err
+		}
+
+		result = pr
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// lockOpenPR блокирует PR до конца транзакции и проверяет, что он в ревью:
+// не смёржен, не закрыт и не черновик
+func lockOpenPR(ctx context.Context, tx repository.Tx, prID string) (*entity.PullRequest, error) {
+	pr, err := tx.PullRequests().GetByIDForUpdate(ctx, prID)
+	if err != nil {
+		return nil, err
+	}
+
+	if pr.Status == entity.StatusMerged {
+		return nil, repository.ErrPRMerged
+	}
+	if pr.Status == entity.StatusClosed {
+		return nil, repository.ErrPRClosed
+	}
+	if pr.Status == entity.StatusDraft {
+		return nil, repository.ErrPRDraft
+	}
+
+	return pr, nil
+}
+
+// checkManualReviewer проверяет, что пользователя можно вручную назначить на PR:
+// он активен, не автор, правила исключения пар не запрещают ему ревьюить автора
+// и он состоит в команде, которая ревьюит PR, или в её резервной команде.
+// Возвращает пользователя и команду, которая ревьюит PR.
+func checkManualReviewer(
+	ctx context.Context,
+	tx repository.Tx,
+	pr *entity.PullRequest,
+	userID string,
+	now time.Time,
+) (*entity.User, string, error) {
+	if userID == pr.AuthorID {
+		return nil, "", repository.ErrReviewerIsAuthor
+	}
+
+	reviewer, err := tx.Users().GetByID(ctx, userID)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if !reviewer.IsActive {
+		return nil, "", repository.ErrReviewerInactive
+	}
+
+	exclusions, err := tx.Exclusions().GetActiveForAuthor(ctx, pr.AuthorID, now)
+	if err != nil {
+		return nil, "", err
+	}
+	if entity.BlockedReviewers(exclusions, pr.AuthorID, now)[userID] {
+		return nil, "", repository.ErrPairExcluded
+	}
+
+	author, err := tx.Users().GetByID(ctx, pr.AuthorID)
+	if err != nil {
+		return nil, "", err
+	}
+
+	team, policy, err := reviewTeam(ctx, tx, pr, author.TeamName)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if reviewer.TeamName != team && !slices.Contains(policy.FallbackTeams, reviewer.TeamName) {
+		return nil, "", repository.ErrNotTeamMember
+	}
+
+	return reviewer, team, nil
+}
+
+// dropReviewer снимает ревьювера с PR без замены, отменяет его назначение
+// в статистике и обновляет объект pr
+func dropReviewer(ctx context.Context, tx repository.Tx, pr *entity.PullRequest, userID string) error {
+	if err := unassignReviewer(ctx, tx, pr, userID); err != nil {
+		return err
+	}
+
+	return tx.Stats().DecrementAssignment(ctx, userID)
+}
+
+// unassignReviewer снимает ревьювера с PR без замены и обновляет объект pr.
+// Статистику не трогает: назначение состоялось, как и при замене ревьювера
+func unassignReviewer(ctx context.Context, tx repository.Tx, pr *entity.PullRequest, userID string) error {
+	if err := tx.PullRequests().RemoveReviewer(ctx, pr.ID, userID); err != nil {
+		return err
+	}
+
+	pr.AssignedReviewers = removeID(pr.AssignedReviewers, userID)
+	pr.FallbackReviewers = removeID(pr.FallbackReviewers, userID)
+	pr.MandatoryReviewers = removeID(pr.MandatoryReviewers, userID)
+
+	return nil
+}
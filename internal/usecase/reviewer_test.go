@@ -0,0 +1,81 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"reviewer-service/internal/domain/entity"
+	"reviewer-service/internal/domain/service"
+	"reviewer-service/internal/repository"
+)
+
+func TestPullRequestUseCase_AddReviewer_Validation(t *testing.T) {
+	ctx := context.Background()
+
+	users := map[string]*entity.User{
+		"author":   {UserID: "author", TeamName: "backend", IsActive: true},
+		"inactive": {UserID: "inactive", TeamName: "backend", IsActive: false},
+		"stranger": {UserID: "stranger", TeamName: "frontend", IsActive: true},
+		"reserve":  {UserID: "reserve", TeamName: "platform", IsActive: true},
+		"assigned": {UserID: "assigned", TeamName: "backend", IsActive: true},
+		"manager":  {UserID: "manager", TeamName: "backend", IsActive: true},
+	}
+
+	tests := []struct {
+		name    string
+		status  entity.PRStatus
+		userID  string
+		wantErr error
+	}{
+		{"merged PR", entity.StatusMerged, "reserve", repository.ErrPRMerged},
+		{"already assigned", entity.StatusOpen, "assigned", repository.ErrAlreadyAssigned},
+		{"author", entity.StatusOpen, "author", repository.ErrReviewerIsAuthor},
+		{"inactive", entity.StatusOpen, "inactive", repository.ErrReviewerInactive},
+		{"other team", entity.StatusOpen, "stranger", repository.ErrNotTeamMember},
+		{"excluded pair", entity.StatusOpen, "manager", repository.ErrPairExcluded},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			txManager := &mockTxManager{
+				withTxFn: func(ctx context.Context, fn func(repository.Tx) error) error {
+					return fn(&mockTx{
+						usersRepo: &mockUsersRepo{
+							getByIDFn: func(ctx context.Context, userID string) (*entity.User, error) {
+								return users[userID], nil
+							},
+						},
+						prRepo: &mockPRRepo{
+							getByIDFn: func(ctx context.Context, id string) (*entity.PullRequest, error) {
+								return &entity.PullRequest{
+									ID:                id,
+									AuthorID:          "author",
+									Status:            tt.status,
+									AssignedReviewers: []string{"assigned"},
+								}, nil
+							},
+						},
+						exclusionRepo: &mockExclusionRepo{exclusions: []*entity.ReviewExclusion{
+							{AuthorID: "manager", ReviewerID: "author", BothWays: true},
+						}},
+						policyRepo: &mockPolicyRepo{
+							getByTeamFn: func(ctx context.Context, teamName string) (*entity.TeamPolicy, error) {
+								policy := entity.DefaultTeamPolicy(teamName)
+								policy.FallbackTeams = []string{"platform"}
+								return policy, nil
+							},
+						},
+					})
+				},
+			}
+
+			uc := NewPullRequestUseCase(txManager, service.NewReviewerSelector())
+
+			_, err := uc.AddReviewer(ctx, "pr1", tt.userID)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("AddReviewer() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
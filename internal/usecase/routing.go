@@ -0,0 +1,62 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"reviewer-service/internal/domain/entity"
+	"reviewer-service/internal/domain/service"
+	"reviewer-service/internal/repository"
+)
+
+// reviewTeam возвращает команду, которая ревьюит PR, и её политику.
+// PR зарегистрированного репозитория ревьюит основная команда-владелец,
+// остальные владельцы подключаются первыми среди резервных.
+// PR без репозитория или из незарегистрированного ревьюит команда автора.
+func reviewTeam(
+	ctx context.Context,
+	tx repository.Tx,
+	pr *entity.PullRequest,
+	authorTeam string,
+) (string, *entity.TeamPolicy, error) {
+	repo, err := routedRepository(ctx, tx, pr.Repository)
+	if err != nil {
+		return "", nil, err
+	}
+
+	if repo == nil {
+		policy, err := service.LoadTeamPolicy(ctx, tx, authorTeam)
+		if err != nil {
+			return "", nil, fmt.Errorf("load policy: %w", err)
+		}
+		return authorTeam, policy, nil
+	}
+
+	policy, err := service.LoadTeamPolicy(ctx, tx, repo.PrimaryTeam())
+	if err != nil {
+		return "", nil, fmt.Errorf("load policy: %w", err)
+	}
+
+	return repo.PrimaryTeam(), repo.RoutePolicy(policy), nil
+}
+
+// routedRepository возвращает репозиторий с командами-владельцами
+// или nil, если PR маршрутизируется по команде автора
+func routedRepository(ctx context.Context, tx repository.Tx, name string) (*entity.Repository, error) {
+	if name == "" {
+		return nil, nil
+	}
+
+	repo, err := tx.Repos().GetByName(ctx, name)
+	if err == repository.ErrNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get repository: %w", err)
+	}
+
+	if repo.PrimaryTeam() == "" {
+		return nil, nil
+	}
+	return repo, nil
+}
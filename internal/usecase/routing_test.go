@@ -0,0 +1,67 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"reviewer-service/internal/domain/entity"
+	"reviewer-service/internal/repository"
+)
+
+func TestCheckManualReviewer_RepositoryRouting(t *testing.T) {
+	ctx := context.Background()
+
+	users := map[string]*entity.User{
+		"author":   {UserID: "author", TeamName: "backend", IsActive: true},
+		"teammate": {UserID: "teammate", TeamName: "backend", IsActive: true},
+		"front":    {UserID: "front", TeamName: "frontend", IsActive: true},
+		"designer": {UserID: "designer", TeamName: "design", IsActive: true},
+	}
+
+	tx := &mockTx{
+		usersRepo: &mockUsersRepo{
+			getByIDFn: func(ctx context.Context, userID string) (*entity.User, error) {
+				return users[userID], nil
+			},
+		},
+		policyRepo: &mockPolicyRepo{},
+		reposRepo: &mockReposRepo{repos: map[string]*entity.Repository{
+			"web": {Name: "web", OwningTeams: []string{"frontend", "design"}},
+		}},
+	}
+
+	tests := []struct {
+		name       string
+		repository string
+		userID     string
+		wantTeam   string
+		wantErr    error
+	}{
+		{"primary owner", "web", "front", "frontend", nil},
+		{"secondary owner", "web", "designer", "frontend", nil},
+		{"author team not owner", "web", "teammate", "", repository.ErrNotTeamMember},
+		{"unregistered repository", "legacy", "teammate", "backend", nil},
+		{"no repository", "", "front", "", repository.ErrNotTeamMember},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pr := &entity.PullRequest{
+				ID:         "pr1",
+				AuthorID:   "author",
+				Status:     entity.StatusOpen,
+				PRMetadata: entity.PRMetadata{Repository: tt.repository},
+			}
+
+			_, team, err := checkManualReviewer(ctx, tx, pr, tt.userID, time.Now())
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("checkManualReviewer() error = %v, want %v", err, tt.wantErr)
+			}
+			if team != tt.wantTeam {
+				t.Errorf("checkManualReviewer() team = %q, want %q", team, tt.wantTeam)
+			}
+		})
+	}
+}
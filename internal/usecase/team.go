@@ -4,16 +4,24 @@ import (
 	"context"
 
 	"reviewer-service/internal/domain/entity"
+	"reviewer-service/internal/domain/service"
 	"reviewer-service/internal/repository"
 	"reviewer-service/internal/repository/postgres"
 )
 
 type TeamUseCase struct {
 	txManager *postgres.TxManager
+	selector  *service.ReviewerSelector
 }
 
-func NewTeamUseCase(txManager *postgres.TxManager) *TeamUseCase {
-	return &TeamUseCase{txManager: txManager}
+func NewTeamUseCase(
+	txManager *postgres.TxManager,
+	selector *service.ReviewerSelector,
+) *TeamUseCase {
+	return &TeamUseCase{
+		txManager: txManager,
+		selector:  selector,
+	}
 }
 
 func (uc *TeamUseCase) CreateTeam(ctx context.Context, team *entity.Team) (*entity.Team, error) {
@@ -27,7 +35,12 @@ func (uc *TeamUseCase) CreateTeam(ctx context.Context, team *entity.Team) (*enti
 			return repository.ErrTeamExists
 		}
 
-		return tx.Teams().Create(ctx, team)
+		if err := tx.Teams().Create(ctx, team); err != nil {
+			return err
+		}
+
+		// Новые участники могут забрать PR из очереди и добрать недоукомплектованные
+		return fillVacancies(ctx, tx, uc.selector)
 	})
 
 	if err != nil {
@@ -55,3 +68,24 @@ func (uc *TeamUseCase) GetTeam(ctx context.Context, name string) (*entity.Team,
 
 	return result, nil
 }
+
+// GetStats возвращает статистику всех команд, включая нагрузку
+// ревьюверов с весами по размеру PR
+func (uc *TeamUseCase) GetStats(ctx context.Context) ([]*entity.TeamStats, error) {
+	var result []*entity.TeamStats
+
+	err := uc.txManager.WithTx(ctx, func(tx repository.Tx) error {
+		stats, err := tx.Stats().GetTeamStats(ctx)
+		if err != nil {
+			return err
+		}
+		result = stats
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
@@ -0,0 +1,160 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"reviewer-service/internal/domain/entity"
+	"reviewer-service/internal/domain/service"
+	"reviewer-service/internal/repository"
+)
+
+// GetPolicy возвращает политику команды (или политику по умолчанию)
+func (uc *TeamUseCase) GetPolicy(ctx context.Context, teamName string) (*entity.TeamPolicy, error) {
+	var result *entity.TeamPolicy
+
+	err := uc.txManager.WithTx(ctx, func(tx repository.Tx) error {
+		if err := ensureTeamExists(ctx, tx, teamName); err != nil {
+			return err
+		}
+
+		policy, err := service.LoadTeamPolicy(ctx, tx, teamName)
+		if err != nil {
+			return err
+		}
+
+		result = policy
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// SetPolicy создаёт или обновляет политику команды
+func (uc *TeamUseCase) SetPolicy(ctx context.Context, policy *entity.TeamPolicy) (*entity.TeamPolicy, error) {
+	if err := uc.validatePolicy(policy); err != nil {
+		return nil, err
+	}
+
+	err := uc.txManager.WithTx(ctx, func(tx repository.Tx) error {
+		if err := ensureTeamExists(ctx, tx, policy.TeamName); err != nil {
+			return err
+		}
+
+		for _, fallback := range policy.FallbackTeams {
+			exists, err := tx.Teams().Exists(ctx, fallback)
+			if err != nil {
+				return err
+			}
+			if !exists {
+				return fmt.Errorf("%w: fallback team %q not found", repository.ErrInvalidPolicy, fallback)
+			}
+		}
+
+		if policy.TeamLeadID != "" {
+			_, err := tx.Users().GetByID(ctx, policy.TeamLeadID)
+			if errors.Is(err, repository.ErrNotFound) {
+				return fmt.Errorf("%w: team lead %q not found", repository.ErrInvalidPolicy, policy.TeamLeadID)
+			}
+			if err != nil {
+				return err
+			}
+		}
+
+		if err := tx.Policies().Upsert(ctx, policy); err != nil {
+			return err
+		}
+
+		// Лимиты команды могли вырасти - разбираем очередь
+		return processBacklog(ctx, tx, uc.selector)
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return policy, nil
+}
+
+// DeletePolicy удаляет политику - команда возвращается к значениям по умолчанию
+func (uc *TeamUseCase) DeletePolicy(ctx context.Context, teamName string) error {
+	return uc.txManager.WithTx(ctx, func(tx repository.Tx) error {
+		return tx.Policies().Delete(ctx, teamName)
+	})
+}
+
+func (uc *TeamUseCase) validatePolicy(policy *entity.TeamPolicy) error {
+	if policy.ReviewerCount < 0 {
+		return fmt.Errorf("%w: reviewer_count must not be negative", repository.ErrInvalidPolicy)
+	}
+	if policy.MinActiveMembers < 0 {
+		return fmt.Errorf("%w: min_active_members must not be negative", repository.ErrInvalidPolicy)
+	}
+	if policy.MaxOpenReviews < 0 {
+		return fmt.Errorf("%w: max_open_reviews must not be negative", repository.ErrInvalidPolicy)
+	}
+	if policy.MaxWeightedLoad < 0 {
+		return fmt.Errorf("%w: max_weighted_load must not be negative", repository.ErrInvalidPolicy)
+	}
+	if policy.PreferWithinHours < 0 || policy.MaxOffHours < 0 {
+		return fmt.Errorf("%w: working hours thresholds must not be negative", repository.ErrInvalidPolicy)
+	}
+	if policy.AntiAffinityDays < 0 || policy.AntiAffinityWeight < 0 {
+		return fmt.Errorf("%w: anti-affinity settings must not be negative", repository.ErrInvalidPolicy)
+	}
+	if policy.ReviewSLAHours < 0 || policy.EscalationStepHours < 0 {
+		return fmt.Errorf("%w: review SLA settings must not be negative", repository.ErrInvalidPolicy)
+	}
+	if policy.RequiredApprovals < 0 {
+		return fmt.Errorf("%w: required_approvals must not be negative", repository.ErrInvalidPolicy)
+	}
+	for _, action := range policy.EscalationLadder {
+		if !action.Valid() {
+			return fmt.Errorf("%w: unknown escalation action %q", repository.ErrInvalidPolicy, action)
+		}
+	}
+	if policy.Strategy != "" && !uc.selector.HasStrategy(policy.Strategy) {
+		return fmt.Errorf("%w: unknown strategy %q", repository.ErrInvalidPolicy, policy.Strategy)
+	}
+
+	switch policy.OwnershipMode {
+	case "":
+		policy.OwnershipMode = entity.OwnershipPrefer
+	case entity.OwnershipOff, entity.OwnershipPrefer, entity.OwnershipRequire:
+	default:
+		return fmt.Errorf("%w: unknown ownership_mode %q", repository.ErrInvalidPolicy, policy.OwnershipMode)
+	}
+
+	if policy.FallbackTeams == nil {
+		policy.FallbackTeams = []string{}
+	}
+
+	seen := make(map[string]bool)
+	for _, fallback := range policy.FallbackTeams {
+		if fallback == policy.TeamName {
+			return fmt.Errorf("%w: team cannot be its own fallback", repository.ErrInvalidPolicy)
+		}
+		if seen[fallback] {
+			return fmt.Errorf("%w: duplicate fallback team %q", repository.ErrInvalidPolicy, fallback)
+		}
+		seen[fallback] = true
+	}
+
+	return nil
+}
+
+func ensureTeamExists(ctx context.Context, tx repository.Tx, teamName string) error {
+	exists, err := tx.Teams().Exists(ctx, teamName)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return repository.ErrNotFound
+	}
+	return nil
+}
@@ -2,27 +2,79 @@ package usecase
 
 import (
 	"context"
+	"fmt"
 
 	"reviewer-service/internal/domain/entity"
+	"reviewer-service/internal/domain/service"
 	"reviewer-service/internal/repository"
 )
 
 type UserUseCase struct {
 	txManager repository.TxManager
+	selector  *service.ReviewerSelector
 }
 
-func NewUserUseCase(txManager repository.TxManager) *UserUseCase {
-	return &UserUseCase{txManager: txManager}
+func NewUserUseCase(
+	txManager repository.TxManager,
+	selector *service.ReviewerSelector,
+) *UserUseCase {
+	return &UserUseCase{
+		txManager: txManager,
+		selector:  selector,
+	}
 }
 
 func (uc *UserUseCase) SetActive(ctx context.Context, userID string, isActive bool) (*entity.User, error) {
 	var result *entity.User
 
 	err := uc.txManager.WithTx(ctx, func(tx repository.Tx) error {
+		if !isActive {
+			if err := checkMinActiveMembers(ctx, tx, []string{userID}); err != nil {
+				return err
+			}
+		}
+
 		if err := tx.Users().SetActive(ctx, userID, isActive); err != nil {
 			return err
 		}
 
+		// Вернувшийся ревьювер может забрать PR из очереди
+		// или добрать недоукомплектованный
+		if isActive {
+			if err := fillVacancies(ctx, tx, uc.selector); err != nil {
+				return err
+			}
+		}
+
+		user, err := tx.Users().GetByID(ctx, userID)
+		if err != nil {
+			return err
+		}
+
+		result = user
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// SetTags заменяет теги экспертизы пользователя
+func (uc *UserUseCase) SetTags(ctx context.Context, userID string, tags []string) (*entity.User, error) {
+	var result *entity.User
+
+	err := uc.txManager.WithTx(ctx, func(tx repository.Tx) error {
+		if _, err := tx.Users().GetByID(ctx, userID); err != nil {
+			return err
+		}
+
+		if err := tx.Users().SetTags(ctx, userID, entity.NormalizeTags(tags)); err != nil {
+			return err
+		}
+
 		user, err := tx.Users().GetByID(ctx, userID)
 		if err != nil {
 			return err
@@ -39,7 +91,88 @@ func (uc *UserUseCase) SetActive(ctx context.Context, userID string, isActive bo
 	return result, nil
 }
 
-func (uc *UserUseCase) GetReviews(ctx context.Context, userID string) ([]*entity.PullRequest, error) {
+// SetSeniority задаёт уровень пользователя
+func (uc *UserUseCase) SetSeniority(
+	ctx context.Context,
+	userID string,
+	seniority entity.Seniority,
+) (*entity.User, error) {
+	if !seniority.Valid() {
+		return nil, fmt.Errorf("%w: %q, expected JUNIOR, MIDDLE or SENIOR", repository.ErrInvalidSeniority, seniority)
+	}
+
+	var result *entity.User
+
+	err := uc.txManager.WithTx(ctx, func(tx repository.Tx) error {
+		if err := tx.Users().SetSeniority(ctx, userID, seniority); err != nil {
+			return err
+		}
+
+		user, err := tx.Users().GetByID(ctx, userID)
+		if err != nil {
+			return err
+		}
+
+		result = user
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// SetCapacity задаёт личный лимит открытых ревью (nil = лимит команды)
+// и долю ставки в процентах (nil = не меняется)
+func (uc *UserUseCase) SetCapacity(
+	ctx context.Context,
+	userID string,
+	maxOpenReviews *int,
+	capacityPercent *int,
+) (*entity.User, error) {
+	if maxOpenReviews != nil && *maxOpenReviews <= 0 {
+		return nil, fmt.Errorf("%w: max_open_reviews must be positive", repository.ErrInvalidCapacity)
+	}
+	if capacityPercent != nil && (*capacityPercent <= 0 || *capacityPercent > entity.FullCapacity) {
+		return nil, fmt.Errorf("%w: capacity_percent must be between 1 and 100", repository.ErrInvalidCapacity)
+	}
+
+	var result *entity.User
+
+	err := uc.txManager.WithTx(ctx, func(tx repository.Tx) error {
+		if err := tx.Users().SetCapacity(ctx, userID, maxOpenReviews, capacityPercent); err != nil {
+			return err
+		}
+
+		// Лимит мог вырасти - разбираем очередь и недобор
+		if err := fillVacancies(ctx, tx, uc.selector); err != nil {
+			return err
+		}
+
+		user, err := tx.Users().GetByID(ctx, userID)
+		if err != nil {
+			return err
+		}
+
+		result = user
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// GetReviews возвращает PR, на которые назначен пользователь, с учётом фильтра
+func (uc *UserUseCase) GetReviews(
+	ctx context.Context,
+	userID string,
+	filter entity.ReviewFilter,
+) ([]*entity.PullRequest, error) {
 	var result []*entity.PullRequest
 
 	err := uc.txManager.WithTx(ctx, func(tx repository.Tx) error {
@@ -48,7 +181,7 @@ func (uc *UserUseCase) GetReviews(ctx context.Context, userID string) ([]*entity
 			return err
 		}
 
-		prs, err := tx.PullRequests().GetByReviewer(ctx, userID)
+		prs, err := tx.PullRequests().GetByReviewer(ctx, userID, filter)
 		if err != nil {
 			return err
 		}
@@ -63,3 +196,39 @@ func (uc *UserUseCase) GetReviews(ctx context.Context, userID string) ([]*entity
 
 	return result, nil
 }
+
+// checkMinActiveMembers проверяет, что после деактивации пользователей
+// в их командах останется не меньше активных участников, чем требует политика
+func checkMinActiveMembers(ctx context.Context, tx repository.Tx, userIDs []string) error {
+	deactivating := make(map[string]int)
+	for _, userID := range userIDs {
+		user, err := tx.Users().GetByID(ctx, userID)
+		if err != nil {
+			return err
+		}
+		if user.IsActive {
+			deactivating[user.TeamName]++
+		}
+	}
+
+	for teamName, count := range deactivating {
+		policy, err := service.LoadTeamPolicy(ctx, tx, teamName)
+		if err != nil {
+			return err
+		}
+		if policy.MinActiveMembers == 0 {
+			continue
+		}
+
+		active, err := tx.Users().GetActiveByTeam(ctx, teamName, "")
+		if err != nil {
+			return err
+		}
+
+		if len(active)-count < policy.MinActiveMembers {
+			return repository.ErrMinActiveMembers
+		}
+	}
+
+	return nil
+}
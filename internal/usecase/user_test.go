@@ -2,9 +2,13 @@ package usecase
 
 import (
 	"context"
+	"errors"
+	"slices"
 	"testing"
+	"time"
 
 	"reviewer-service/internal/domain/entity"
+	"reviewer-service/internal/domain/service"
 	"reviewer-service/internal/repository"
 )
 
@@ -22,10 +26,18 @@ func (m *mockTxManager) WithTx(ctx context.Context, fn func(repository.Tx) error
 
 // Mock implementation of Tx interface for testing
 type mockTx struct {
-	usersRepo repository.UserRepository
-	prRepo    repository.PullRequestRepository
-	statsRepo repository.StatsRepository
-	teamRepo  repository.TeamRepository
+	usersRepo      repository.UserRepository
+	prRepo         repository.PullRequestRepository
+	statsRepo      repository.StatsRepository
+	teamRepo       repository.TeamRepository
+	policyRepo     repository.TeamPolicyRepository
+	absenceRepo    repository.AbsenceRepository
+	exclusionRepo  repository.ExclusionRepository
+	reposRepo      repository.ReposRepository
+	ownersRepo     repository.CodeOwnersRepository
+	declineRepo    repository.DeclineRepository
+	escalationRepo repository.EscalationRepository
+	mandatoryRepo  repository.MandatoryReviewerRepository
 }
 
 func (m *mockTx) Teams() repository.TeamRepository {
@@ -37,6 +49,9 @@ func (m *mockTx) Users() repository.UserRepository {
 }
 
 func (m *mockTx) Stats() repository.StatsRepository {
+	if m.statsRepo == nil {
+		return &mockStatsRepo{}
+	}
 	return m.statsRepo
 }
 
@@ -44,6 +59,56 @@ func (m *mockTx) PullRequests() repository.PullRequestRepository {
 	return m.prRepo
 }
 
+func (m *mockTx) Policies() repository.TeamPolicyRepository {
+	if m.policyRepo == nil {
+		return &mockPolicyRepo{}
+	}
+	return m.policyRepo
+}
+
+func (m *mockTx) CodeOwners() repository.CodeOwnersRepository {
+	return m.ownersRepo
+}
+
+func (m *mockTx) Absences() repository.AbsenceRepository {
+	return m.absenceRepo
+}
+
+func (m *mockTx) MandatoryReviewers() repository.MandatoryReviewerRepository {
+	if m.mandatoryRepo == nil {
+		return &mockMandatoryRepo{}
+	}
+	return m.mandatoryRepo
+}
+
+func (m *mockTx) Exclusions() repository.ExclusionRepository {
+	if m.exclusionRepo == nil {
+		return &mockExclusionRepo{}
+	}
+	return m.exclusionRepo
+}
+
+func (m *mockTx) Declines() repository.DeclineRepository {
+	if m.declineRepo == nil {
+		return &mockDeclineRepo{}
+	}
+	return m.declineRepo
+}
+
+func (m *mockTx) Escalations() repository.EscalationRepository {
+	if m.escalationRepo == nil {
+		return &mockEscalationRepo{}
+	}
+	return m.escalationRepo
+}
+
+func (m *mockTx) Repos() repository.ReposRepository {
+	if m.reposRepo == nil {
+		return &mockReposRepo{}
+	}
+	return m.reposRepo
+}
+
 func (m *mockTx) Commit() error {
 	return nil
 }
@@ -54,10 +119,11 @@ func (m *mockTx) Rollback() error {
 
 // Mock implementations of repository interfaces
 type mockUsersRepo struct {
-	getByIDFn   func(context.Context, string) (*entity.User, error)
-	setActiveFn func(context.Context, string, bool) error
-	getByTeamFn func(context.Context, string) ([]*entity.User, error)
-	getActiveFn func(context.Context, string, string) ([]*entity.User, error)
+	getByIDFn     func(context.Context, string) (*entity.User, error)
+	setActiveFn   func(context.Context, string, bool) error
+	setCapacityFn func(context.Context, string, *int, *int) error
+	getByTeamFn   func(context.Context, string) ([]*entity.User, error)
+	getActiveFn   func(context.Context, string, string) ([]*entity.User, error)
 }
 
 func (m *mockUsersRepo) Create(ctx context.Context, user *entity.User) error {
@@ -104,9 +170,127 @@ func (m *mockUsersRepo) BulkDeactivate(ctx context.Context, userIDs []string) er
 	return nil
 }
 
+func (m *mockUsersRepo) SetTags(ctx context.Context, userID string, tags []string) error {
+	return nil
+}
+
+func (m *mockUsersRepo) SetCapacity(ctx context.Context, userID string, maxOpenReviews *int, capacityPercent *int) error {
+	if m.setCapacityFn != nil {
+		return m.setCapacityFn(ctx, userID, maxOpenReviews, capacityPercent)
+	}
+	return nil
+}
+
+func (m *mockUsersRepo) SetSeniority(ctx context.Context, userID string, seniority entity.Seniority) error {
+	return nil
+}
+
+func (m *mockUsersRepo) SetSchedule(ctx context.Context, userID string, timezone string, hours []entity.WorkingHours) error {
+	return nil
+}
+
+type mockAbsenceRepo struct {
+	dueToStart  []*entity.Absence
+	dueToFinish []*entity.Absence
+	updated     []entity.Absence
+}
+
+func (m *mockAbsenceRepo) Create(ctx context.Context, absence *entity.Absence) error {
+	return nil
+}
+
+func (m *mockAbsenceRepo) GetByUser(ctx context.Context, userID string) ([]*entity.Absence, error) {
+	return nil, nil
+}
+
+func (m *mockAbsenceRepo) GetByIDForUpdate(ctx context.Context, id int64) (*entity.Absence, error) {
+	for _, absence := range append(m.dueToFinish, m.dueToStart...) {
+		if absence.ID == id {
+			return absence, nil
+		}
+	}
+	return nil, repository.ErrNotFound
+}
+
+func (m *mockAbsenceRepo) GetDueToStart(ctx context.Context, now time.Time) ([]*entity.Absence, error) {
+	return m.dueToStart, nil
+}
+
+func (m *mockAbsenceRepo) GetDueToFinish(ctx context.Context, now time.Time) ([]*entity.Absence, error) {
+	return m.dueToFinish, nil
+}
+
+func (m *mockAbsenceRepo) UpdateStatus(ctx context.Context, absence *entity.Absence) error {
+	m.updated = append(m.updated, *absence)
+	return nil
+}
+
+func (m *mockAbsenceRepo) Delete(ctx context.Context, id int64) error {
+	return nil
+}
+
+type mockEscalationRepo struct {
+	created []*entity.ReviewEscalation
+}
+
+func (m *mockEscalationRepo) Create(ctx context.Context, escalation *entity.ReviewEscalation) error {
+	m.created = append(m.created, escalation)
+	return nil
+}
+
+func (m *mockEscalationRepo) GetByPR(ctx context.Context, prID string) ([]*entity.ReviewEscalation, error) {
+	return m.created, nil
+}
+
+type mockDeclineRepo struct {
+	decliners []string
+	created   []*entity.ReviewDecline
+}
+
+func (m *mockDeclineRepo) Create(ctx context.Context, decline *entity.ReviewDecline) error {
+	m.created = append(m.created, decline)
+	return nil
+}
+
+func (m *mockDeclineRepo) GetByPR(ctx context.Context, prID string) ([]*entity.ReviewDecline, error) {
+	return nil, nil
+}
+
+func (m *mockDeclineRepo) GetDecliners(ctx context.Context, prID string) ([]string, error) {
+	return m.decliners, nil
+}
+
+type mockPolicyRepo struct {
+	getByTeamFn func(context.Context, string) (*entity.TeamPolicy, error)
+}
+
+func (m *mockPolicyRepo) GetByTeam(ctx context.Context, teamName string) (*entity.TeamPolicy, error) {
+	if m.getByTeamFn != nil {
+		return m.getByTeamFn(ctx, teamName)
+	}
+	return nil, repository.ErrNotFound
+}
+
+func (m *mockPolicyRepo) Upsert(ctx context.Context, policy *entity.TeamPolicy) error {
+	return nil
+}
+
+func (m *mockPolicyRepo) Delete(ctx context.Context, teamName string) error {
+	return nil
+}
+
 type mockPRRepo struct {
-	getByReviewerFn func(context.Context, string) ([]*entity.PullRequest, error)
-	getByIDFn       func(context.Context, string) (*entity.PullRequest, error)
+	getByReviewerFn      func(context.Context, string) ([]*entity.PullRequest, error)
+	getByIDFn            func(context.Context, string) (*entity.PullRequest, error)
+	getVerdictsFn        func(context.Context, string) ([]*entity.ReviewVerdict, error)
+	getAwaitingFn        func(context.Context) ([]*entity.PullRequest, error)
+	getUnderstaffedFn    func(context.Context) ([]*entity.PullRequest, error)
+	getOpenAssignmentsFn func(context.Context) ([]*entity.ReviewAssignment, error)
+	assignFn             func(context.Context, string, []string, entity.ReviewerSource) error
+	updateFn             func(context.Context, *entity.PullRequest) error
+	getOpenByReviewersFn func(context.Context, []string) ([]*entity.PullRequest, error)
+	replaceFn            func(ctx context.Context, prID, oldUserID, newUserID string) error
+	escalationSteps      map[string]int // user_id -> сделанные шаги эскалации
 }
 
 func (m *mockPRRepo) Create(ctx context.Context, pr *entity.PullRequest) error {
@@ -114,6 +298,9 @@ func (m *mockPRRepo) Create(ctx context.Context, pr *entity.PullRequest) error {
 }
 
 func (m *mockPRRepo) Update(ctx context.Context, pr *entity.PullRequest) error {
+	if m.updateFn != nil {
+		return m.updateFn(ctx, pr)
+	}
 	return nil
 }
 
@@ -125,29 +312,132 @@ func (m *mockPRRepo) GetByID(ctx context.Context, id string) (*entity.PullReques
 }
 
 func (m *mockPRRepo) GetByIDForUpdate(ctx context.Context, id string) (*entity.PullRequest, error) {
+	if m.getByIDFn != nil {
+		return m.getByIDFn(ctx, id)
+	}
 	return nil, nil
 }
 
-func (m *mockPRRepo) GetByReviewer(ctx context.Context, userID string) ([]*entity.PullRequest, error) {
+func (m *mockPRRepo) GetByReviewer(ctx context.Context, userID string, filter entity.ReviewFilter) ([]*entity.PullRequest, error) {
 	if m.getByReviewerFn != nil {
 		return m.getByReviewerFn(ctx, userID)
 	}
 	return []*entity.PullRequest{}, nil
 }
 
+func (m *mockPRRepo) GetAwaiting(ctx context.Context) ([]*entity.PullRequest, error) {
+	if m.getAwaitingFn != nil {
+		return m.getAwaitingFn(ctx)
+	}
+	return nil, nil
+}
+
+func (m *mockPRRepo) LockAwaiting(ctx context.Context) ([]*entity.PullRequest, error) {
+	awaiting, err := m.GetAwaiting(ctx)
+	if err != nil {
+		return nil, err
+	}
+	prs := make([]*entity.PullRequest, 0, len(awaiting))
+	for _, pr := range awaiting {
+		locked, err := m.GetByIDForUpdate(ctx, pr.ID)
+		if err != nil {
+			return nil, err
+		}
+		prs = append(prs, locked)
+	}
+	return prs, nil
+}
+
+func (m *mockPRRepo) GetUnderstaffed(ctx context.Context) ([]*entity.PullRequest, error) {
+	if m.getUnderstaffedFn != nil {
+		return m.getUnderstaffedFn(ctx)
+	}
+	return nil, nil
+}
+
+func (m *mockPRRepo) LockUnderstaffed(ctx context.Context) ([]*entity.PullRequest, error) {
+	understaffed, err := m.GetUnderstaffed(ctx)
+	if err != nil {
+		return nil, err
+	}
+	prs := make([]*entity.PullRequest, 0, len(understaffed))
+	for _, pr := range understaffed {
+		locked, err := m.GetByIDForUpdate(ctx, pr.ID)
+		if err != nil {
+			return nil, err
+		}
+		prs = append(prs, locked)
+	}
+	return prs, nil
+}
+
 func (m *mockPRRepo) GetOpenByReviewers(ctx context.Context, userIDs []string) ([]*entity.PullRequest, error) {
+	if m.getOpenByReviewersFn != nil {
+		return m.getOpenByReviewersFn(ctx, userIDs)
+	}
 	return []*entity.PullRequest{}, nil
 }
 
-func (m *mockPRRepo) AssignReviewers(ctx context.Context, prID string, userIDs []string) error {
+func (m *mockPRRepo) AssignReviewers(ctx context.Context, prID string, userIDs []string, source entity.ReviewerSource) error {
+	if m.assignFn != nil {
+		return m.assignFn(ctx, prID, userIDs, source)
+	}
+	return nil
+}
+
+func (m *mockPRRepo) ReplaceReviewer(ctx context.Context, prID, oldUserID, newUserID string, source entity.ReviewerSource) error {
+	if m.replaceFn != nil {
+		return m.replaceFn(ctx, prID, oldUserID, newUserID)
+	}
 	return nil
 }
 
-func (m *mockPRRepo) ReplaceReviewer(ctx context.Context, prID, oldUserID, newUserID string) error {
+func (m *mockPRRepo) RemoveReviewer(ctx context.Context, prID, userID string) error {
 	return nil
 }
 
+func (m *mockPRRepo) GetOpenAssignments(ctx context.Context) ([]*entity.ReviewAssignment, error) {
+	if m.getOpenAssignmentsFn != nil {
+		return m.getOpenAssignmentsFn(ctx)
+	}
+	return nil, nil
+}
+
+func (m *mockPRRepo) GetEscalationStep(ctx context.Context, prID, userID string) (int, error) {
+	return m.escalationSteps[userID], nil
+}
+
+func (m *mockPRRepo) SetEscalationStep(ctx context.Context, prID, userID string, step int) error {
+	return nil
+}
+
+func (m *mockPRRepo) SetVerdict(ctx context.Context, prID, userID string, verdict entity.Verdict) error {
+	return nil
+}
+
+func (m *mockPRRepo) SetLabels(ctx context.Context, prID string, labels []string) error {
+	return nil
+}
+
+func (m *mockPRRepo) ResumeReviews(ctx context.Context, prID string, closedAt time.Time) error {
+	return nil
+}
+
+func (m *mockPRRepo) GetVerdicts(ctx context.Context, prID string) ([]*entity.ReviewVerdict, error) {
+	if m.getVerdictsFn != nil {
+		return m.getVerdictsFn(ctx, prID)
+	}
+	return nil, nil
+}
+
 func (m *mockPRRepo) IsReviewerAssigned(ctx context.Context, prID, userID string) (bool, error) {
+	if m.getByIDFn != nil {
+		pr, err := m.getByIDFn(ctx, prID)
+		if err != nil || pr == nil {
+			return false, err
+		}
+		return pr.HasReviewer(userID), nil
+	}
 	return false, nil
 }
 
@@ -167,11 +457,12 @@ func TestUserUseCase_SetActive(t *testing.T) {
 							return expectedUser, nil
 						},
 					},
+					prRepo: &mockPRRepo{},
 				})
 			},
 		}
 
-		usecase := NewUserUseCase(txManager)
+		usecase := NewUserUseCase(txManager, service.NewReviewerSelector())
 
 		user, err := usecase.SetActive(ctx, "user123", true)
 		if err != nil {
@@ -199,7 +490,7 @@ func TestUserUseCase_SetActive(t *testing.T) {
 			},
 		}
 
-		usecase := NewUserUseCase(txManager)
+		usecase := NewUserUseCase(txManager, service.NewReviewerSelector())
 
 		_, err := usecase.SetActive(ctx, "user123", true)
 		if err == nil {
@@ -234,9 +525,9 @@ func TestUserUseCase_GetReviews(t *testing.T) {
 			},
 		}
 
-		usecase := NewUserUseCase(txManager)
+		usecase := NewUserUseCase(txManager, service.NewReviewerSelector())
 
-		prs, err := usecase.GetReviews(ctx, "user123")
+		prs, err := usecase.GetReviews(ctx, "user123", entity.ReviewFilter{})
 		if err != nil {
 			t.Fatalf("GetReviews() error = %v", err)
 		}
@@ -259,11 +550,583 @@ func TestUserUseCase_GetReviews(t *testing.T) {
 			},
 		}
 
-		usecase := NewUserUseCase(txManager)
+		usecase := NewUserUseCase(txManager, service.NewReviewerSelector())
 
-		_, err := usecase.GetReviews(ctx, "user123")
+		_, err := usecase.GetReviews(ctx, "user123", entity.ReviewFilter{})
 		if err == nil {
 			t.Error("GetReviews() expected error, got nil")
 		}
 	})
 }
+
+func TestUserUseCase_SetActive_MinActiveMembers(t *testing.T) {
+	ctx := context.Background()
+
+	newTxManager := func(minActive int) *mockTxManager {
+		return &mockTxManager{
+			withTxFn: func(ctx context.Context, fn func(repository.Tx) error) error {
+				return fn(&mockTx{
+					usersRepo: &mockUsersRepo{
+						getByIDFn: func(ctx context.Context, userID string) (*entity.User, error) {
+							return &entity.User{UserID: userID, TeamName: "backend", IsActive: true}, nil
+						},
+						getActiveFn: func(ctx context.Context, teamName, excludeID string) ([]*entity.User, error) {
+							return []*entity.User{
+								{UserID: "user1", TeamName: "backend", IsActive: true},
+								{UserID: "user2", TeamName: "backend", IsActive: true},
+							}, nil
+						},
+					},
+					policyRepo: &mockPolicyRepo{
+						getByTeamFn: func(ctx context.Context, teamName string) (*entity.TeamPolicy, error) {
+							return &entity.TeamPolicy{TeamName: teamName, ReviewerCount: 2, MinActiveMembers: minActive}, nil
+						},
+					},
+				})
+			},
+		}
+	}
+
+	t.Run("Deactivation allowed", func(t *testing.T) {
+		usecase := NewUserUseCase(newTxManager(1), service.NewReviewerSelector())
+
+		if _, err := usecase.SetActive(ctx, "user1", false); err != nil {
+			t.Fatalf("SetActive() error = %v", err)
+		}
+	})
+
+	t.Run("Deactivation rejected", func(t *testing.T) {
+		usecase := NewUserUseCase(newTxManager(2), service.NewReviewerSelector())
+
+		_, err := usecase.SetActive(ctx, "user1", false)
+		if err != repository.ErrMinActiveMembers {
+			t.Errorf("SetActive() error = %v, want %v", err, repository.ErrMinActiveMembers)
+		}
+	})
+}
+
+func TestUserUseCase_BulkDeactivate(t *testing.T) {
+	ctx := context.Background()
+
+	newTxManager := func(minActive int) *mockTxManager {
+		return &mockTxManager{
+			withTxFn: func(ctx context.Context, fn func(repository.Tx) error) error {
+				return fn(&mockTx{
+					usersRepo: &mockUsersRepo{
+						getByIDFn: func(ctx context.Context, userID string) (*entity.User, error) {
+							return &entity.User{UserID: userID, TeamName: "backend", IsActive: true}, nil
+						},
+						getActiveFn: func(ctx context.Context, teamName, excludeID string) ([]*entity.User, error) {
+							return []*entity.User{
+								{UserID: "user1", TeamName: "backend", IsActive: true},
+								{UserID: "user2", TeamName: "backend", IsActive: true},
+								{UserID: "user3", TeamName: "backend", IsActive: true},
+							}, nil
+						},
+					},
+					prRepo: &mockPRRepo{},
+					policyRepo: &mockPolicyRepo{
+						getByTeamFn: func(ctx context.Context, teamName string) (*entity.TeamPolicy, error) {
+							return &entity.TeamPolicy{TeamName: teamName, ReviewerCount: 2, MinActiveMembers: minActive}, nil
+						},
+					},
+				})
+			},
+		}
+	}
+
+	t.Run("Duplicates counted once", func(t *testing.T) {
+		usecase := NewUserUseCase(newTxManager(1), service.NewReviewerSelector())
+
+		result, err := usecase.BulkDeactivate(ctx, []string{"user1", "user2", "user1"})
+		if err != nil {
+			t.Fatalf("BulkDeactivate() error = %v", err)
+		}
+
+		if len(result.Deactivated) != 2 {
+			t.Errorf("BulkDeactivate() deactivated = %v, want [user1 user2]", result.Deactivated)
+		}
+		if len(result.PullRequests) != 0 {
+			t.Errorf("BulkDeactivate() pull_requests = %v, want none", result.PullRequests)
+		}
+	})
+
+	t.Run("Below minimum active members", func(t *testing.T) {
+		usecase := NewUserUseCase(newTxManager(2), service.NewReviewerSelector())
+
+		_, err := usecase.BulkDeactivate(ctx, []string{"user1", "user2"})
+		if err != repository.ErrMinActiveMembers {
+			t.Errorf("BulkDeactivate() error = %v, want %v", err, repository.ErrMinActiveMembers)
+		}
+	})
+}
+
+func TestUserUseCase_SetCapacity_Validation(t *testing.T) {
+	ctx := context.Background()
+	usecase := NewUserUseCase(&mockTxManager{}, service.NewReviewerSelector())
+
+	zero := 0
+	full := 100
+	over := 150
+
+	tests := []struct {
+		name            string
+		maxOpenReviews  *int
+		capacityPercent *int
+	}{
+		{"Zero personal limit", &zero, &full},
+		{"Zero capacity", nil, &zero},
+		{"Capacity above 100", nil, &over},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := usecase.SetCapacity(ctx, "user1", tt.maxOpenReviews, tt.capacityPercent)
+			if !errors.Is(err, repository.ErrInvalidCapacity) {
+				t.Errorf("SetCapacity() error = %v, want %v", err, repository.ErrInvalidCapacity)
+			}
+		})
+	}
+}
+
+func TestUserUseCase_SetCapacity_KeepsPercent(t *testing.T) {
+	ctx := context.Background()
+
+	var gotPercent *int
+	txManager := &mockTxManager{
+		withTxFn: func(ctx context.Context, fn func(repository.Tx) error) error {
+			return fn(&mockTx{
+				usersRepo: &mockUsersRepo{
+					setCapacityFn: func(ctx context.Context, userID string, maxOpenReviews, capacityPercent *int) error {
+						gotPercent = capacityPercent
+						return nil
+					},
+				},
+				prRepo: &mockPRRepo{},
+			})
+		},
+	}
+
+	limit := 3
+	if _, err := NewUserUseCase(txManager, service.NewReviewerSelector()).SetCapacity(ctx, "user1", &limit, nil); err != nil {
+		t.Fatalf("SetCapacity() error = %v", err)
+	}
+	if gotPercent != nil {
+		t.Errorf("capacity_percent = %d, want unchanged (nil)", *gotPercent)
+	}
+}
+
+func TestUserUseCase_ApplyAbsences(t *testing.T) {
+	ctx := context.Background()
+
+	absences := &mockAbsenceRepo{
+		dueToStart:  []*entity.Absence{{ID: 1, UserID: "leaving", Status: entity.AbsenceScheduled}},
+		dueToFinish: []*entity.Absence{{ID: 2, UserID: "returning", Status: entity.AbsenceActive, Deactivated: true}},
+	}
+	active := make(map[string]bool)
+
+	txManager := &mockTxManager{
+		withTxFn: func(ctx context.Context, fn func(repository.Tx) error) error {
+			return fn(&mockTx{
+				usersRepo: &mockUsersRepo{
+					setActiveFn: func(ctx context.Context, userID string, isActive bool) error {
+						active[userID] = isActive
+						return nil
+					},
+				},
+				prRepo:      &mockPRRepo{},
+				absenceRepo: absences,
+			})
+		},
+	}
+
+	usecase := NewUserUseCase(txManager, service.NewReviewerSelector())
+
+	if err := usecase.ApplyAbsences(ctx, time.Now()); err != nil {
+		t.Fatalf("ApplyAbsences() error = %v", err)
+	}
+
+	if isActive, ok := active["leaving"]; !ok || isActive {
+		t.Error("ApplyAbsences() should deactivate user whose absence started")
+	}
+	if isActive, ok := active["returning"]; !ok || !isActive {
+		t.Error("ApplyAbsences() should reactivate user whose absence finished")
+	}
+
+	statuses := make(map[int64]entity.Absence)
+	for _, a := range absences.updated {
+		statuses[a.ID] = a
+	}
+
+	if a := statuses[1]; a.Status != entity.AbsenceActive || !a.Deactivated {
+		t.Errorf("started absence = %v (deactivated %v), want ACTIVE deactivated", a.Status, a.Deactivated)
+	}
+	if a := statuses[2]; a.Status != entity.AbsenceFinished {
+		t.Errorf("finished absence = %v, want FINISHED", a.Status)
+	}
+}
+
+func TestUserUseCase_ApplyAbsences_MinActiveMembers(t *testing.T) {
+	ctx := context.Background()
+
+	// Уход lonely оставил бы backend без активных участников,
+	// а уход другого пользователя из frontend - нет
+	absences := &mockAbsenceRepo{
+		dueToStart: []*entity.Absence{
+			{ID: 1, UserID: "lonely", Status: entity.AbsenceScheduled},
+			{ID: 2, UserID: "leaving", Status: entity.AbsenceScheduled},
+		},
+	}
+	active := make(map[string]bool)
+
+	txManager := &mockTxManager{
+		withTxFn: func(ctx context.Context, fn func(repository.Tx) error) error {
+			return fn(&mockTx{
+				usersRepo: &mockUsersRepo{
+					getByIDFn: func(ctx context.Context, userID string) (*entity.User, error) {
+						team := "frontend"
+						if userID == "lonely" {
+							team = "backend"
+						}
+						return &entity.User{UserID: userID, TeamName: team, IsActive: true}, nil
+					},
+					getActiveFn: func(ctx context.Context, teamName, excludeID string) ([]*entity.User, error) {
+						if teamName == "backend" {
+							return []*entity.User{{UserID: "lonely", TeamName: "backend", IsActive: true}}, nil
+						}
+						return []*entity.User{
+							{UserID: "leaving", TeamName: "frontend", IsActive: true},
+							{UserID: "staying", TeamName: "frontend", IsActive: true},
+						}, nil
+					},
+					setActiveFn: func(ctx context.Context, userID string, isActive bool) error {
+						active[userID] = isActive
+						return nil
+					},
+				},
+				policyRepo: &mockPolicyRepo{
+					getByTeamFn: func(ctx context.Context, teamName string) (*entity.TeamPolicy, error) {
+						policy := entity.DefaultTeamPolicy(teamName)
+						policy.MinActiveMembers = 1
+						return policy, nil
+					},
+				},
+				prRepo:      &mockPRRepo{},
+				absenceRepo: absences,
+			})
+		},
+	}
+
+	usecase := NewUserUseCase(txManager, service.NewReviewerSelector())
+
+	err := usecase.ApplyAbsences(ctx, time.Now())
+	if !errors.Is(err, repository.ErrMinActiveMembers) {
+		t.Fatalf("ApplyAbsences() error = %v, want %v", err, repository.ErrMinActiveMembers)
+	}
+
+	if _, changed := active["lonely"]; changed {
+		t.Error("ApplyAbsences() should keep the last active member of the team")
+	}
+	if isActive, ok := active["leaving"]; !ok || isActive {
+		t.Error("ApplyAbsences() should still start the other absence")
+	}
+	if absences.dueToStart[0].Status != entity.AbsenceScheduled {
+		t.Errorf("blocked absence = %v, want SCHEDULED", absences.dueToStart[0].Status)
+	}
+}
+
+type mockExclusionRepo struct {
+	exclusions []*entity.ReviewExclusion
+}
+
+func (m *mockExclusionRepo) Create(ctx context.Context, exclusion *entity.ReviewExclusion) error {
+	return nil
+}
+
+func (m *mockExclusionRepo) GetByUser(ctx context.Context, userID string) ([]*entity.ReviewExclusion, error) {
+	return nil, nil
+}
+
+func (m *mockExclusionRepo) GetActiveForAuthor(ctx context.Context, authorID string, now time.Time) ([]*entity.ReviewExclusion, error) {
+	return m.exclusions, nil
+}
+
+func (m *mockExclusionRepo) Delete(ctx context.Context, id int64) error {
+	return nil
+}
+
+type mockReposRepo struct {
+	repos map[string]*entity.Repository
+}
+
+func (m *mockReposRepo) GetByName(ctx context.Context, name string) (*entity.Repository, error) {
+	if repo, ok := m.repos[name]; ok {
+		return repo, nil
+	}
+	return nil, repository.ErrNotFound
+}
+
+func (m *mockReposRepo) List(ctx context.Context) ([]*entity.Repository, error) {
+	return nil, nil
+}
+
+func (m *mockReposRepo) Upsert(ctx context.Context, repo *entity.Repository) error {
+	return nil
+}
+
+func (m *mockReposRepo) Delete(ctx context.Context, name string) error {
+	return nil
+}
+
+type mockCodeOwnersRepo struct {
+	content string
+}
+
+func (m *mockCodeOwnersRepo) GetByTeam(ctx context.Context, teamName string) (*entity.CodeOwnersFile, error) {
+	return &entity.CodeOwnersFile{TeamName: teamName, Content: m.content}, nil
+}
+
+func (m *mockCodeOwnersRepo) Upsert(ctx context.Context, file *entity.CodeOwnersFile) error {
+	return nil
+}
+
+type mockMandatoryRepo struct {
+	rules []*entity.MandatoryReviewer
+}
+
+func (m *mockMandatoryRepo) GetByTeam(ctx context.Context, teamName string) ([]*entity.MandatoryReviewer, error) {
+	var rules []*entity.MandatoryReviewer
+	for _, rule := range m.rules {
+		if rule.TeamName == teamName {
+			rules = append(rules, rule)
+		}
+	}
+	return rules, nil
+}
+
+func (m *mockMandatoryRepo) Upsert(ctx context.Context, reviewer *entity.MandatoryReviewer) error {
+	return nil
+}
+
+func (m *mockMandatoryRepo) Delete(ctx context.Context, teamName, userID string) error {
+	return nil
+}
+
+type mockStatsRepo struct {
+	workload   map[string]int
+	increments map[string]int
+	decrements map[string]int
+}
+
+func (m *mockStatsRepo) GetOpenReviews(ctx context.Context, userIDs []string) (map[string]int, error) {
+	return m.GetWorkload(ctx, userIDs)
+}
+
+func (m *mockStatsRepo) GetWorkload(ctx context.Context, userIDs []string) (map[string]int, error) {
+	result := make(map[string]int)
+	for _, id := range userIDs {
+		result[id] = m.workload[id]
+	}
+	return result, nil
+}
+
+func (m *mockStatsRepo) IncrementAssignment(ctx context.Context, userID string) error {
+	if m.increments == nil {
+		m.increments = make(map[string]int)
+	}
+	m.increments[userID]++
+	return nil
+}
+
+func (m *mockStatsRepo) DecrementAssignment(ctx context.Context, userID string) error {
+	if m.decrements == nil {
+		m.decrements = make(map[string]int)
+	}
+	m.decrements[userID]++
+	return nil
+}
+
+func (m *mockStatsRepo) GetLastAssigned(ctx context.Context, userIDs []string) (map[string]time.Time, error) {
+	return map[string]time.Time{}, nil
+}
+
+func (m *mockStatsRepo) CountMergedSince(ctx context.Context, teamName string, since time.Time) (int, error) {
+	return 0, nil
+}
+
+func (m *mockStatsRepo) GetPairCounts(ctx context.Context, authorID string, reviewerIDs []string, since time.Time) (map[string]int, error) {
+	return map[string]int{}, nil
+}
+
+func (m *mockStatsRepo) GetPairingMatrix(ctx context.Context, teamName string, since time.Time) ([]*entity.PairStat, error) {
+	return nil, nil
+}
+
+func (m *mockStatsRepo) GetUserStats(ctx context.Context, userID string) (*entity.UserStats, error) {
+	return &entity.UserStats{UserID: userID}, nil
+}
+
+func (m *mockStatsRepo) GetTeamStats(ctx context.Context) ([]*entity.TeamStats, error) {
+	return nil, nil
+}
+
+// blockingPRRepo возвращает PR, на котором blocker запросил изменения,
+// и считает замены ревьюверов
+func blockingPRRepo(pr *entity.PullRequest, blocker string, replaced *int) *mockPRRepo {
+	return &mockPRRepo{
+		getByIDFn: func(ctx context.Context, id string) (*entity.PullRequest, error) {
+			return pr, nil
+		},
+		getOpenByReviewersFn: func(ctx context.Context, userIDs []string) ([]*entity.PullRequest, error) {
+			return []*entity.PullRequest{pr}, nil
+		},
+		getVerdictsFn: func(ctx context.Context, prID string) ([]*entity.ReviewVerdict, error) {
+			return []*entity.ReviewVerdict{
+				{PullRequestID: prID, UserID: blocker, Verdict: entity.VerdictChangesRequested},
+			}, nil
+		},
+		replaceFn: func(ctx context.Context, prID, oldUserID, newUserID string) error {
+			*replaced++
+			return nil
+		},
+	}
+}
+
+func TestUserUseCase_ApplyAbsences_KeepsBlockingReviewer(t *testing.T) {
+	ctx := context.Background()
+
+	pr := &entity.PullRequest{
+		ID:                "pr1",
+		AuthorID:          "author",
+		Status:            entity.StatusOpen,
+		AssignedReviewers: []string{"leaving", "r2"},
+	}
+	absences := &mockAbsenceRepo{
+		dueToStart: []*entity.Absence{{ID: 1, UserID: "leaving", Status: entity.AbsenceScheduled}},
+	}
+	replaced := 0
+
+	txManager := &mockTxManager{
+		withTxFn: func(ctx context.Context, fn func(repository.Tx) error) error {
+			return fn(&mockTx{
+				usersRepo: &mockUsersRepo{
+					getByIDFn: func(ctx context.Context, userID string) (*entity.User, error) {
+						return &entity.User{UserID: userID, TeamName: "backend", IsActive: true}, nil
+					},
+					getActiveFn: func(ctx context.Context, teamName, excludeID string) ([]*entity.User, error) {
+						return []*entity.User{
+							{UserID: "leaving", TeamName: "backend", IsActive: true},
+							{UserID: "r2", TeamName: "backend", IsActive: true},
+							{UserID: "spare", TeamName: "backend", IsActive: true},
+						}, nil
+					},
+				},
+				prRepo:      blockingPRRepo(pr, "leaving", &replaced),
+				absenceRepo: absences,
+			})
+		},
+	}
+
+	usecase := NewUserUseCase(txManager, service.NewReviewerSelector())
+
+	if err := usecase.ApplyAbsences(ctx, time.Now()); err != nil {
+		t.Fatalf("ApplyAbsences() error = %v", err)
+	}
+
+	if replaced != 0 || !pr.HasReviewer("leaving") {
+		t.Errorf("reviewers = %v (replaced %d), want blocking reviewer kept", pr.AssignedReviewers, replaced)
+	}
+	if len(absences.updated) != 1 || absences.updated[0].Status != entity.AbsenceActive {
+		t.Errorf("absence updates = %v, want ACTIVE", absences.updated)
+	}
+}
+
+func TestUserUseCase_ApplyAbsences_ReassignsQueuedPR(t *testing.T) {
+	ctx := context.Background()
+
+	pr := &entity.PullRequest{
+		ID:                "pr1",
+		AuthorID:          "author",
+		Status:            entity.StatusAwaitingReviewers,
+		AssignedReviewers: []string{"leaving"},
+	}
+	absences := &mockAbsenceRepo{
+		dueToStart: []*entity.Absence{{ID: 1, UserID: "leaving", Status: entity.AbsenceScheduled}},
+	}
+	replaced := 0
+
+	txManager := &mockTxManager{
+		withTxFn: func(ctx context.Context, fn func(repository.Tx) error) error {
+			return fn(&mockTx{
+				usersRepo: &mockUsersRepo{
+					getByIDFn: func(ctx context.Context, userID string) (*entity.User, error) {
+						return &entity.User{UserID: userID, TeamName: "backend", IsActive: true}, nil
+					},
+					getActiveFn: func(ctx context.Context, teamName, excludeID string) ([]*entity.User, error) {
+						return []*entity.User{
+							{UserID: "leaving", TeamName: "backend", IsActive: true},
+							{UserID: "spare", TeamName: "backend", IsActive: true},
+						}, nil
+					},
+				},
+				prRepo:      blockingPRRepo(pr, "nobody", &replaced),
+				absenceRepo: absences,
+			})
+		},
+	}
+
+	usecase := NewUserUseCase(txManager, service.NewReviewerSelector())
+
+	if err := usecase.ApplyAbsences(ctx, time.Now()); err != nil {
+		t.Fatalf("ApplyAbsences() error = %v", err)
+	}
+
+	if replaced != 1 || pr.HasReviewer("leaving") {
+		t.Errorf("reviewers = %v (replaced %d), want absent reviewer replaced on queued PR", pr.AssignedReviewers, replaced)
+	}
+}
+
+func TestUserUseCase_BulkDeactivate_KeepsBlockingReviewer(t *testing.T) {
+	ctx := context.Background()
+
+	pr := &entity.PullRequest{
+		ID:                "pr1",
+		AuthorID:          "author",
+		Status:            entity.StatusOpen,
+		AssignedReviewers: []string{"user1", "user2"},
+	}
+	replaced := 0
+
+	txManager := &mockTxManager{
+		withTxFn: func(ctx context.Context, fn func(repository.Tx) error) error {
+			return fn(&mockTx{
+				usersRepo: &mockUsersRepo{
+					getByIDFn: func(ctx context.Context, userID string) (*entity.User, error) {
+						return &entity.User{UserID: userID, TeamName: "backend", IsActive: true}, nil
+					},
+					getActiveFn: func(ctx context.Context, teamName, excludeID string) ([]*entity.User, error) {
+						return []*entity.User{
+							{UserID: "user2", TeamName: "backend", IsActive: true},
+							{UserID: "user3", TeamName: "backend", IsActive: true},
+						}, nil
+					},
+				},
+				prRepo: blockingPRRepo(pr, "user1", &replaced),
+			})
+		},
+	}
+
+	usecase := NewUserUseCase(txManager, service.NewReviewerSelector())
+
+	result, err := usecase.BulkDeactivate(ctx, []string{"user1"})
+	if err != nil {
+		t.Fatalf("BulkDeactivate() error = %v", err)
+	}
+
+	if replaced != 0 || !pr.HasReviewer("user1") {
+		t.Errorf("reviewers = %v (replaced %d), want blocking reviewer kept", pr.AssignedReviewers, replaced)
+	}
+	if len(result.PullRequests) != 1 || !slices.Equal(result.PullRequests[0].Blocking, []string{"user1"}) {
+		t.Errorf("BulkDeactivate() pull_requests = %+v, want user1 reported as blocking", result.PullRequests)
+	}
+}
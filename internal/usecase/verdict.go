@@ -0,0 +1,120 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+
+	"reviewer-service/internal/domain/entity"
+	"reviewer-service/internal/repository"
+)
+
+// SubmitVerdict сохраняет вердикт назначенного ревьювера.
+// Повторный вердикт заменяет предыдущий.
+func (uc *PullRequestUseCase) SubmitVerdict(
+	ctx context.Context,
+	prID, userID string,
+	verdict entity.Verdict,
+) (*entity.ApprovalStatus, error) {
+	if !verdict.Valid() {
+		return nil, repository.ErrInvalidVerdict
+	}
+
+	var result *entity.ApprovalStatus
+
+	err := uc.txManager.WithTx(ctx, func(tx repository.Tx) error {
+		pr, err := lockOpenPR(ctx, tx, prID)
+		if err != nil {
+			return err
+		}
+
+		if !pr.HasReviewer(userID) {
+			return repository.ErrNotAssigned
+		}
+
+		if err := tx.PullRequests().SetVerdict(ctx, prID, userID, verdict); err != nil {
+			return err
+		}
+
+		result, err = approvalStatus(ctx, tx, pr)
+		return err
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// GetApproval возвращает вердикты ревьюверов и готовность PR к merge
+func (uc *PullRequestUseCase) GetApproval(ctx context.Context, prID string) (*entity.ApprovalStatus, error) {
+	var result *entity.ApprovalStatus
+
+	err := uc.txManager.WithTx(ctx, func(tx repository.Tx) error {
+		pr, err := tx.PullRequests().GetByID(ctx, prID)
+		if err != nil {
+			return err
+		}
+
+		result, err = approvalStatus(ctx, tx, pr)
+		return err
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// approvalStatus сверяет вердикты PR с required_approvals политики команды,
+// которая ревьюит PR
+func approvalStatus(ctx context.Context, tx repository.Tx, pr *entity.PullRequest) (*entity.ApprovalStatus, error) {
+	author, err := tx.Users().GetByID(ctx, pr.AuthorID)
+	if err != nil {
+		return nil, err
+	}
+
+	_, policy, err := reviewTeam(ctx, tx, pr, author.TeamName)
+	if err != nil {
+		return nil, err
+	}
+
+	verdicts, err := tx.PullRequests().GetVerdicts(ctx, pr.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return entity.NewApprovalStatus(pr.ID, verdicts, policy.RequiredApprovals), nil
+}
+
+// isBlocking сообщает, что ревьювер запросил изменения на PR. Такого ревьювера
+// автоматические переназначения оставляют на PR, чтобы не снять блокировку merge.
+func isBlocking(ctx context.Context, tx repository.Tx, prID, userID string) (bool, error) {
+	err := checkNotBlocking(ctx, tx, prID, userID, false)
+	if errors.Is(err, repository.ErrReviewerBlocking) {
+		return true, nil
+	}
+	return false, err
+}
+
+// checkNotBlocking не даёт снять ревьювера, запросившего изменения: его вердикт
+// хранится в назначении и пропал бы вместе с ним. Снять его может только
+// администратор (force).
+func checkNotBlocking(ctx context.Context, tx repository.Tx, prID, userID string, force bool) error {
+	if force {
+		return nil
+	}
+
+	verdicts, err := tx.PullRequests().GetVerdicts(ctx, prID)
+	if err != nil {
+		return err
+	}
+
+	for _, v := range verdicts {
+		if v.UserID == userID && v.Verdict == entity.VerdictChangesRequested {
+			return repository.ErrReviewerBlocking
+		}
+	}
+	return nil
+}
@@ -0,0 +1,155 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"reviewer-service/internal/domain/entity"
+	"reviewer-service/internal/domain/service"
+	"reviewer-service/internal/repository"
+)
+
+func TestPullRequestUseCase_Merge_ApprovalGate(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name         string
+		verdicts     []*entity.ReviewVerdict
+		force        bool
+		wantErr      error
+		wantOverride bool
+	}{
+		{"no approvals", nil, false, repository.ErrNotApproved, false},
+		{"changes requested", []*entity.ReviewVerdict{
+			{UserID: "r1", Verdict: entity.VerdictApproved},
+			{UserID: "r2", Verdict: entity.VerdictChangesRequested},
+		}, false, repository.ErrChangesRequested, false},
+		{"approved", []*entity.ReviewVerdict{
+			{UserID: "r1", Verdict: entity.VerdictApproved},
+		}, false, nil, false},
+		{"admin override", nil, true, nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			txManager := &mockTxManager{
+				withTxFn: func(ctx context.Context, fn func(repository.Tx) error) error {
+					return fn(&mockTx{
+						usersRepo: &mockUsersRepo{
+							getByIDFn: func(ctx context.Context, userID string) (*entity.User, error) {
+								return &entity.User{UserID: userID, TeamName: "backend", IsActive: true}, nil
+							},
+						},
+						prRepo: &mockPRRepo{
+							getByIDFn: func(ctx context.Context, id string) (*entity.PullRequest, error) {
+								return &entity.PullRequest{
+									ID:                id,
+									AuthorID:          "author",
+									Status:            entity.StatusOpen,
+									AssignedReviewers: []string{"r1", "r2"},
+								}, nil
+							},
+							getVerdictsFn: func(ctx context.Context, prID string) ([]*entity.ReviewVerdict, error) {
+								return tt.verdicts, nil
+							},
+						},
+						policyRepo: &mockPolicyRepo{
+							getByTeamFn: func(ctx context.Context, teamName string) (*entity.TeamPolicy, error) {
+								policy := entity.DefaultTeamPolicy(teamName)
+								policy.RequiredApprovals = 1
+								return policy, nil
+							},
+						},
+					})
+				},
+			}
+
+			uc := NewPullRequestUseCase(txManager, service.NewReviewerSelector())
+
+			pr, err := uc.Merge(ctx, "pr1", tt.force)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Merge() error = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if pr.Status != entity.StatusMerged {
+				t.Errorf("Merge() status = %v, want MERGED", pr.Status)
+			}
+			if pr.MergeOverride != tt.wantOverride {
+				t.Errorf("Merge() merge_override = %v, want %v", pr.MergeOverride, tt.wantOverride)
+			}
+		})
+	}
+}
+
+func TestPullRequestUseCase_ChangesRequested_BlocksReviewerChanges(t *testing.T) {
+	ctx := context.Background()
+
+	txManager := &mockTxManager{
+		withTxFn: func(ctx context.Context, fn func(repository.Tx) error) error {
+			return fn(&mockTx{
+				usersRepo: &mockUsersRepo{
+					getByIDFn: func(ctx context.Context, userID string) (*entity.User, error) {
+						return &entity.User{UserID: userID, TeamName: "backend", IsActive: true}, nil
+					},
+				},
+				prRepo: &mockPRRepo{
+					getByIDFn: func(ctx context.Context, id string) (*entity.PullRequest, error) {
+						return &entity.PullRequest{
+							ID:                id,
+							AuthorID:          "author",
+							Status:            entity.StatusOpen,
+							AssignedReviewers: []string{"r1", "r2"},
+						}, nil
+					},
+					getVerdictsFn: func(ctx context.Context, prID string) ([]*entity.ReviewVerdict, error) {
+						return []*entity.ReviewVerdict{
+							{UserID: "r1", Verdict: entity.VerdictApproved},
+							{UserID: "r2", Verdict: entity.VerdictChangesRequested},
+						}, nil
+					},
+				},
+				policyRepo: &mockPolicyRepo{},
+			})
+		},
+	}
+
+	uc := NewPullRequestUseCase(txManager, service.NewReviewerSelector())
+
+	changes := []struct {
+		name string
+		run  func() error
+	}{
+		{"reassign", func() error {
+			_, _, err := uc.Reassign(ctx, "pr1", "r2", false)
+			return err
+		}},
+		{"reassign to", func() error {
+			_, err := uc.ReassignTo(ctx, "pr1", "r2", "r3", false)
+			return err
+		}},
+		{"remove", func() error {
+			_, err := uc.RemoveReviewer(ctx, "pr1", "r2", false)
+			return err
+		}},
+		{"decline", func() error {
+			_, _, err := uc.Decline(ctx, "pr1", "r2", entity.DeclineOverloaded, "")
+			return err
+		}},
+	}
+
+	for _, tt := range changes {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.run(); !errors.Is(err, repository.ErrReviewerBlocking) {
+				t.Fatalf("%s error = %v, want %v", tt.name, err, repository.ErrReviewerBlocking)
+			}
+
+			// Запрос изменений остался в силе - merge без force заблокирован
+			if _, err := uc.Merge(ctx, "pr1", false); !errors.Is(err, repository.ErrChangesRequested) {
+				t.Errorf("Merge() error = %v, want %v", err, repository.ErrChangesRequested)
+			}
+		})
+	}
+}
@@ -0,0 +1,73 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"reviewer-service/internal/domain/entity"
+	"reviewer-service/internal/repository"
+)
+
+// SetWorkingHours задаёт часовой пояс и недельное расписание пользователя
+func (uc *UserUseCase) SetWorkingHours(
+	ctx context.Context,
+	userID string,
+	timezone string,
+	hours []entity.WorkingHours,
+) (*entity.User, error) {
+	if timezone == "" {
+		timezone = entity.DefaultTimezone
+	}
+	if err := entity.ValidateSchedule(timezone, hours); err != nil {
+		return nil, fmt.Errorf("%w: %v", repository.ErrInvalidSchedule, err)
+	}
+
+	var result *entity.User
+
+	err := uc.txManager.WithTx(ctx, func(tx repository.Tx) error {
+		if err := tx.Users().SetSchedule(ctx, userID, timezone, hours); err != nil {
+			return err
+		}
+
+		user, err := tx.Users().GetByID(ctx, userID)
+		if err != nil {
+			return err
+		}
+
+		result = user
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// GetAvailability показывает, находится ли пользователь в рабочих часах
+// в момент at (нулевое значение - сейчас по часам селектора)
+func (uc *UserUseCase) GetAvailability(ctx context.Context, userID string, at time.Time) (*entity.Availability, error) {
+	if at.IsZero() {
+		at = uc.selector.Now()
+	}
+
+	var result *entity.Availability
+
+	err := uc.txManager.WithTx(ctx, func(tx repository.Tx) error {
+		user, err := tx.Users().GetByID(ctx, userID)
+		if err != nil {
+			return err
+		}
+
+		result = user.AvailabilityAt(at)
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
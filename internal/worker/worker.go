@@ -0,0 +1,34 @@
+// Package worker запускает периодические фоновые задачи
+package worker
+
+import (
+	"context"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// Job - одна итерация фоновой задачи
+type Job func(ctx context.Context, now time.Time) error
+
+// Run выполняет job сразу и затем каждые interval, пока не отменён ctx.
+// Ошибка итерации логируется и не останавливает задачу.
+func Run(ctx context.Context, name string, interval time.Duration, job Job) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	log.Info().Str("job", name).Dur("interval", interval).Msg("background job started")
+
+	for {
+		if err := job(ctx, time.Now()); err != nil {
+			log.Error().Err(err).Str("job", name).Msg("background job failed")
+		}
+
+		select {
+		case <-ctx.Done():
+			log.Info().Str("job", name).Msg("background job stopped")
+			return
+		case <-ticker.C:
+		}
+	}
+}
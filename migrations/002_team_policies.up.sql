@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS team_policies (
+    team_name VARCHAR(255) PRIMARY KEY REFERENCES teams(team_name) ON DELETE CASCADE,
+    reviewer_count INTEGER NOT NULL DEFAULT 2 CHECK (reviewer_count >= 0),
+    min_active_members INTEGER NOT NULL DEFAULT 0 CHECK (min_active_members >= 0),
+    max_open_reviews INTEGER NOT NULL DEFAULT 0 CHECK (max_open_reviews >= 0),  -- 0 = без ограничения
+    strategy VARCHAR(50) NOT NULL DEFAULT '',  -- пусто = стратегия сервиса по умолчанию
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
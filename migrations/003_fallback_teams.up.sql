@@ -0,0 +1,9 @@
+ALTER TABLE team_policies
+    ADD COLUMN IF NOT EXISTS fallback_teams TEXT[] NOT NULL DEFAULT '{}';  -- в порядке приоритета
+
+ALTER TABLE pr_reviewers
+    ADD COLUMN IF NOT EXISTS source VARCHAR(20) NOT NULL DEFAULT 'TEAM';
+
+ALTER TABLE pr_reviewers DROP CONSTRAINT IF EXISTS pr_reviewers_source_check;
+ALTER TABLE pr_reviewers
+    ADD CONSTRAINT pr_reviewers_source_check CHECK (source IN ('TEAM', 'FALLBACK'));
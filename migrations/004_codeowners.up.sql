@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS team_codeowners (
+    team_name VARCHAR(255) PRIMARY KEY REFERENCES teams(team_name) ON DELETE CASCADE,
+    content TEXT NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+ALTER TABLE team_policies
+    ADD COLUMN IF NOT EXISTS ownership_mode VARCHAR(20) NOT NULL DEFAULT 'PREFER'
+    CHECK (ownership_mode IN ('OFF', 'PREFER', 'REQUIRE'));
+
+CREATE TABLE IF NOT EXISTS pr_files (
+    pull_request_id VARCHAR(255) NOT NULL REFERENCES pull_requests(pull_request_id) ON DELETE CASCADE,
+    path TEXT NOT NULL,
+    PRIMARY KEY (pull_request_id, path)
+);
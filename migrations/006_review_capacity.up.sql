@@ -0,0 +1,13 @@
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS max_open_reviews INTEGER CHECK (max_open_reviews > 0);  -- NULL = лимит команды
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS capacity_percent INTEGER NOT NULL DEFAULT 100
+    CHECK (capacity_percent BETWEEN 1 AND 100);  -- доля ставки
+
+-- PR, для которых не нашлось свободных ревьюверов, ждут в очереди
+ALTER TABLE pull_requests DROP CONSTRAINT IF EXISTS pull_requests_status_check;
+ALTER TABLE pull_requests
+    ADD CONSTRAINT pull_requests_status_check CHECK (status IN ('OPEN', 'MERGED', 'AWAITING_REVIEWERS'));
+
+CREATE INDEX IF NOT EXISTS idx_pr_awaiting ON pull_requests(created_at)
+    WHERE status = 'AWAITING_REVIEWERS';
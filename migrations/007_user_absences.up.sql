@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS user_absences (
+    id BIGSERIAL PRIMARY KEY,
+    user_id VARCHAR(255) NOT NULL REFERENCES users(user_id) ON DELETE CASCADE,
+    starts_at TIMESTAMPTZ NOT NULL,
+    ends_at TIMESTAMPTZ NOT NULL,
+    reason TEXT NOT NULL DEFAULT '',
+    status VARCHAR(20) NOT NULL DEFAULT 'SCHEDULED'
+        CHECK (status IN ('SCHEDULED', 'ACTIVE', 'FINISHED')),
+    deactivated BOOLEAN NOT NULL DEFAULT false,  -- is_active выключил планировщик, а не сам пользователь
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CHECK (ends_at > starts_at)
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_absences_user ON user_absences(user_id);
+CREATE INDEX IF NOT EXISTS idx_user_absences_pending ON user_absences(status, starts_at, ends_at)
+    WHERE status IN ('SCHEDULED', 'ACTIVE');
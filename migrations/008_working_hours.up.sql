@@ -0,0 +1,22 @@
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS timezone VARCHAR(64) NOT NULL DEFAULT 'UTC';
+
+-- Рабочие интервалы по дням недели в часовом поясе пользователя.
+-- Нет строк - пользователь доступен всегда.
+CREATE TABLE IF NOT EXISTS user_working_hours (
+    user_id VARCHAR(255) NOT NULL REFERENCES users(user_id) ON DELETE CASCADE,
+    weekday SMALLINT NOT NULL CHECK (weekday BETWEEN 0 AND 6),  -- 0 = воскресенье
+    start_time TIME NOT NULL,
+    end_time TIME NOT NULL,
+    PRIMARY KEY (user_id, weekday, start_time),
+    CHECK (end_time > start_time)
+);
+
+ALTER TABLE team_policies
+    ADD COLUMN IF NOT EXISTS prefer_working_hours BOOLEAN NOT NULL DEFAULT false;
+ALTER TABLE team_policies
+    ADD COLUMN IF NOT EXISTS prefer_within_hours INTEGER NOT NULL DEFAULT 0
+    CHECK (prefer_within_hours >= 0);
+ALTER TABLE team_policies
+    ADD COLUMN IF NOT EXISTS max_off_hours INTEGER NOT NULL DEFAULT 0
+    CHECK (max_off_hours >= 0);  -- 0 = не отсеивать
@@ -0,0 +1,10 @@
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS seniority VARCHAR(16) NOT NULL DEFAULT 'MIDDLE';
+ALTER TABLE users DROP CONSTRAINT IF EXISTS users_seniority_check;
+ALTER TABLE users
+    ADD CONSTRAINT users_seniority_check CHECK (seniority IN ('JUNIOR', 'MIDDLE', 'SENIOR'));
+
+ALTER TABLE team_policies
+    ADD COLUMN IF NOT EXISTS require_senior BOOLEAN NOT NULL DEFAULT false;
+ALTER TABLE team_policies
+    ADD COLUMN IF NOT EXISTS learning_reviewer BOOLEAN NOT NULL DEFAULT false;
@@ -0,0 +1,9 @@
+ALTER TABLE team_policies
+    ADD COLUMN IF NOT EXISTS anti_affinity_days INTEGER NOT NULL DEFAULT 0
+    CHECK (anti_affinity_days >= 0);  -- 0 = не учитывать историю пар
+ALTER TABLE team_policies
+    ADD COLUMN IF NOT EXISTS anti_affinity_weight INTEGER NOT NULL DEFAULT 0
+    CHECK (anti_affinity_weight >= 0);  -- 0 = вес по умолчанию
+
+-- История пар автор-ревьювер за окно
+CREATE INDEX IF NOT EXISTS idx_pr_reviewers_assigned ON pr_reviewers(assigned_at);
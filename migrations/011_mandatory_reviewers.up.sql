@@ -0,0 +1,12 @@
+-- Обязательные ревьюверы команды: на каждый PR или на PR с метками
+CREATE TABLE IF NOT EXISTS team_mandatory_reviewers (
+    team_name VARCHAR(255) NOT NULL REFERENCES teams(team_name) ON DELETE CASCADE,
+    user_id VARCHAR(255) NOT NULL REFERENCES users(user_id) ON DELETE CASCADE,
+    labels TEXT[] NOT NULL DEFAULT '{}',  -- пусто = на каждый PR
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (team_name, user_id)
+);
+
+ALTER TABLE pr_reviewers DROP CONSTRAINT IF EXISTS pr_reviewers_source_check;
+ALTER TABLE pr_reviewers
+    ADD CONSTRAINT pr_reviewers_source_check CHECK (source IN ('TEAM', 'FALLBACK', 'MANDATORY'));
@@ -0,0 +1,14 @@
+-- Пары, в которых reviewer_id не должен ревьюить PR author_id
+CREATE TABLE IF NOT EXISTS review_exclusions (
+    id BIGSERIAL PRIMARY KEY,
+    author_id VARCHAR(255) NOT NULL REFERENCES users(user_id) ON DELETE CASCADE,
+    reviewer_id VARCHAR(255) NOT NULL REFERENCES users(user_id) ON DELETE CASCADE,
+    both_ways BOOLEAN NOT NULL DEFAULT false,  -- запрет и в обратную сторону
+    reason TEXT NOT NULL DEFAULT '',
+    expires_at TIMESTAMPTZ,  -- NULL = бессрочно
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (author_id, reviewer_id),
+    CHECK (author_id <> reviewer_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_review_exclusions_reviewer ON review_exclusions(reviewer_id);
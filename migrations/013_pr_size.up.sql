@@ -0,0 +1,7 @@
+-- Размер PR для взвешенной нагрузки ревьюверов
+ALTER TABLE pull_requests
+    ADD COLUMN IF NOT EXISTS lines_added INTEGER NOT NULL DEFAULT 0 CHECK (lines_added >= 0);
+ALTER TABLE pull_requests
+    ADD COLUMN IF NOT EXISTS lines_removed INTEGER NOT NULL DEFAULT 0 CHECK (lines_removed >= 0);
+ALTER TABLE pull_requests
+    ADD COLUMN IF NOT EXISTS files_changed INTEGER NOT NULL DEFAULT 0 CHECK (files_changed >= 0);
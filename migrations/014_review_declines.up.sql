@@ -0,0 +1,13 @@
+-- Отказы ревьюверов от ревью с причиной, для замены и аналитики
+CREATE TABLE IF NOT EXISTS review_declines (
+    id BIGSERIAL PRIMARY KEY,
+    pull_request_id VARCHAR(255) NOT NULL REFERENCES pull_requests(pull_request_id) ON DELETE CASCADE,
+    user_id VARCHAR(255) NOT NULL REFERENCES users(user_id) ON DELETE CASCADE,
+    reason VARCHAR(32) NOT NULL CHECK (reason IN ('NO_CONTEXT', 'CONFLICT', 'OVERLOADED', 'OUT_OF_OFFICE')),
+    comment TEXT NOT NULL DEFAULT '',
+    replaced_by VARCHAR(255) NOT NULL REFERENCES users(user_id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_review_declines_pr ON review_declines(pull_request_id);
+CREATE INDEX IF NOT EXISTS idx_review_declines_user ON review_declines(user_id);
@@ -0,0 +1,3 @@
+-- Целевое число ревьюверов PR, чтобы добирать недостающих
+ALTER TABLE pull_requests
+    ADD COLUMN IF NOT EXISTS required_reviewers INTEGER NOT NULL DEFAULT 0 CHECK (required_reviewers >= 0);
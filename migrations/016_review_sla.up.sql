@@ -0,0 +1,29 @@
+-- SLA ревью и лестница эскалации в политике команды
+ALTER TABLE team_policies
+    ADD COLUMN IF NOT EXISTS review_sla_hours INTEGER NOT NULL DEFAULT 0
+    CHECK (review_sla_hours >= 0);  -- 0 = без SLA
+ALTER TABLE team_policies
+    ADD COLUMN IF NOT EXISTS escalation_step_hours INTEGER NOT NULL DEFAULT 0
+    CHECK (escalation_step_hours >= 0);  -- 0 = review_sla_hours
+ALTER TABLE team_policies
+    ADD COLUMN IF NOT EXISTS escalation_ladder TEXT[] NOT NULL DEFAULT '{}';  -- пусто = по умолчанию
+ALTER TABLE team_policies
+    ADD COLUMN IF NOT EXISTS team_lead_id VARCHAR(255) REFERENCES users(user_id) ON DELETE SET NULL;
+
+-- Сколько шагов эскалации сделано по назначению
+ALTER TABLE pr_reviewers
+    ADD COLUMN IF NOT EXISTS escalation_step INTEGER NOT NULL DEFAULT 0;
+
+-- Журнал эскалаций
+CREATE TABLE IF NOT EXISTS review_escalations (
+    id BIGSERIAL PRIMARY KEY,
+    pull_request_id VARCHAR(255) NOT NULL REFERENCES pull_requests(pull_request_id) ON DELETE CASCADE,
+    user_id VARCHAR(255) NOT NULL REFERENCES users(user_id) ON DELETE CASCADE,
+    action VARCHAR(16) NOT NULL CHECK (action IN ('NOTIFY', 'REASSIGN', 'ALERT_LEAD')),
+    step INTEGER NOT NULL CHECK (step > 0),
+    recipient_id VARCHAR(255) NOT NULL DEFAULT '',
+    replaced_by VARCHAR(255) NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_review_escalations_pr ON review_escalations(pull_request_id);
@@ -0,0 +1,15 @@
+-- Вердикт ревьювера по назначению
+ALTER TABLE pr_reviewers
+    ADD COLUMN IF NOT EXISTS verdict VARCHAR(32)
+    CHECK (verdict IN ('APPROVED', 'CHANGES_REQUESTED', 'COMMENTED'));
+ALTER TABLE pr_reviewers
+    ADD COLUMN IF NOT EXISTS verdict_at TIMESTAMPTZ;
+
+-- Сколько одобрений нужно для merge
+ALTER TABLE team_policies
+    ADD COLUMN IF NOT EXISTS required_approvals INTEGER NOT NULL DEFAULT 0
+    CHECK (required_approvals >= 0);  -- 0 = одобрения не требуются
+
+-- PR смержен администратором в обход одобрений
+ALTER TABLE pull_requests
+    ADD COLUMN IF NOT EXISTS merge_override BOOLEAN NOT NULL DEFAULT FALSE;
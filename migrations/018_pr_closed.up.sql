@@ -0,0 +1,7 @@
+-- Закрытые без merge PR
+ALTER TABLE pull_requests DROP CONSTRAINT IF EXISTS pull_requests_status_check;
+ALTER TABLE pull_requests
+    ADD CONSTRAINT pull_requests_status_check
+    CHECK (status IN ('OPEN', 'MERGED', 'AWAITING_REVIEWERS', 'CLOSED'));
+ALTER TABLE pull_requests
+    ADD COLUMN IF NOT EXISTS closed_at TIMESTAMPTZ;
@@ -0,0 +1,12 @@
+-- Черновики PR: ревьюверы назначаются, когда PR готов к ревью
+ALTER TABLE pull_requests DROP CONSTRAINT IF EXISTS pull_requests_status_check;
+ALTER TABLE pull_requests
+    ADD CONSTRAINT pull_requests_status_check
+    CHECK (status IN ('OPEN', 'MERGED', 'AWAITING_REVIEWERS', 'CLOSED', 'DRAFT'));
+
+-- NULL = черновик. DEFAULT отмечает готовыми PR, созданные до черновиков,
+-- и снимается сразу, чтобы новые строки задавали значение явно.
+ALTER TABLE pull_requests
+    ADD COLUMN IF NOT EXISTS ready_at TIMESTAMPTZ DEFAULT NOW();
+ALTER TABLE pull_requests
+    ALTER COLUMN ready_at DROP DEFAULT;
@@ -0,0 +1,20 @@
+-- Репозитории кода и команды, которые ревьюят их PR
+CREATE TABLE IF NOT EXISTS repositories (
+    name VARCHAR(255) PRIMARY KEY,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Команды-владельцы по порядку: первая основная, остальные резервные
+CREATE TABLE IF NOT EXISTS repository_teams (
+    repository VARCHAR(255) NOT NULL REFERENCES repositories(name) ON DELETE CASCADE,
+    team_name VARCHAR(255) NOT NULL REFERENCES teams(team_name) ON DELETE CASCADE,
+    position INTEGER NOT NULL,
+    PRIMARY KEY (repository, team_name)
+);
+
+-- Номер PR внутри репозитория
+ALTER TABLE pull_requests
+    ADD COLUMN IF NOT EXISTS number INTEGER CHECK (number > 0);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_pr_repository_number ON pull_requests(repository, number)
+    WHERE number IS NOT NULL;
@@ -0,0 +1,6 @@
+-- Отказ фиксируется и без замены: свободное место доберёт фоновая задача
+ALTER TABLE review_declines ALTER COLUMN replaced_by DROP NOT NULL;
+ALTER TABLE review_declines DROP CONSTRAINT IF EXISTS review_declines_replaced_by_fkey;
+ALTER TABLE review_declines
+    ADD CONSTRAINT review_declines_replaced_by_fkey
+    FOREIGN KEY (replaced_by) REFERENCES users(user_id) ON DELETE SET NULL;
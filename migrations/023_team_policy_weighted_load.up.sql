@@ -0,0 +1,3 @@
+-- Лимит нагрузки ревьювера с весами по размеру PR, отдельно от лимита открытых ревью
+ALTER TABLE team_policies
+    ADD COLUMN IF NOT EXISTS max_weighted_load INTEGER NOT NULL DEFAULT 0 CHECK (max_weighted_load >= 0);  -- 0 = без ограничения
@@ -0,0 +1,4 @@
+-- Сколько PR простоял закрытым после назначения ревьювера. SLA считается
+-- от assigned_at + paused, а само время назначения не меняется
+ALTER TABLE pr_reviewers
+    ADD COLUMN IF NOT EXISTS paused INTERVAL NOT NULL DEFAULT INTERVAL '0';
@@ -30,16 +30,20 @@ psql -h $DB_HOST -p $DB_PORT -U $DB_USER -tc "SELECT 1 FROM pg_database WHERE da
 
 # Apply migrations
 echo "Applying migrations..."
-if [ -f "/api/migrations/001_init.up.sql" ]; then
-    if psql -h $DB_HOST -p $DB_PORT -U $DB_USER -d $DB_NAME -f /api/migrations/001_init.up.sql; then
-        echo "Migrations applied successfully"
-    else
-        echo "Failed to apply migrations"
-        exit 1
-    fi
-else
-    echo "Migration file not found: /api/migrations/001_init.up.sql"
+shopt -s nullglob
+migrations=(/api/migrations/*.up.sql)
+if [ ${#migrations[@]} -eq 0 ]; then
+    echo "Migration files not found in /api/migrations"
     exit 1
 fi
 
+for migration in "${migrations[@]}"; do
+    echo "Applying $migration"
+    if ! psql -h $DB_HOST -p $DB_PORT -U $DB_USER -d $DB_NAME -f "$migration"; then
+        echo "Failed to apply migration $migration"
+        exit 1
+    fi
+done
+echo "Migrations applied successfully"
+
 echo "Database initialization completed!"